@@ -1,69 +1,136 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/hsoul/skconf/internal/ast"
-	"github.com/hsoul/skconf/internal/generator"
-	"github.com/hsoul/skconf/internal/generator/languages/lua"
-	"github.com/hsoul/skconf/internal/lexer"
-	"github.com/hsoul/skconf/internal/syntax"
+	"github.com/hsoul/skconf/pkg/skconf"
 )
 
+// optionFlags collects repeated -opt key=value flags.
+type optionFlags map[string]string
+
+func (o optionFlags) String() string { return fmt.Sprint(map[string]string(o)) }
+
+func (o optionFlags) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok {
+		val = "true"
+	}
+	o[key] = val
+	return nil
+}
+
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: dsl <input_file> <output_dir>")
+	targetOpts := optionFlags{}
+	target := flag.String("target", skconf.DefaultTarget, "code generation target")
+	flag.Var(targetOpts, "opt", "target option as key=value, may be repeated")
+	manifestFile := flag.String("manifest", "", "JSON API manifest describing the host modules")
+	loopBudget := flag.Int("loop-budget", 0, "fail loops not known to be bounded after this many passes; 0 for no limit")
+	trace := flag.Bool("trace", false, "print lexer tokens and parser errors while compiling")
+	flag.Usage = func() {
+		fmt.Println("Usage: dsl [-target lang] [-opt key=value]... [-manifest file] [-loop-budget n] [-trace] <input_file|input_dir> <output_dir>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	inputFile := os.Args[1]
-	outputDir := os.Args[2]
+	inputFile := flag.Arg(0)
+	outputDir := flag.Arg(1)
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		log.Fatalf("Error creating output directory: %v", err)
 	}
 
-	input, err := os.ReadFile(inputFile)
+	// A directory compiles every .dsl file in it; a file is read by name,
+	// so characters such as [ or * in it are not taken as a pattern.
+	var inputFS fs.FS
+	var sources []skconf.Source
+	info, err := os.Stat(inputFile)
 	if err != nil {
-		log.Fatalf("Error reading file: %v", err)
+		log.Fatalf("Error reading input: %v", err)
+	}
+	if info.IsDir() {
+		inputFS = os.DirFS(inputFile)
+		if sources, err = skconf.LoadFS(inputFS); err != nil {
+			log.Fatalf("Error reading input: %v", err)
+		}
+		if len(sources) == 0 {
+			log.Fatalf("Error reading input: no %s files in %s", skconf.Ext, inputFile)
+		}
+	} else {
+		inputFS = os.DirFS(filepath.Dir(inputFile))
+		content, err := fs.ReadFile(inputFS, filepath.Base(inputFile))
+		if err != nil {
+			log.Fatalf("Error reading input: %v", err)
+		}
+		sources = []skconf.Source{{Name: filepath.Base(inputFile), Content: content}}
 	}
 
-	l := lexer.New(string(input))
-	p := syntax.New(l, inputFile)
+	opts := skconf.Options{Target: *target, TargetOptions: targetOpts, ImportFS: inputFS, LoopBudget: *loopBudget}
+	if *trace {
+		opts.Trace = os.Stdout
+	}
+	if *manifestFile != "" {
+		data, err := os.ReadFile(*manifestFile)
+		if err != nil {
+			log.Fatalf("Error reading manifest: %v", err)
+		}
+		if opts.Manifest, err = skconf.ParseManifest(data); err != nil {
+			log.Fatalf("Error reading manifest: %v", err)
+		}
+	}
 
-	program := p.ParseProgram()
-	if len(p.Errors()) != 0 {
-		fmt.Printf("Parser errors:\n")
-		for _, msg := range p.Errors() {
-			fmt.Printf("\t%s\n", msg)
+	result, diags := skconf.Compile(context.Background(), sources, opts)
+	if result == nil {
+		fmt.Printf("Compile errors:\n")
+		for _, d := range diags {
+			fmt.Printf("\t%s\n", d)
+			for _, note := range d.Notes {
+				fmt.Printf("\t\t%s\n", note)
+			}
 		}
 		os.Exit(1)
 	}
-
-	astTree := ast.PrintTree(program)
-	astFile := filepath.Join(outputDir, "ast_tree.dot")
-	if err := os.WriteFile(astFile, []byte(astTree), 0644); err != nil {
-		log.Printf("Warning: Failed to write AST tree to file: %v", err)
+	for _, d := range diags {
+		fmt.Printf("%s\n", d)
+		for _, note := range d.Notes {
+			fmt.Printf("\t%s\n", note)
+		}
 	}
 
-	gen, err := generator.New(lua.Language)
-	if err != nil {
-		log.Fatalf("Error creating generator: %v", err)
-	}
-	output := gen.Generate(program)
+	fmt.Printf("Successfully generated:\n")
 
-	fileName := filepath.Base(inputFile)
-	baseName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
-	outputFile := filepath.Join(outputDir, baseName+".lua")
-	if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
-		log.Fatalf("Error writing output file: %v", err)
+	// The AST tree is written for a single file only, as it has one name.
+	if len(result.Files) == 1 {
+		astTree := result.Files[0].Tree()
+		astFile := filepath.Join(outputDir, "ast_tree.dot")
+		if err := os.WriteFile(astFile, []byte(astTree), 0644); err != nil {
+			log.Printf("Warning: Failed to write AST tree to file: %v", err)
+		}
+		fmt.Printf("- AST: %s\n", astFile)
 	}
 
-	fmt.Printf("Successfully generated:\n")
-	fmt.Printf("- AST: %s\n", astFile)
-	fmt.Printf("- Lua: %s\n", outputFile)
+	for _, file := range result.Files {
+		for _, out := range file.Outputs {
+			outputFile := filepath.Join(outputDir, filepath.FromSlash(out.Name))
+			if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+				log.Fatalf("Error creating output directory: %v", err)
+			}
+			if err := os.WriteFile(outputFile, out.Content, 0644); err != nil {
+				log.Fatalf("Error writing output file: %v", err)
+			}
+			fmt.Printf("- %s %s: %s\n", *target, out.Kind, outputFile)
+		}
+	}
 }
@@ -0,0 +1,360 @@
+// <auto-generated>
+// Generated by skconf. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+using System.Globalization;
+using System.Runtime.CompilerServices;
+using System.Text;
+
+namespace Skills.Test
+{
+    /// <summary>A DSL function value.</summary>
+    public delegate object DslFunc(params object[] args);
+
+    /// <summary>
+    /// Implemented by host values whose members DSL code reads and whose
+    /// methods it calls, such as the unit UE.GetTarget(t) returns.
+    /// </summary>
+    public interface IDslObject
+    {
+        object Member(string name);
+        object Invoke(string method, object[] args);
+    }
+
+    /// <summary>
+    /// A DSL table. Positional values live in Array, so index 1 is Array[0];
+    /// all other keys live in Hash.
+    /// </summary>
+    public sealed class DslTable
+    {
+        public readonly List<object> Array = new List<object>();
+        public readonly Dictionary<object, object> Hash = new Dictionary<object, object>();
+
+        public object Get(object key)
+        {
+            key = Dsl.Key(key);
+            if (key is long i && i >= 1 && i <= Array.Count)
+            {
+                return Array[(int)(i - 1)];
+            }
+            object value;
+            return key != null && Hash.TryGetValue(key, out value) ? value : null;
+        }
+
+        public void Set(object key, object value)
+        {
+            key = Dsl.Key(key);
+            if (key is long i && i >= 1)
+            {
+                if (i <= Array.Count)
+                {
+                    Array[(int)(i - 1)] = value;
+                    return;
+                }
+                if (i == Array.Count + 1 && value != null)
+                {
+                    Array.Add(value);
+                    return;
+                }
+            }
+            if (value == null)
+            {
+                Hash.Remove(key);
+                return;
+            }
+            Hash[key] = value;
+        }
+
+        /// <summary>Positional entries first, then the others ordered by their printed key.</summary>
+        public List<KeyValuePair<object, object>> Pairs()
+        {
+            var pairs = new List<KeyValuePair<object, object>>(Array.Count + Hash.Count);
+            for (int i = 0; i < Array.Count; i++)
+            {
+                pairs.Add(new KeyValuePair<object, object>((long)(i + 1), Array[i]));
+            }
+            var rest = new List<KeyValuePair<object, object>>(Hash);
+            rest.Sort((a, b) => string.CompareOrdinal(a.Key.ToString(), b.Key.ToString()));
+            pairs.AddRange(rest);
+            return pairs;
+        }
+    }
+
+    public static class Dsl
+    {
+        /// <summary>Creates a table; a positional value wins over an explicit key for the same index.</summary>
+        public static DslTable Table(object[] array, params object[] pairs)
+        {
+            var t = new DslTable();
+            for (int i = 0; i + 1 < pairs.Length; i += 2)
+            {
+                t.Set(pairs[i], pairs[i + 1]);
+            }
+            if (array != null)
+            {
+                for (int i = 0; i < array.Length; i++)
+                {
+                    t.Set((long)(i + 1), array[i]);
+                }
+            }
+            return t;
+        }
+
+        public static object Key(object key)
+        {
+            if (key is double d && d == Math.Truncate(d))
+            {
+                return (long)d;
+            }
+            return key;
+        }
+
+        public static bool Truthy(object v)
+        {
+            return v != null && !(v is bool b && !b);
+        }
+
+        public static object Arg(object[] args, int i)
+        {
+            return i < args.Length ? args[i] : null;
+        }
+
+        public static double ToDouble(object v)
+        {
+            if (v is long l) return l;
+            if (v is double d) return d;
+            throw new InvalidOperationException("attempt to use " + Describe(v) + " as a number");
+        }
+
+        public static long ToLong(object v)
+        {
+            if (v is long l) return l;
+            if (v is double d && d == Math.Truncate(d)) return (long)d;
+            throw new InvalidOperationException("attempt to use " + Describe(v) + " as an integer");
+        }
+
+        public static string ToStr(object v)
+        {
+            if (v is string s) return s;
+            throw new InvalidOperationException("attempt to use " + Describe(v) + " as a string");
+        }
+
+        /// <summary>Formats v as Lua's tostring does.</summary>
+        public static string Format(object v)
+        {
+            switch (v)
+            {
+                case null: return "nil";
+                case string s: return s;
+                case bool b: return b ? "true" : "false";
+                case long l: return l.ToString(CultureInfo.InvariantCulture);
+                case double d:
+                    if (double.IsPositiveInfinity(d)) return "inf";
+                    if (double.IsNegativeInfinity(d)) return "-inf";
+                    if (double.IsNaN(d)) return "nan";
+                    var text = d.ToString("G14", CultureInfo.InvariantCulture).ToLowerInvariant();
+                    return text.IndexOfAny(new[] { '.', 'e' }) < 0 ? text + ".0" : text;
+            }
+            return Describe(v) + ": " + RuntimeHelpers.GetHashCode(v).ToString("x8");
+        }
+
+        /// <summary>Joins the parts of an interpolated string.</summary>
+        public static string Concat(params object[] parts)
+        {
+            var sb = new StringBuilder();
+            foreach (var part in parts) sb.Append(Format(part));
+            return sb.ToString();
+        }
+
+        public static DslTable ToTable(object v)
+        {
+            if (v == null || v is DslTable) return (DslTable)v;
+            throw new InvalidOperationException("attempt to use " + Describe(v) + " as a table");
+        }
+
+        public static object Add(object a, object b)
+        {
+            if (a is long x && b is long y) return x + y;
+            return ToDouble(a) + ToDouble(b);
+        }
+
+        public static object Sub(object a, object b)
+        {
+            if (a is long x && b is long y) return x - y;
+            return ToDouble(a) - ToDouble(b);
+        }
+
+        public static object Mul(object a, object b)
+        {
+            if (a is long x && b is long y) return x * y;
+            return ToDouble(a) * ToDouble(b);
+        }
+
+        public static object Div(object a, object b)
+        {
+            return ToDouble(a) / ToDouble(b);
+        }
+
+        /// <summary>Implements %; as in Lua the result takes the sign of the divisor.</summary>
+        public static object Mod(object a, object b)
+        {
+            if (a is long x && b is long y)
+            {
+                if (y == 0) throw new InvalidOperationException("attempt to perform 'n%0'");
+                long m = x % y;
+                if (m != 0 && (m < 0) != (y < 0)) m += y;
+                return m;
+            }
+            double f = ToDouble(a), g = ToDouble(b);
+            double r = f % g;
+            if (r != 0 && (r < 0) != (g < 0)) r += g;
+            return r;
+        }
+
+        /// <summary>Implements //, division rounded towards minus infinity.</summary>
+        public static object IntDiv(object a, object b)
+        {
+            if (a is long x && b is long y)
+            {
+                if (y == 0) throw new InvalidOperationException("attempt to perform 'n//0'");
+                long q = x / y;
+                if (x % y != 0 && (x < 0) != (y < 0)) q--;
+                return q;
+            }
+            return Math.Floor(ToDouble(a) / ToDouble(b));
+        }
+
+        /// <summary>Implements ^, which always yields a double.</summary>
+        public static object Pow(object a, object b)
+        {
+            return Math.Pow(ToDouble(a), ToDouble(b));
+        }
+
+        public static object BAnd(object a, object b) { return ToLong(a) & ToLong(b); }
+        public static object BOr(object a, object b) { return ToLong(a) | ToLong(b); }
+        public static object BXor(object a, object b) { return ToLong(a) ^ ToLong(b); }
+        public static object BNot(object a) { return ~ToLong(a); }
+        public static object Shl(object a, object b) { return Shift(ToLong(a), ToLong(b)); }
+        public static object Shr(object a, object b) { return Shift(ToLong(a), -ToLong(b)); }
+
+        /// <summary>Shifts left by n bits, right when n is negative. As in Lua, shifts are logical and 64 bits or more yield 0.</summary>
+        static long Shift(long x, long n)
+        {
+            if (n <= -64 || n >= 64) return 0;
+            if (n >= 0) return (long)((ulong)x << (int)n);
+            return (long)((ulong)x >> (int)-n);
+        }
+
+        public static object Neg(object a)
+        {
+            if (a is long x) return -x;
+            return -ToDouble(a);
+        }
+
+        public static bool Eq(object a, object b)
+        {
+            if ((a is long || a is double) && (b is long || b is double))
+            {
+                return ToDouble(a) == ToDouble(b);
+            }
+            return Equals(a, b);
+        }
+
+        public static bool Less(object a, object b, bool orEqual)
+        {
+            if (a is string s)
+            {
+                int c = string.CompareOrdinal(s, ToStr(b));
+                return c < 0 || orEqual && c == 0;
+            }
+            double x = ToDouble(a), y = ToDouble(b);
+            return x < y || orEqual && x == y;
+        }
+
+        public static bool Greater(object a, object b, bool orEqual)
+        {
+            return Less(b, a, orEqual);
+        }
+
+        public static object And(object a, Func<object> b)
+        {
+            return Truthy(a) ? b() : a;
+        }
+
+        public static object Or(object a, Func<object> b)
+        {
+            return Truthy(a) ? a : b();
+        }
+
+        public static object Index(object v, object key)
+        {
+            if (v is DslTable t) return t.Get(key);
+            if (v is IDslObject o && key is string name) return o.Member(name);
+            throw new InvalidOperationException("attempt to index " + Describe(v));
+        }
+
+        /// <summary>Implements ?., which yields nil for a nil value.</summary>
+        public static object IndexOpt(object v, object key)
+        {
+            return v == null ? null : Index(v, key);
+        }
+
+        /// <summary>Runs f on v unless v is nil.</summary>
+        public static object IfNotNil(object v, Func<object, object> f)
+        {
+            return v == null ? null : f(v);
+        }
+
+        /// <summary>
+        /// Calls a method of a value returned by a host call. Members of
+        /// tables are plain functions and get no receiver.
+        /// </summary>
+        public static object Invoke(object v, string method, params object[] args)
+        {
+            if (v is IDslObject o) return o.Invoke(method, args);
+            if (v is DslTable t) return Call(t.Get(method), args);
+            throw new InvalidOperationException("attempt to call method " + method + " of " + Describe(v));
+        }
+
+        public static void SetIndex(object v, object key, object value)
+        {
+            if (v is DslTable t)
+            {
+                t.Set(key, value);
+                return;
+            }
+            throw new InvalidOperationException("attempt to index " + Describe(v));
+        }
+
+        /// <summary>Implements v[key] op= operand, evaluating v and key once.</summary>
+        public static void UpdateIndex(object v, object key, Func<object, object, object> op, object operand)
+        {
+            SetIndex(v, key, op(Index(v, key), operand));
+        }
+
+        public static object Call(object f, params object[] args)
+        {
+            if (f is DslFunc fn) return fn(args);
+            throw new InvalidOperationException("attempt to call " + Describe(f));
+        }
+
+        public static List<KeyValuePair<object, object>> Pairs(object v)
+        {
+            if (v is DslTable t) return t.Pairs();
+            throw new InvalidOperationException("attempt to range over " + Describe(v));
+        }
+
+        static string Describe(object v)
+        {
+            if (v == null) return "nil";
+            if (v is long || v is double) return "number";
+            if (v is string) return "string";
+            if (v is bool) return "boolean";
+            if (v is DslTable) return "table";
+            if (v is DslFunc) return "function";
+            return "userdata";
+        }
+    }
+}
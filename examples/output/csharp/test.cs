@@ -0,0 +1,212 @@
+// <auto-generated>
+// Generated by skconf from test.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.Test
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object AA(params object[] args);
+        object Do(params object[] args);
+        object PP(params object[] args);
+        object XM(params object[] args);
+        object XXX(params object[] args);
+        object YYY(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+        object AddState(params object[] args);
+        object BB(params object[] args);
+        object BV(params object[] args);
+        object CD(params object[] args);
+        object Do1(params object[] args);
+        object DoSomething(params object[] args);
+        object DoSomething1(params object[] args);
+        object DoSomething2(params object[] args);
+        object OI(params object[] args);
+        object OP(params object[] args);
+        object RE(params object[] args);
+        object SM(params object[] args);
+        object TY(params object[] args);
+        object UY(params object[] args);
+        object XX(params object[] args);
+    }
+
+    /// <summary>Top-level variables, statements and functions of test.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Skill ack_s</summary>
+    public sealed class AckS
+    {
+        public long Tid;
+        public double Ds;
+        public string Df;
+        public object Type;
+        public DslTable Ss;
+
+        public AckS(IHost host)
+        {
+            Tid = 1;
+            Ds = 1.5;
+            Df = "测试";
+            Type = Dsl.Index(host.Global("EM"), "Test");
+            Ss = Dsl.Table(new object[] { Dsl.Index(host.Global("EM"), "Test"), Dsl.Table(new object[] { 1L }) });
+        }
+
+        public object XX1(IHost host, object o)
+        {
+            object a = host.UE.AA(0L, 100L);
+            if (Dsl.Less(!Dsl.Truthy(a), 50L, false))
+            {
+                return false;
+            }
+            else if ((Dsl.Greater(a, 50L, false) && Dsl.Less(a, 70L, false)))
+            {
+                return true;
+            }
+            else if (Dsl.Greater(a, 6L, false))
+            {
+                return host.Global("ture");
+            }
+            else
+            {
+                return false;
+            }
+        }
+
+        public object XX2(IHost host, object o)
+        {
+            {
+                object i = 0L;
+                for (; Dsl.Less(i, 10L, false); i = Dsl.Add(i, 1L))
+                {
+                    host.UF.DoSomething1();
+                    host.UF.XX();
+                    host.UF.DoSomething2();
+                }
+            }
+            foreach (var dslPair in Dsl.Pairs(host.Global("units")))
+            {
+                object k = dslPair.Key;
+                object v = dslPair.Value;
+                host.UF.DoSomething();
+                host.UF.XX();
+            }
+            while (Dsl.Greater(host.UE.Do(0L, 100L), 50L, false))
+            {
+                host.UF.Do1();
+                host.UF.XX();
+                host.UF.Do1();
+            }
+            return null;
+        }
+
+        public object XX3(IHost host, object t)
+        {
+            if (Dsl.Truthy(host.UE.AA(t, 0.5D)))
+            {
+                host.UF.BB(t, 32L, 3L);
+            }
+            else
+            {
+                host.UF.BV(t, 2L, 3L);
+            }
+            return true;
+        }
+
+        public object XX4(IHost host, object t)
+        {
+            if (((Dsl.Truthy(host.UE.PP(t, 0.08D)) || Dsl.Truthy(host.UE.XXX(t, 4L))) && Dsl.Truthy(host.UE.YYY(t, 0.08D))))
+            {
+                host.UF.OP(t, 1L, 3L);
+            }
+            else
+            {
+                host.UF.CD(t, 1L, 3L);
+            }
+            if (Dsl.Greater(host.Global("a"), 5L, false))
+            {
+                return host.Global("fasle");
+            }
+            return null;
+        }
+
+        public object XX5(IHost host, object a)
+        {
+            if (Dsl.Truthy(host.UE.XM(host.Global("t"), 0.08D)))
+            {
+                host.UF.SM(host.Global("t"), 1L, 3L);
+            }
+            host.UF.OI(host.Global("t"), 30L);
+            host.UF.AddState(host.Global("t"), 1L, 2L);
+            object x = host.UF.UY(host.Global("t"), "x_");
+            host.UF.TY(host.Global("t"), Dsl.Mul(x, 0.3D));
+            host.UF.RE(host.Global("t"), 1L, 2L);
+            return null;
+        }
+    }
+
+    /// <summary>State sname</summary>
+    public sealed class Sname
+    {
+        public long Tid;
+        public DslTable Map;
+        public long Tt;
+        public long Xs;
+        public long Cc;
+
+        public Sname(IHost host)
+        {
+            Tid = 1;
+            Map = Dsl.Table(new object[] { 4L, 6L, 8L, 6.7D, Dsl.Table(null, "v", 5L), Dsl.Table(new object[] { 4L, 6L, 7L }) }, "id", 2L, "ss", 1L, 1L, 5L, "tsad", 45L, 6L, Dsl.Table(new object[] { 4L, 6L, 7L }), "testfun", new DslFunc(dslArgs =>
+            {
+                object p1 = Dsl.Arg(dslArgs, 0);
+                return null;
+            }));
+            Tt = 1;
+            Xs = 1;
+            Cc = 0;
+        }
+
+        public object YY1(IHost host, object unit)
+        {
+            return null;
+        }
+
+        public object YY2(IHost host, object unit)
+        {
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 1L, host => new AckS(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+            { 1L, host => new Sname(host) },
+        };
+    }
+}
@@ -0,0 +1,93 @@
+// <auto-generated>
+// Generated by skconf from test_assign.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestAssign
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object GetTarget(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_assign.dsl.</summary>
+    public static class Module
+    {
+        public static object hits;
+        public static object total;
+
+        public static void Init(IHost host)
+        {
+            Module.total = 0L;
+            Module.hits = Dsl.Table(new object[] { 0L, 0L, 0L });
+        }
+    }
+
+    /// <summary>Skill Combo</summary>
+    public sealed class Combo
+    {
+        public long Tid;
+
+        public Combo(IHost host)
+        {
+            Tid = 3001;
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            Module.total = Dsl.Add(Module.total, Dsl.Index(ctx, "damage"));
+            Dsl.UpdateIndex(Module.hits, Dsl.Index(ctx, "slot"), Dsl.Add, 1L);
+            Dsl.UpdateIndex(Dsl.Index(ctx, "target"), "hp", Dsl.Sub, Dsl.Mul(Dsl.Index(ctx, "damage"), 2L));
+            Dsl.UpdateIndex(host.UE.GetTarget(), "shield", Dsl.Div, 2L);
+            Dsl.UpdateIndex(ctx, "combo", Dsl.Add, 1L);
+            {
+                object i = 10L;
+                for (; Dsl.Greater(i, 0L, true); i = Dsl.Sub(i, 2L))
+                {
+                    Module.total = Dsl.Sub(Module.total, 1L);
+                }
+            }
+            object n = 0L;
+            {
+                n = 0L;
+                for (; Dsl.Less(n, 3L, false); n = Dsl.Add(n, 1L))
+                {
+                    if (Dsl.Eq(n, 1L))
+                    {
+                        continue;
+                    }
+                    Module.total = Dsl.Mul(Module.total, 2L);
+                }
+            }
+            Dsl.UpdateIndex(ctx, "left", Dsl.Sub, 1L);
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3001L, host => new Combo(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
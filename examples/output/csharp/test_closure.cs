@@ -0,0 +1,140 @@
+// <auto-generated>
+// Generated by skconf from test_closure.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestClosure
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Damage(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+        object After(params object[] args);
+        object ForEachTarget(params object[] args);
+        object Notify(params object[] args);
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_closure.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Skill chain_lightning</summary>
+    public sealed class ChainLightning
+    {
+        public long Tid;
+
+        public ChainLightning(IHost host)
+        {
+            Tid = 4201;
+        }
+
+        public object OnCast(IHost host, object ctx)
+        {
+            object hits = 0L;
+            object dmg = Dsl.Index(ctx, "damage");
+            host.UF.ForEachTarget(Dsl.Index(ctx, "target"), new DslFunc(dslArgs =>
+            {
+                object u = Dsl.Arg(dslArgs, 0);
+                hits = Dsl.Add(hits, 1L);
+                host.UE.Damage(u, dmg);
+                dmg = Dsl.Mul(dmg, 0.8D);
+                return null;
+            }));
+            host.UF.Notify(Dsl.Index(ctx, "caster"), Dsl.Concat("hits: ", hits));
+            return null;
+        }
+    }
+
+    /// <summary>Skill fork</summary>
+    public sealed class Fork
+    {
+        public long Tid;
+
+        public Fork(IHost host)
+        {
+            Tid = 4202;
+        }
+
+        public object OnCast(IHost host, object ctx)
+        {
+            object split = null;
+            split = new DslFunc(dslArgs =>
+            {
+                object t = Dsl.Arg(dslArgs, 0);
+                object n = Dsl.Arg(dslArgs, 1);
+                if (Dsl.Greater(n, 0L, false))
+                {
+                    host.UF.ForEachTarget(t, new DslFunc(dslArgs_1 =>
+                    {
+                        object u = Dsl.Arg(dslArgs_1, 0);
+                        Dsl.Call(split, u, Dsl.Sub(n, 1L));
+                        return null;
+                    }));
+                }
+                return null;
+            });
+            Dsl.Call(split, Dsl.Index(ctx, "target"), 3L);
+            return null;
+        }
+    }
+
+    /// <summary>Skill barrage</summary>
+    public sealed class Barrage
+    {
+        public long Tid;
+
+        public Barrage(IHost host)
+        {
+            Tid = 4203;
+        }
+
+        public object OnCast(IHost host, object ctx)
+        {
+            {
+                object i = 1L;
+                for (; Dsl.Less(i, 3L, true); i = Dsl.Add(i, 1L))
+                {
+                    object wave = i;
+                    host.UF.After(Dsl.Mul(wave, 500L), new DslFunc(dslArgs =>
+                    {
+                        host.UE.Damage(Dsl.Index(ctx, "target"), Dsl.Mul(wave, 10L));
+                        return null;
+                    }));
+                }
+            }
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 4201L, host => new ChainLightning(host) },
+            { 4202L, host => new Fork(host) },
+            { 4203L, host => new Barrage(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,88 @@
+// <auto-generated>
+// Generated by skconf from test_const.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestConst
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_const.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Skill Fireball</summary>
+    public sealed class Fireball
+    {
+        public long Tid;
+        public double Cd;
+        public double Reach;
+        public long Stacks;
+
+        public Fireball(IHost host)
+        {
+            Tid = 4001;
+            Cd = 2.0;
+            Reach = 12.0;
+            Stacks = 4;
+        }
+
+        public object OnCast(IHost host, object ctx)
+        {
+            if (Dsl.Less(Dsl.Index(ctx, "stacks"), 5L, false))
+            {
+                Dsl.SetIndex(ctx, "stacks", Dsl.Add(Dsl.Index(ctx, "stacks"), 1L));
+            }
+            return "Fireball";
+        }
+    }
+
+    /// <summary>Skill Meteor</summary>
+    public sealed class Meteor
+    {
+        public long Tid;
+        public double Cd;
+        public string Big;
+
+        public Meteor(IHost host)
+        {
+            Tid = 4002;
+            Cd = 3.0;
+            Big = "yes";
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 4001L, host => new Fireball(host) },
+            { 4002L, host => new Meteor(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,96 @@
+// <auto-generated>
+// Generated by skconf from test_doc.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestDoc
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_doc.dsl.</summary>
+    public static class Module
+    {
+        public static object split;
+
+        public static void Init(IHost host)
+        {
+            Module.split = new DslFunc(dslArgs =>
+            {
+                object damage = Dsl.Arg(dslArgs, 0);
+                object count = Dsl.Arg(dslArgs, 1);
+                return Dsl.Div(damage, count);
+            });
+        }
+    }
+
+    /// <summary>Fireball: hits every enemy in range.</summary>
+    public sealed class Fireball
+    {
+        /// <summary>Skill template id.</summary>
+        public long Tid;
+        public string Ds;
+        /// <summary>Damage by level.</summary>
+        public DslTable Damage;
+
+        public Fireball(IHost host)
+        {
+            Tid = 3001;
+            Ds = "fireball";
+            Damage = Dsl.Table(new object[] { 10L, 20L });
+        }
+
+        /// <summary>Called once per target hit.</summary>
+        public object OnHit(IHost host, object ctx, object target)
+        {
+            Dsl.UpdateIndex(target, "hp", Dsl.Sub, Dsl.Call(Module.split, Dsl.Index(ctx, "damage"), Dsl.Index(ctx, "count")));
+            return null;
+        }
+    }
+
+    /// <summary>Burning: loses hp every second.</summary>
+    public sealed class Burning
+    {
+        public long Tid;
+
+        public Burning(IHost host)
+        {
+            Tid = 5001;
+        }
+
+        public object OnTick(IHost host, object ctx)
+        {
+            Dsl.UpdateIndex(Dsl.Index(ctx, "target"), "hp", Dsl.Sub, 1L);
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3001L, host => new Fireball(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+            { 5001L, host => new Burning(host) },
+        };
+    }
+}
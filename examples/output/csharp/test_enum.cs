@@ -0,0 +1,80 @@
+// <auto-generated>
+// Generated by skconf from test_enum.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestEnum
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Damage elements.</summary>
+    public enum Element : long
+    {
+        Physical = 0,
+        /// <summary>Burns over time.</summary>
+        Fire = 10,
+        Ice = 11,
+        Holy = 100,
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_enum.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Skill FrostBolt</summary>
+    public sealed class FrostBolt
+    {
+        public long Tid;
+        public long Element;
+        public long Target;
+
+        public FrostBolt(IHost host)
+        {
+            Tid = 5001;
+            Element = 11;
+            Target = 3;
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            if ((Dsl.Eq(Dsl.Index(ctx, "element"), 10L) || Dsl.Eq(Dsl.Index(ctx, "element"), 0L)))
+            {
+                return 0L;
+            }
+            return Dsl.Index(ctx, "damage");
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 5001L, host => new FrostBolt(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,136 @@
+// <auto-generated>
+// Generated by skconf from test_extends.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestExtends
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Damage(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_extends.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Level 1 fireball.</summary>
+    public sealed class Fireball
+    {
+        public double Speed;
+        public double Cd;
+        public long Target;
+        public long Tid;
+        public double Ds;
+        public long Damage;
+
+        public Fireball(IHost host)
+        {
+            Speed = 12.0;
+            Cd = 1.0;
+            Target = 3;
+            Tid = 6001;
+            Ds = 1.5;
+            Damage = 100;
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            host.UE.Damage(Dsl.Index(ctx, "target"), Dsl.Index(ctx, "damage"));
+            return null;
+        }
+    }
+
+    /// <summary>Level 2 hits harder and faster.</summary>
+    public sealed class Fireball2
+    {
+        public double Speed;
+        public double Cd;
+        public long Target;
+        public long Tid;
+        public double Ds;
+        public long Damage;
+        public double Burn;
+
+        public Fireball2(IHost host)
+        {
+            Speed = 12.0;
+            Cd = 1.0;
+            Target = 3;
+            Tid = 6002;
+            Ds = 2.0;
+            Damage = 180;
+            Burn = 3.0;
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            host.UE.Damage(Dsl.Index(ctx, "target"), Dsl.Index(ctx, "damage"));
+            return null;
+        }
+    }
+
+    /// <summary>State burning</summary>
+    public sealed class Burning
+    {
+        public long Tid;
+        public double Duration;
+        public double Tick;
+
+        public Burning(IHost host)
+        {
+            Tid = 7001;
+            Duration = 3.0;
+            Tick = 1.0;
+        }
+    }
+
+    /// <summary>State burning_long</summary>
+    public sealed class BurningLong
+    {
+        public long Tid;
+        public double Duration;
+        public double Tick;
+
+        public BurningLong(IHost host)
+        {
+            Tid = 7002;
+            Duration = 6.0;
+            Tick = 1.0;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 6001L, host => new Fireball(host) },
+            { 6002L, host => new Fireball2(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+            { 7001L, host => new Burning(host) },
+            { 7002L, host => new BurningLong(host) },
+        };
+    }
+}
@@ -0,0 +1,85 @@
+// <auto-generated>
+// Generated by skconf from test_for.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestFor
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Do(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_for.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+            {
+                object i = 10L;
+                for (; Dsl.Greater(i, 1L, true); i = Dsl.Sub(i, 2L))
+                {
+                    Dsl.Call(host.Global("print"), i);
+                }
+            }
+            Dsl.Call(host.Global("print"), "----------------\n");
+            {
+                object i_1 = 1L;
+                for (; Dsl.Less(i_1, 10L, true); i_1 = Dsl.Add(i_1, 1L))
+                {
+                    Dsl.Call(host.Global("print"), i_1);
+                }
+            }
+            Dsl.Call(host.Global("print"), "----------------\n");
+            {
+                object i_2 = 0L;
+                for (; Dsl.Less(i_2, 10L, true); i_2 = Dsl.Add(i_2, 2L))
+                {
+                    Dsl.Call(host.Global("print"), i_2);
+                }
+            }
+            Dsl.Call(host.Global("print"), "----------------\n");
+            {
+                object i_3 = 10L;
+                for (; Dsl.Greater(i_3, 1L, false); i_3 = Dsl.Sub(i_3, 2L))
+                {
+                    Dsl.Call(host.Global("print"), i_3);
+                }
+            }
+            foreach (var dslPair in Dsl.Pairs(host.Global("units")))
+            {
+                object k = dslPair.Key;
+                object v = dslPair.Value;
+            }
+            while (Dsl.Greater(host.UE.Do(0L, 100L), 50L, false))
+            {
+            }
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,99 @@
+// <auto-generated>
+// Generated by skconf from test_func.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestFunc
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Damage(params object[] args);
+        object Do(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_func.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+            host.UE.Do(1L, 3L);
+        }
+
+        /// <summary>Limits x to the range [lo, hi].</summary>
+        public static object lib_common_clamp(IHost host, object x, object lo, object hi)
+        {
+            if (Dsl.Less(x, lo, false))
+            {
+                return lo;
+            }
+            if (Dsl.Greater(x, hi, false))
+            {
+                return hi;
+            }
+            return x;
+        }
+
+        /// <summary>Whether either flag is set while the skill is ready.</summary>
+        public static object ready(IHost host, object a, object b, object c)
+        {
+            if (((Dsl.Truthy(a) || Dsl.Truthy(b)) && Dsl.Truthy(c)))
+            {
+                return true;
+            }
+            return false;
+        }
+
+        /// <summary>The damage of a strike, with the combo bonus, at most 500.</summary>
+        public static object bonus(IHost host, object dmg)
+        {
+            return Module.lib_common_clamp(host, Dsl.Mul(dmg, 2L), 0L, 500L);
+        }
+    }
+
+    /// <summary>Skill strike</summary>
+    public sealed class Strike
+    {
+        public long Tid;
+
+        public Strike(IHost host)
+        {
+            Tid = 4101;
+        }
+
+        public object OnCast(IHost host, object ctx)
+        {
+            if (Dsl.Truthy(Module.ready(host, Dsl.Index(ctx, "silenced"), Dsl.Index(ctx, "stunned"), Dsl.Index(ctx, "cooled"))))
+            {
+                host.UE.Damage(Dsl.Index(ctx, "target"), Module.bonus(host, Dsl.Index(ctx, "damage")));
+            }
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 4101L, host => new Strike(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,76 @@
+// <auto-generated>
+// Generated by skconf from test_index.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestIndex
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_index.dsl.</summary>
+    public static class Module
+    {
+        public static object cfg;
+        public static object units;
+
+        public static void Init(IHost host)
+        {
+            Module.cfg = Dsl.Table(null, "key", "fire", "targets", Dsl.Table(new object[] { Dsl.Table(null, "hp", 100L), Dsl.Table(null, "hp", 80L) }));
+            Module.units = Dsl.Table(new object[] { 3L, 5L, 7L });
+        }
+    }
+
+    /// <summary>Skill index_s</summary>
+    public sealed class IndexS
+    {
+        public long Tid;
+
+        public IndexS(IHost host)
+        {
+            Tid = 3;
+        }
+
+        public object XX1(IHost host, object o)
+        {
+            {
+                object i = 1L;
+                for (; Dsl.Less(i, 3L, true); i = Dsl.Add(i, 1L))
+                {
+                    Dsl.SetIndex(Module.units, i, Dsl.Mul(Dsl.Index(Module.units, i), 2L));
+                }
+            }
+            Dsl.SetIndex(Module.cfg, "key", "ice");
+            Dsl.SetIndex(Dsl.Index(Dsl.Index(Module.cfg, "targets"), 1L), "hp", Dsl.Sub(Dsl.Index(Dsl.Index(Dsl.Index(Module.cfg, "targets"), 2L), "hp"), 10L));
+            return Dsl.Index(Dsl.Table(new object[] { 1L, 2L, 3L }), 2L);
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3L, host => new IndexS(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,126 @@
+// <auto-generated>
+// Generated by skconf from test_match.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestMatch
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Damage(params object[] args);
+        object Slow(params object[] args);
+        object Stance(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    public enum Element : long
+    {
+        Physical = 0,
+        Fire = 1,
+        Ice = 2,
+        Water = 3,
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_match.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Skill Conduct</summary>
+    public sealed class Conduct
+    {
+        public long Tid;
+        public long Element;
+
+        public Conduct(IHost host)
+        {
+            Tid = 6001;
+            Element = 2;
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            switch (Dsl.Key(Dsl.Index(ctx, "element")))
+            {
+                case 1L:
+                {
+                    return Dsl.Mul(Dsl.Index(ctx, "damage"), 2L);
+                }
+                case 2L:
+                case 3L:
+                {
+                    host.UE.Slow(Dsl.Index(ctx, "target"), 2.0D);
+                    break;
+                }
+                case 0L:
+                {
+                    break;
+                }
+            }
+            switch (Dsl.Key(host.UE.Stance(Dsl.Index(ctx, "target"))))
+            {
+                case "guard":
+                {
+                    return 0L;
+                }
+                default:
+                {
+                    return Dsl.Index(ctx, "damage");
+                }
+            }
+        }
+
+        public object OnTick(IHost host, object targets)
+        {
+            {
+                object i = 1L;
+                for (; Dsl.Less(i, 3L, true); i = Dsl.Add(i, 1L))
+                {
+                    object dslMatch = Dsl.Index(Dsl.Index(targets, i), "kind");
+                    if (Dsl.Eq(dslMatch, 0L))
+                    {
+                        continue;
+                    }
+                    else if (Dsl.Eq(dslMatch, 1L) || Dsl.Eq(dslMatch, 2L))
+                    {
+                        host.UE.Damage(Dsl.Index(targets, i), i);
+                    }
+                    else
+                    {
+                        break;
+                    }
+                }
+            }
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 6001L, host => new Conduct(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
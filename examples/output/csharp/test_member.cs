@@ -0,0 +1,73 @@
+// <auto-generated>
+// Generated by skconf from test_member.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestMember
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object GetTarget(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_member.dsl.</summary>
+    public static class Module
+    {
+        public static object cfg;
+        public static object list;
+
+        public static void Init(IHost host)
+        {
+            Module.cfg = Dsl.Table(null, "caster", Dsl.Table(null, "stats", Dsl.Table(null, "atk", 10L, "def", 4L)));
+            Module.list = Dsl.Table(new object[] { Module.cfg, Dsl.Index(Module.cfg, "caster") });
+        }
+    }
+
+    /// <summary>Skill member_s</summary>
+    public sealed class MemberS
+    {
+        public long Tid;
+
+        public MemberS(IHost host)
+        {
+            Tid = 4;
+        }
+
+        public object XX1(IHost host, object t)
+        {
+            object atk = Dsl.Index(Dsl.Index(Dsl.Index(Module.cfg, "caster"), "stats"), "atk");
+            object hp = Dsl.Invoke(host.UE.GetTarget(t), "Hp");
+            object buff = Dsl.IndexOpt(Dsl.IndexOpt(host.UE.GetTarget(t), "buff"), "level");
+            Dsl.IfNotNil(host.UE.GetTarget(t), dslRecv => Dsl.Invoke(dslRecv, "AddBuff", atk, hp));
+            Dsl.SetIndex(Dsl.Index(Dsl.Index(Module.cfg, "caster"), "stats"), "def", Dsl.Div(atk, 2L));
+            return Dsl.IndexOpt(Dsl.Index(Dsl.Index(Module.list, 2L), "stats"), "def");
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 4L, host => new MemberS(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,91 @@
+// <auto-generated>
+// Generated by skconf from test_number.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestNumber
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_number.dsl.</summary>
+    public static class Module
+    {
+        public static object all;
+        public static object half;
+        public static object large;
+        public static object mask;
+        public static object maxInt;
+        public static object million;
+        public static object minInt;
+        public static object ratio;
+        public static object small;
+        public static object wide;
+
+        public static void Init(IHost host)
+        {
+            Module.mask = 255L;
+            Module.wide = 4294967295L;
+            Module.all = -1L;
+            Module.million = 1000000L;
+            Module.small = 0.001D;
+            Module.large = 2.5e+10D;
+            Module.half = 0.5D;
+            Module.ratio = 1.0D;
+            Module.minInt = -9223372036854775808L;
+            Module.maxInt = 9223372036854775807L;
+        }
+    }
+
+    /// <summary>Skill Numbers</summary>
+    public sealed class Numbers
+    {
+        public long Tid;
+        public long Offset;
+        public double Scale;
+
+        public Numbers(IHost host)
+        {
+            Tid = 3100;
+            Offset = -5;
+            Scale = -0.25;
+        }
+
+        public object Run(IHost host, object x)
+        {
+            object a = -4.0D;
+            object b = 4.0D;
+            object c = Dsl.Sub(x, -1L);
+            object d = 3L;
+            return Dsl.Table(new object[] { a, b, c, d, Dsl.BAnd(x, Module.mask), Dsl.Mul(Module.million, Module.small) });
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3100L, host => new Numbers(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,73 @@
+// <auto-generated>
+// Generated by skconf from test_ops.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestOps
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_ops.dsl.</summary>
+    public static class Module
+    {
+        public static object flags;
+
+        public static void Init(IHost host)
+        {
+            Module.flags = 0L;
+        }
+    }
+
+    /// <summary>Skill Ops</summary>
+    public sealed class Ops
+    {
+        public long Tid;
+
+        public Ops(IHost host)
+        {
+            Tid = 3002;
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            object turn = Dsl.Mod(Dsl.Index(ctx, "turn"), 3L);
+            object half = Dsl.IntDiv(Dsl.Index(ctx, "hp"), 2L);
+            object crit = Dsl.Mul(Dsl.Pow(2L, Dsl.Index(ctx, "level")), 1.5D);
+            Module.flags = Dsl.BOr(Module.flags, Dsl.Shl(1L, Dsl.Index(ctx, "slot")));
+            if ((!Dsl.Eq(Dsl.BAnd(Module.flags, 4L), 0L) && Dsl.Less(Dsl.BXor(Dsl.BNot(Module.flags), 1L), 0L, false)))
+            {
+                Dsl.UpdateIndex(ctx, "hp", Dsl.Sub, half);
+            }
+            return Dsl.Table(new object[] { turn, crit, Dsl.Shr(Module.flags, 1L) });
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3002L, host => new Ops(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
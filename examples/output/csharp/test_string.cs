@@ -0,0 +1,67 @@
+// <auto-generated>
+// Generated by skconf from test_string.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestString
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_string.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Skill Greet</summary>
+    public sealed class Greet
+    {
+        public long Tid;
+        public string Ds;
+
+        public Greet(IHost host)
+        {
+            Tid = 3004;
+            Ds = "say \"hi\"\t❤ costs 100% $5";
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            object dmg = Dsl.Mul(Dsl.Index(ctx, "atk"), 2L);
+            Dsl.Call(host.Global("print"), Dsl.Concat("dealt ", dmg, " to ", Dsl.Index(Dsl.Index(ctx, "target"), "name"), "\n"));
+            Dsl.Call(host.Global("print"), Dsl.Concat((Dsl.Truthy(Dsl.Index(ctx, "crit")) ? (object)"crit" : "hit"), " x", Dsl.Index(ctx, "combo"), " ", Dsl.Concat("nested ", dmg)));
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3004L, host => new Greet(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,48 @@
+// <auto-generated>
+// Generated by skconf from test_table.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestTable
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_table.dsl.</summary>
+    public static class Module
+    {
+        public static object s1;
+
+        public static void Init(IHost host)
+        {
+            Module.s1 = Dsl.Table(null, "sd", Dsl.Table(new object[] { Dsl.Index(host.Global("EM"), "Test"), Dsl.Table(new object[] { 1L }) }), "tid", 1L, "name", "测试", "ss", Dsl.Table(null, 1L, Dsl.Table(new object[] { 1L, 2L, 3L }), 5L, Dsl.Table(new object[] { 1L, 5L, 4L })));
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
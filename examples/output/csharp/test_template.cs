@@ -0,0 +1,148 @@
+// <auto-generated>
+// Generated by skconf from test_template.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestTemplate
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+        object Damage(params object[] args);
+        object Notify(params object[] args);
+        object SetSpeed(params object[] args);
+    }
+
+    public enum Element : long
+    {
+        Fire = 1,
+        Ice = 2,
+        Poison = 3,
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_template.dsl.</summary>
+    public static class Module
+    {
+        public static object total;
+
+        public static void Init(IHost host)
+        {
+            Module.total = 5L;
+        }
+    }
+
+    /// <summary>State burning</summary>
+    public sealed class Burning
+    {
+        public double Interval;
+        public long Tid;
+        public double Duration;
+
+        public Burning(IHost host)
+        {
+            Interval = 1.0;
+            Tid = 7101;
+            Duration = 3.0;
+        }
+
+        public object Tick(IHost host, object t)
+        {
+            object total = Dsl.Mul(30L, Dsl.Index(t, "stacks"));
+            host.UF.Damage(t, 1L, total);
+            return null;
+        }
+    }
+
+    /// <summary>The dot reads the global total, not the local of the template.</summary>
+    public sealed class Frostbite
+    {
+        public double Interval;
+        public long Tid;
+
+        public Frostbite(IHost host)
+        {
+            Interval = 2.0;
+            Tid = 7102;
+        }
+
+        public object Tick(IHost host, object t)
+        {
+            object total__2 = Dsl.Mul(Module.total, Dsl.Index(t, "stacks"));
+            host.UF.Damage(t, 2L, total__2);
+            return null;
+        }
+
+        public object OnAdd(IHost host, object t)
+        {
+            host.UF.SetSpeed(t, 0.4D);
+            return null;
+        }
+
+        public object OnRemove(IHost host, object t)
+        {
+            host.UF.SetSpeed(t, 1.0D);
+            return null;
+        }
+    }
+
+    /// <summary>State venom</summary>
+    public sealed class Venom
+    {
+        public double Interval;
+        public long MaxStacks;
+        public long Tid;
+        public double Duration;
+
+        public Venom(IHost host)
+        {
+            Interval = 1.0;
+            MaxStacks = 5;
+            Tid = 7103;
+            Duration = 8.0;
+        }
+
+        public object Tick(IHost host, object t)
+        {
+            object total = Dsl.Mul(12L, Dsl.Index(t, "stacks"));
+            host.UF.Damage(t, 3L, total);
+            return null;
+        }
+
+        public object OnStack(IHost host, object t, object count)
+        {
+            if (Dsl.Greater(count, 5L, true))
+            {
+                host.UF.Notify(t, "max stacks");
+            }
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+            { 7101L, host => new Burning(host) },
+            { 7102L, host => new Frostbite(host) },
+            { 7103L, host => new Venom(host) },
+        };
+    }
+}
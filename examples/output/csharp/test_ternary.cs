@@ -0,0 +1,72 @@
+// <auto-generated>
+// Generated by skconf from test_ternary.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestTernary
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_ternary.dsl.</summary>
+    public static class Module
+    {
+        public static object bonus;
+
+        public static void Init(IHost host)
+        {
+            Module.bonus = 0L;
+        }
+    }
+
+    /// <summary>Skill Pick</summary>
+    public sealed class Pick
+    {
+        public long Tid;
+        public string Ds;
+
+        public Pick(IHost host)
+        {
+            Tid = 3003;
+            Ds = "melee";
+        }
+
+        public object OnHit(IHost host, object ctx)
+        {
+            object dmg = (Dsl.Truthy(Dsl.Index(ctx, "crit")) ? (object)Dsl.Mul(Dsl.Index(ctx, "atk"), 2L) : Dsl.Index(ctx, "atk"));
+            object shield = (Dsl.Truthy(Dsl.Index(Dsl.Index(ctx, "target"), "shield")) ? (object)Dsl.Index(Dsl.Index(ctx, "target"), "shield") : false);
+            object tier = (Dsl.Greater(dmg, 100L, false) ? (object)"high" : (Dsl.Greater(dmg, 50L, false) ? (object)"mid" : "low"));
+            Module.bonus = Dsl.Add(Module.bonus, ((Dsl.Truthy(Dsl.Index(ctx, "combo")) && Dsl.Greater(Dsl.Index(ctx, "combo"), 3L, false)) ? (object)10L : 0L));
+            Dsl.SetIndex(ctx, "flag", (Dsl.Truthy(Dsl.Index(ctx, "first")) ? (object)false : true));
+            return Dsl.Table(new object[] { dmg, shield, tier, Dsl.Index((Dsl.Truthy(Dsl.Index(ctx, "crit")) ? (object)Dsl.Index(ctx, "a") : Dsl.Index(ctx, "b")), "hp") });
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3003L, host => new Pick(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,88 @@
+// <auto-generated>
+// Generated by skconf from test_types.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestTypes
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Damage(params object[] args);
+        object Log(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_types.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+
+        /// <summary>The damage of a hit, scaled by ratio.</summary>
+        public static double scaled(IHost host, double dmg, double ratio)
+        {
+            return Dsl.ToDouble(Dsl.Mul(dmg, ratio));
+        }
+    }
+
+    /// <summary>Skill execute</summary>
+    public sealed class Execute
+    {
+        public long Tid;
+        public double Cooldown;
+
+        public Execute(IHost host)
+        {
+            Tid = 4201;
+            Cooldown = 8.0;
+        }
+
+        public bool OnHit(IHost host, object target, double ratio)
+        {
+            if (Dsl.Less(Dsl.Index(target, "hp"), 0.3D, false))
+            {
+                host.UE.Damage(target, Module.scaled(host, Dsl.ToDouble(Dsl.Index(target, "hp")), ratio));
+                return true;
+            }
+            return false;
+        }
+
+        public object OnTick(IHost host, double elapsed)
+        {
+            object left = new DslFunc(dslArgs =>
+            {
+                double total = Dsl.ToDouble(Dsl.Arg(dslArgs, 0));
+                return Dsl.ToDouble(Dsl.Sub(total, elapsed));
+            });
+            host.UE.Log(Dsl.Call(left, 8.0D));
+            return null;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 4201L, host => new Execute(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
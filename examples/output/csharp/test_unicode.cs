@@ -0,0 +1,72 @@
+// <auto-generated>
+// Generated by skconf from test_unicode.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestUnicode
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_unicode.dsl.</summary>
+    public static class Module
+    {
+        public static object 伤害;
+
+        public static void Init(IHost host)
+        {
+            Module.伤害 = 10L;
+        }
+    }
+
+    /// <summary>Skill 火球术</summary>
+    public sealed class 火球术
+    {
+        public long Tid;
+        public string Df;
+        public string 名字;
+
+        public 火球术(IHost host)
+        {
+            Tid = 3005;
+            Df = "测试";
+            名字 = "火球";
+        }
+
+        public object OnHit(IHost host, object ctx, object 目标)
+        {
+            object 倍率 = (Dsl.Truthy(Dsl.Index(Dsl.Index(ctx, "属性"), "暴击")) ? (object)2L : 1L);
+            Dsl.UpdateIndex(目标, "生命", Dsl.Sub, Dsl.Mul(Module.伤害, 倍率));
+            Dsl.Call(host.Global("print"), Dsl.Concat(Dsl.Index(目标, "名字"), " 受到 ", Dsl.Mul(Module.伤害, 倍率), " 点伤害"));
+            return Dsl.Table(null, "名字", Dsl.Index(目标, "名字"), "hp", Dsl.Index(目标, "生命"));
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3005L, host => new 火球术(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,83 @@
+// <auto-generated>
+// Generated by skconf from test_units.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestUnits
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Delay(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_units.dsl.</summary>
+    public static class Module
+    {
+        public static object crit;
+        public static object tick;
+
+        public static void Init(IHost host)
+        {
+            Module.tick = 0.1D;
+            Module.crit = 0.2D;
+        }
+    }
+
+    /// <summary>Skill Dash</summary>
+    public sealed class Dash
+    {
+        public long Tid;
+        public double Cd;
+        public double Cast;
+        public double Reach;
+        public double Width;
+
+        public Dash(IHost host)
+        {
+            Tid = 3200;
+            Cd = 1.5;
+            Cast = 0.3;
+            Reach = 5.0;
+            Width = 0.8;
+        }
+
+        public object OnCast(IHost host, object ctx)
+        {
+            object wait = 1.2D;
+            object step = 0.5D;
+            object bonus = Dsl.Mul(Dsl.Index(ctx, "damage"), Module.crit);
+            if ((Dsl.Greater(wait, 1.0D, true) && Dsl.Less(step, 4.5D, false)))
+            {
+                host.UE.Delay(Dsl.Add(wait, Module.tick));
+            }
+            return Dsl.Table(new object[] { wait, step, bonus, 1L, Dsl.Mul(Module.crit, 0.5D), -1.0D });
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 3200L, host => new Dash(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
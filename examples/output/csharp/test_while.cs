@@ -0,0 +1,83 @@
+// <auto-generated>
+// Generated by skconf from test_while.dsl. Do not edit.
+// </auto-generated>
+
+using System;
+using System.Collections.Generic;
+
+namespace Skills.TestWhile
+{
+    /// <summary>Gives generated code access to the game.</summary>
+    public interface IHost
+    {
+        IUEModule UE { get; }
+        IUFModule UF { get; }
+        /// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>
+        object Global(string name);
+    }
+
+    public interface IUEModule
+    {
+        object Damage(params object[] args);
+        object IsChanneling(params object[] args);
+        object Roll(params object[] args);
+        object Wait(params object[] args);
+    }
+
+    public interface IUFModule
+    {
+    }
+
+    /// <summary>Top-level variables, statements and functions of test_while.dsl.</summary>
+    public static class Module
+    {
+        public static void Init(IHost host)
+        {
+        }
+    }
+
+    /// <summary>Skill Channel</summary>
+    public sealed class Channel
+    {
+        public long Tid;
+
+        public Channel(IHost host)
+        {
+            Tid = 7001;
+        }
+
+        public object OnCast(IHost host, object ctx)
+        {
+            object stacks = 0L;
+            while ((Dsl.Less(stacks, Dsl.Index(ctx, "max_stacks"), false) && Dsl.Truthy(host.UE.IsChanneling())))
+            {
+                stacks = Dsl.Add(stacks, 1L);
+                host.UE.Wait(0.5D);
+            }
+            object tries = 0L;
+            do
+            {
+                tries = Dsl.Add(tries, 1L);
+                object roll = host.UE.Roll(100L);
+                if (Dsl.Less(roll, 20L, false))
+                {
+                    continue;
+                }
+                host.UE.Damage(Dsl.Index(ctx, "target"), roll);
+            } while (!(Dsl.Greater(tries, 3L, true) || Dsl.Less(Dsl.Index(Dsl.Index(ctx, "target"), "hp"), 0L, true)));
+            return stacks;
+        }
+    }
+
+    public static class Registry
+    {
+        public static readonly Dictionary<long, Func<IHost, object>> Skills = new Dictionary<long, Func<IHost, object>>
+        {
+            { 7001L, host => new Channel(host) },
+        };
+
+        public static readonly Dictionary<long, Func<IHost, object>> States = new Dictionary<long, Func<IHost, object>>
+        {
+        };
+    }
+}
@@ -0,0 +1,3 @@
+module github.com/hsoul/skconf/examples/output/go
+
+go 1.23.4
@@ -1,5 +1,5 @@
 -- Generated by DSL
--- 2024-12-31 11:16:01
+-- 2026-10-19 10:48:50
 
 local UE = RE
 local UF = FC
@@ -56,7 +56,7 @@ local ack_s = {
         return true
     end,
     XX4 = function(ctx, t)
-        if (UE.PP(ctx, t, 0.1) or UE.XXX(ctx, t, 4)) and UE.YYY(ctx, t, 0.1) then
+        if (UE.PP(ctx, t, 0.08) or UE.XXX(ctx, t, 4)) and UE.YYY(ctx, t, 0.08) then
             UF.OP(ctx, t, 1, 3)
         else
             UF.CD(ctx, t, 1, 3)
@@ -66,7 +66,7 @@ local ack_s = {
         end
     end,
     XX5 = function(ctx, a)
-        if UE.XM(ctx, t, 0.1) then
+        if UE.XM(ctx, t, 0.08) then
             UF.SM(ctx, t, 1, 3)
         end
         UF.OI(ctx, t, 30)
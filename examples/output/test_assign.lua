@@ -0,0 +1,49 @@
+-- Generated by DSL
+-- 2026-10-19 10:10:04
+
+local UE = RE
+local UF = FC
+
+local total = 0
+local hits = {
+    0,
+    0,
+    0
+}
+local Combo = {
+    tid = 3001,
+    OnHit = function(ctx)
+        total = total + ctx.damage
+        hits[ctx.slot] = hits[ctx.slot] + 1
+        ctx.target.hp = ctx.target.hp - ctx.damage * 2
+        do
+            local _obj = UE.GetTarget(ctx)
+            _obj.shield = _obj.shield / 2
+        end
+        ctx.combo = ctx.combo + 1
+        for i = 10, 0, -2 do
+            total = total - 1
+        end
+        local n = 0
+        do
+            n = 0
+            while n < 3 do
+                do
+                    if n == 1 then
+                        goto continue_1
+                    end
+                    total = total * 2
+                end
+                ::continue_1::
+                n = n + 1
+            end
+        end
+        ctx.left = ctx.left - 1
+    end
+}
+
+return {
+    skills = {
+        [3001] = Combo,
+    },
+}
\ No newline at end of file
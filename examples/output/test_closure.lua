@@ -0,0 +1,54 @@
+-- Generated by DSL
+-- 2026-10-19 11:43:36
+
+local UE = RE
+local UF = FC
+
+local chain_lightning = {
+    tid = 4201,
+    OnCast = function(ctx)
+        local hits = 0
+        local dmg = ctx.damage
+        UF.ForEachTarget(ctx, ctx.target, function(u)
+            hits = hits + 1
+            UE.Damage(ctx, u, dmg)
+            dmg = dmg * 0.8
+        end)
+        UF.Notify(ctx, ctx.caster, string.format("hits: %s", hits))
+    end
+}
+
+local fork = {
+    tid = 4202,
+    OnCast = function(ctx)
+        --- Forks into n bolts, each forking again into fewer.
+        local function split(t, n)
+            if n > 0 then
+                UF.ForEachTarget(ctx, t, function(u)
+                    split(u, n - 1)
+                end)
+            end
+        end
+        split(ctx.target, 3)
+    end
+}
+
+local barrage = {
+    tid = 4203,
+    OnCast = function(ctx)
+        for i = 1, 3 do
+            local wave = i
+            UF.After(ctx, wave * 500, function()
+                UE.Damage(ctx, ctx.target, wave * 10)
+            end)
+        end
+    end
+}
+
+return {
+    skills = {
+        [4201] = chain_lightning,
+        [4202] = fork,
+        [4203] = barrage,
+    },
+}
\ No newline at end of file
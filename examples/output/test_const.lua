@@ -0,0 +1,31 @@
+-- Generated by DSL
+-- 2026-10-19 12:37:27
+
+local UE = RE
+local UF = FC
+
+local Fireball = {
+    tid = 4001,
+    cd = 2.0,
+    reach = 12.0,
+    stacks = 4,
+    OnCast = function(ctx)
+        if ctx.stacks < 5 then
+            ctx.stacks = ctx.stacks + 1
+        end
+        return "Fireball"
+    end
+}
+
+local Meteor = {
+    tid = 4002,
+    cd = 3.0,
+    big = "yes"
+}
+
+return {
+    skills = {
+        [4001] = Fireball,
+        [4002] = Meteor,
+    },
+}
\ No newline at end of file
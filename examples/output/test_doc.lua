@@ -0,0 +1,45 @@
+-- Generated by DSL
+-- 2026-10-19 10:45:22
+
+local UE = RE
+local UF = FC
+
+--- Splits damage between the targets.
+---
+--- Returns the damage each target takes.
+local split = function(damage, count)
+    return damage / count
+end
+--- Fireball: hits every enemy in range.
+local Fireball = {
+    --- Skill template id.
+    tid = 3001,
+    ds = "fireball",
+    --- Damage by level.
+    damage = {
+        --- Level 1.
+        10,
+        20
+    },
+    --- Called once per target hit.
+    OnHit = function(ctx, target)
+        target.hp = target.hp - split(ctx.damage, ctx.count)
+    end
+}
+
+--- Burning: loses hp every second.
+local Burning = {
+    tid = 5001,
+    OnTick = function(ctx)
+        ctx.target.hp = ctx.target.hp - 1
+    end
+}
+
+return {
+    skills = {
+        [3001] = Fireball,
+    },
+    states = {
+        [5001] = Burning,
+    }
+}
\ No newline at end of file
@@ -0,0 +1,36 @@
+-- Generated by DSL
+-- 2026-10-19 12:37:28
+
+local UE = RE
+local UF = FC
+
+--- Damage elements.
+---@enum Element
+local Element = {
+    Physical = 0,
+    --- Burns over time.
+    Fire = 10,
+    Ice = 11,
+    Holy = 100
+}
+
+local FrostBolt = {
+    tid = 5001,
+    element = 11,
+    target = 3,
+    OnHit = function(ctx)
+        if ctx.element == 10 or ctx.element == 0 then
+            return 0
+        end
+        return ctx.damage
+    end
+}
+
+return {
+    enums = {
+        Element = Element,
+    },
+    skills = {
+        [5001] = FrostBolt,
+    },
+}
\ No newline at end of file
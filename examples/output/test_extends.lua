@@ -0,0 +1,55 @@
+-- Generated by DSL
+-- 2026-10-19 12:37:28
+
+local UE = RE
+local UF = FC
+
+--- Level 1 fireball.
+local fireball = {
+    speed = 12.0,
+    cd = 1.0,
+    target = 3,
+    OnHit = function(ctx)
+        UE.Damage(ctx, ctx.target, ctx.damage)
+    end,
+    tid = 6001,
+    ds = 1.5,
+    damage = 100
+}
+
+--- Level 2 hits harder and faster.
+local fireball_2 = {
+    speed = 12.0,
+    cd = 1.0,
+    target = 3,
+    OnHit = function(ctx)
+        UE.Damage(ctx, ctx.target, ctx.damage)
+    end,
+    tid = 6002,
+    ds = 2.0,
+    damage = 180,
+    burn = 3.0
+}
+
+local burning = {
+    tid = 7001,
+    duration = 3.0,
+    tick = 1.0
+}
+
+local burning_long = {
+    tid = 7002,
+    duration = 6.0,
+    tick = 1.0
+}
+
+return {
+    skills = {
+        [6001] = fireball,
+        [6002] = fireball_2,
+    },
+    states = {
+        [7001] = burning,
+        [7002] = burning_long,
+    }
+}
\ No newline at end of file
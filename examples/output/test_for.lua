@@ -1,5 +1,5 @@
 -- Generated by DSL
--- 2024-12-27 16:37:25
+-- 2026-10-19 10:23:34
 
 local UE = RE
 local UF = FC
@@ -7,15 +7,15 @@ local UF = FC
 for i = 10, 1, -2 do
     print(i)
 end
-print("----------------\\n")
+print("----------------\n")
 for i = 1, 10 do
     print(i)
 end
-print("----------------\\n")
+print("----------------\n")
 for i = 0, 10, 2 do
     print(i)
 end
-print("----------------\\n")
+print("----------------\n")
 do
     local i = 10
     while i > 1 do
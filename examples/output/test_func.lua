@@ -1,12 +1,47 @@
 -- Generated by DSL
--- 2024-12-31 10:30:23
+-- 2026-10-19 12:37:28
 
 local UE = RE
 local UF = FC
 
+local lib_common_clamp, ready, bonus
+
+--- Limits x to the range [lo, hi].
+function lib_common_clamp(x, lo, hi)
+    if x < lo then
+        return lo
+    end
+    if x > hi then
+        return hi
+    end
+    return x
+end
+
 UE.Do(ctx, 1, 3)
-function()
+--- Whether either flag is set while the skill is ready.
+function ready(a, b, c)
     if (a or b) and c then
         return true
     end
+    return false
 end
+
+local strike = {
+    tid = 4101,
+    OnCast = function(ctx)
+        if ready(ctx.silenced, ctx.stunned, ctx.cooled) then
+            UE.Damage(ctx, ctx.target, bonus(ctx.damage))
+        end
+    end
+}
+
+--- The damage of a strike, with the combo bonus, at most 500.
+function bonus(dmg)
+    return lib_common_clamp(dmg * 2, 0, 500)
+end
+
+return {
+    skills = {
+        [4101] = strike,
+    },
+}
\ No newline at end of file
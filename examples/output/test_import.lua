@@ -1,7 +1,8 @@
 -- Generated by DSL
--- 2024-12-27 16:46:12
+-- 2026-10-19 12:37:28
 
 local UE = RE
 local UF = FC
 
-require UE.CCC
+require("UE.CCC")
+
@@ -0,0 +1,43 @@
+-- Generated by DSL
+-- 2026-10-19 09:59:18
+
+local UE = RE
+local UF = FC
+
+local cfg = {
+    key = "fire",
+    targets = {
+        {
+            hp = 100
+        },
+        {
+            hp = 80
+        }
+    }
+}
+local units = {
+    3,
+    5,
+    7
+}
+local index_s = {
+    tid = 3,
+    XX1 = function(ctx, o)
+        for i = 1, 3 do
+            units[i] = units[i] * 2
+        end
+        cfg["key"] = "ice"
+        cfg.targets[1].hp = cfg.targets[2].hp - 10
+        return ({
+            1,
+            2,
+            3
+        })[2]
+    end
+}
+
+return {
+    skills = {
+        [3] = index_s,
+    },
+}
\ No newline at end of file
@@ -0,0 +1,61 @@
+-- Generated by DSL
+-- 2026-10-19 12:27:28
+
+local UE = RE
+local UF = FC
+
+---@enum Element
+local Element = {
+    Physical = 0,
+    Fire = 1,
+    Ice = 2,
+    Water = 3
+}
+
+local Conduct = {
+    tid = 6001,
+    element = 2,
+    OnHit = function(ctx)
+        do
+            local _match = ctx.element
+            if _match == 1 then
+                return ctx.damage * 2
+            elseif _match == 2 or _match == 3 then
+                UE.Slow(ctx, ctx.target, 2.0)
+            elseif _match == 0 then
+            end
+        end
+        do
+            local _match = UE.Stance(ctx, ctx.target)
+            if _match == "guard" then
+                return 0
+            else
+                return ctx.damage
+            end
+        end
+    end,
+    OnTick = function(targets)
+        for i = 1, 3 do
+            do
+                local _match = targets[i].kind
+                if _match == 0 then
+                    goto continue_1
+                elseif _match == 1 or _match == 2 then
+                    UE.Damage(ctx, targets[i], i)
+                else
+                    break
+                end
+            end
+            ::continue_1::
+        end
+    end
+}
+
+return {
+    enums = {
+        Element = Element,
+    },
+    skills = {
+        [6001] = Conduct,
+    },
+}
\ No newline at end of file
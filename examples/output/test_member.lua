@@ -0,0 +1,35 @@
+-- Generated by DSL
+-- 2026-10-19 10:02:03
+
+local UE = RE
+local UF = FC
+
+local cfg = {
+    caster = {
+        stats = {
+            atk = 10,
+            def = 4
+        }
+    }
+}
+local list = {
+    cfg,
+    cfg.caster
+}
+local member_s = {
+    tid = 4,
+    XX1 = function(ctx, t)
+        local atk = cfg.caster.stats.atk
+        local hp = UE.GetTarget(ctx, t):Hp()
+        local buff = (function(_recv) if _recv ~= nil then return _recv.level end end)((function(_recv) if _recv ~= nil then return _recv.buff end end)(UE.GetTarget(ctx, t)))
+        ;(function(_recv) if _recv ~= nil then return _recv:AddBuff(atk, hp) end end)(UE.GetTarget(ctx, t))
+        cfg.caster.stats.def = atk / 2
+        return (function(_recv) if _recv ~= nil then return _recv.def end end)(list[2].stats)
+    end
+}
+
+return {
+    skills = {
+        [4] = member_s,
+    },
+}
\ No newline at end of file
@@ -0,0 +1,41 @@
+-- Generated by DSL
+-- 2026-10-19 10:48:51
+
+local UE = RE
+local UF = FC
+
+local mask = 255
+local wide = 4294967295
+local all = -1
+local million = 1000000
+local small = 0.001
+local large = 2.5e+10
+local half = 0.5
+local ratio = 1.0
+local minInt = (-9223372036854775807 - 1)
+local maxInt = 9223372036854775807
+local Numbers = {
+    tid = 3100,
+    offset = -5,
+    scale = -0.25,
+    Run = function(x)
+        local a = -4.0
+        local b = 4.0
+        local c = x - -1
+        local d = 3
+        return {
+            a,
+            b,
+            c,
+            d,
+            x & mask,
+            million * small
+        }
+    end
+}
+
+return {
+    skills = {
+        [3100] = Numbers,
+    },
+}
\ No newline at end of file
@@ -0,0 +1,30 @@
+-- Generated by DSL
+-- 2026-10-19 10:16:46
+
+local UE = RE
+local UF = FC
+
+local flags = 0
+local Ops = {
+    tid = 3002,
+    OnHit = function(ctx)
+        local turn = ctx.turn % 3
+        local half = ctx.hp // 2
+        local crit = 2 ^ ctx.level * 1.5
+        flags = flags | 1 << ctx.slot
+        if flags & 4 ~= 0 and ~(flags) ~ 1 < 0 then
+            ctx.hp = ctx.hp - half
+        end
+        return {
+            turn,
+            crit,
+            flags >> 1
+        }
+    end
+}
+
+return {
+    skills = {
+        [3002] = Ops,
+    },
+}
\ No newline at end of file
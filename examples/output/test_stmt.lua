@@ -4,5 +4,5 @@
 local UE = RE
 local UF = FC
 
-local f1 = 3 + 5 * 6
-local f2 = 5 * 6 + 3
+local f1 = 33
+local f2 = 33
@@ -0,0 +1,21 @@
+-- Generated by DSL
+-- 2026-10-19 10:23:43
+
+local UE = RE
+local UF = FC
+
+local Greet = {
+    tid = 3004,
+    ds = "say \"hi\"\t❤ costs 100% $5",
+    OnHit = function(ctx)
+        local dmg = ctx.atk * 2
+        print(string.format("dealt %s to %s\n", dmg, ctx.target.name))
+        print(string.format("%s x%s %s", (ctx.crit and "crit" or "hit"), ctx.combo, string.format("nested %s", dmg)))
+    end
+}
+
+return {
+    skills = {
+        [3004] = Greet,
+    },
+}
\ No newline at end of file
@@ -0,0 +1,66 @@
+-- Generated by DSL
+-- 2026-10-19 12:37:29
+
+local UE = RE
+local UF = FC
+
+---@enum Element
+local Element = {
+    Fire = 1,
+    Ice = 2,
+    Poison = 3
+}
+
+local total = 5
+local burning = {
+    interval = 1.0,
+    tick = function(t)
+        local total = 30 * t.stacks
+        UF.Damage(ctx, t, 1, total)
+    end,
+    tid = 7101,
+    duration = 3.0
+}
+
+--- The dot reads the global total, not the local of the template.
+local frostbite = {
+    interval = 2.0,
+    tick = function(t)
+        local total__2 = total * t.stacks
+        UF.Damage(ctx, t, 2, total__2)
+    end,
+    OnAdd = function(t)
+        UF.SetSpeed(ctx, t, 0.4)
+    end,
+    OnRemove = function(t)
+        UF.SetSpeed(ctx, t, 1.0)
+    end,
+    tid = 7102
+}
+
+local venom = {
+    interval = 1.0,
+    tick = function(t)
+        local total = 12 * t.stacks
+        UF.Damage(ctx, t, 3, total)
+    end,
+    max_stacks = 5,
+    OnStack = function(t, count)
+        if count >= 5 then
+            UF.Notify(ctx, t, "max stacks")
+        end
+    end,
+    tid = 7103,
+    duration = 8.0
+}
+
+return {
+    enums = {
+        Element = Element,
+    },
+    states = {
+        [7101] = burning,
+        [7102] = frostbite,
+        [7103] = venom,
+    }
+}
\ No newline at end of file
@@ -0,0 +1,30 @@
+-- Generated by DSL
+-- 2026-10-19 10:18:52
+
+local UE = RE
+local UF = FC
+
+local bonus = 0
+local Pick = {
+    tid = 3003,
+    ds = "melee",
+    OnHit = function(ctx)
+        local dmg = (function() if ctx.crit then return ctx.atk * 2 else return ctx.atk end end)()
+        local shield = (function() if ctx.target.shield then return ctx.target.shield else return false end end)()
+        local tier = (dmg > 100 and "high" or (dmg > 50 and "mid" or "low"))
+        bonus = bonus + (ctx.combo and ctx.combo > 3 and 10 or 0)
+        ctx.flag = (function() if ctx.first then return false else return true end end)()
+        return {
+            dmg,
+            shield,
+            tier,
+            (function() if ctx.crit then return ctx.a else return ctx.b end end)().hp
+        }
+    end
+}
+
+return {
+    skills = {
+        [3003] = Pick,
+    },
+}
\ No newline at end of file
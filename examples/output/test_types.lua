@@ -0,0 +1,36 @@
+-- Generated by DSL
+-- 2026-10-19 11:51:04
+
+local UE = RE
+local UF = FC
+
+local scaled
+
+--- The damage of a hit, scaled by ratio.
+function scaled(dmg, ratio)
+    return dmg * ratio
+end
+
+local execute = {
+    tid = 4201,
+    cooldown = 8.0,
+    OnHit = function(target, ratio)
+        if target.hp < 0.3 then
+            UE.Damage(ctx, target, scaled(target.hp, ratio))
+            return true
+        end
+        return false
+    end,
+    OnTick = function(elapsed)
+        local left = function(total)
+            return total - elapsed
+        end
+        UE.Log(ctx, left(8.0))
+    end
+}
+
+return {
+    skills = {
+        [4201] = execute,
+    },
+}
\ No newline at end of file
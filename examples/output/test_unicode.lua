@@ -0,0 +1,27 @@
+-- Generated by DSL
+-- 2026-10-19 10:41:01
+
+local UE = RE
+local UF = FC
+
+local _u4F24_u5BB3 = 10
+local _u706B_u7403_u672F = {
+    tid = 3005,
+    df = "测试",
+    ["名字"] = "火球",
+    OnHit = function(ctx, _u76EE_u6807)
+        local _u500D_u7387 = (ctx["属性"]["暴击"] and 2 or 1)
+        _u76EE_u6807["生命"] = _u76EE_u6807["生命"] - _u4F24_u5BB3 * _u500D_u7387
+        print(string.format("%s 受到 %s 点伤害", _u76EE_u6807["名字"], _u4F24_u5BB3 * _u500D_u7387))
+        return {
+            ["名字"] = _u76EE_u6807["名字"],
+            hp = _u76EE_u6807["生命"]
+        }
+    end
+}
+
+return {
+    skills = {
+        [3005] = _u706B_u7403_u672F,
+    },
+}
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- Generated by DSL
+-- 2026-10-19 10:58:40
+
+local UE = RE
+local UF = FC
+
+local tick = 0.1
+local crit = 0.2
+local Dash = {
+    tid = 3200,
+    cd = 1.5,
+    cast = 0.3,
+    reach = 5.0,
+    width = 0.8,
+    OnCast = function(ctx)
+        local wait = 1.2
+        local step = 0.5
+        local bonus = ctx.damage * crit
+        if wait >= 1.0 and step < 4.5 then
+            UE.Delay(ctx, wait + tick)
+        end
+        return {
+            wait,
+            step,
+            bonus,
+            1,
+            crit * 0.5,
+            -1.0
+        }
+    end
+}
+
+return {
+    skills = {
+        [3200] = Dash,
+    },
+}
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- Generated by DSL
+-- 2026-10-19 12:09:18
+
+local UE = RE
+local UF = FC
+
+local Channel = {
+    tid = 7001,
+    OnCast = function(ctx)
+        local stacks = 0
+        while stacks < ctx.max_stacks and UE.IsChanneling(ctx) do
+            stacks = stacks + 1
+            UE.Wait(ctx, 0.5)
+        end
+        local tries = 0
+        repeat
+            do
+                tries = tries + 1
+                local roll = UE.Roll(ctx, 100)
+                if roll < 20 then
+                    goto continue_1
+                end
+                UE.Damage(ctx, ctx.target, roll)
+            end
+            ::continue_1::
+        until tries >= 3 or ctx.target.hp <= 0
+        return stacks
+    end
+}
+
+return {
+    skills = {
+        [7001] = Channel,
+    },
+}
\ No newline at end of file
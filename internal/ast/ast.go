@@ -1,3 +1,7 @@
+// Package ast defines the syntax tree the parser builds and the checker
+// rewrites for the generators. Declarations that can be documented have a
+// Doc field: the text of the "---" comments right before them, one line per
+// comment.
 package ast
 
 import "github.com/hsoul/skconf/internal/lexer"
@@ -5,6 +9,7 @@ import "github.com/hsoul/skconf/internal/lexer"
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() lexer.Position
 }
 
 type BaseNode struct {
@@ -18,3 +23,7 @@ func (b *BaseNode) TokenLiteral() string {
 func (b *BaseNode) String() string {
 	return b.Token.Literal
 }
+
+func (b *BaseNode) Pos() lexer.Position {
+	return b.Token.Pos
+}
@@ -0,0 +1,53 @@
+package ast
+
+import "reflect"
+
+// Clone returns a deep copy of the tree rooted at node. Nodes shared within
+// the tree, such as a FunctionDef.Name that is also a property key, stay
+// shared in the copy.
+func Clone[T Node](node T) T {
+	copies := map[reflect.Value]reflect.Value{}
+	return cloneValue(reflect.ValueOf(node), copies).Interface().(T)
+}
+
+func cloneValue(v reflect.Value, copies map[reflect.Value]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		if c, ok := copies[v]; ok {
+			return c
+		}
+		c := reflect.New(v.Elem().Type())
+		copies[v] = c
+		c.Elem().Set(cloneValue(v.Elem(), copies))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(cloneValue(v.Elem(), copies))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(cloneValue(v.Index(i), copies))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := range v.NumField() {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(cloneValue(v.Field(i), copies))
+			}
+		}
+		return c
+	}
+	return v
+}
@@ -2,8 +2,10 @@ package ast
 
 type PropertyDef struct {
 	BaseNode
-	Key   Expression
-	Value Expression
+	Key       Expression
+	Value     Expression
+	Doc       string
+	Overrides *PropertyDef // the inherited property this one replaces, set by the checker
 }
 
 type CodeBlock struct {
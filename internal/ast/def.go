@@ -1,13 +1,89 @@
 package ast
 
+// SkillDef is a skill. With extends, it starts from the properties of the
+// parent skill and overrides those it declares again; templates it uses add
+// their properties on top of the parent's. Properties holds the properties
+// as written and Merged, filled in by the checker, all of them.
 type SkillDef struct {
 	BaseNode
 	Name       *Identifier
+	Parent     Expression // name after extends, such as fireball or lib.common.fireball; nil if none
+	Uses       []*UseDecl
 	Properties []*PropertyDef
+	Merged     []*PropertyDef
+	Doc        string
 }
 
+// StateDef is a state; it extends other states as SkillDef does skills.
 type StateDef struct {
 	BaseNode
 	Name       *Identifier
+	Parent     Expression
+	Uses       []*UseDecl
 	Properties []*PropertyDef
+	Merged     []*PropertyDef
+	Doc        string
+}
+
+// MergedProperties returns the properties of s including inherited ones:
+// those of the parent and then of each template used, in their order, with
+// overridden ones in place, then the new ones. It is Properties until the program is checked.
+func (s *SkillDef) MergedProperties() []*PropertyDef {
+	if s.Merged != nil {
+		return s.Merged
+	}
+	return s.Properties
+}
+
+// MergedProperties returns the properties of s including inherited ones,
+// see SkillDef.MergedProperties.
+func (s *StateDef) MergedProperties() []*PropertyDef {
+	if s.Merged != nil {
+		return s.Merged
+	}
+	return s.Properties
+}
+
+// EnumDef declares named integers grouped under a type name, such as
+// enum Element { Fire = 1, Ice = 2 }. Uses are written Element.Fire, or
+// lib.common.Element.Fire in files that import this one.
+type EnumDef struct {
+	BaseNode
+	Name    *Identifier
+	Members []*EnumMember
+	Doc     string
+}
+
+// EnumMember is a member of an enum. A member without a value takes one
+// more than the member before it, or 0 if it is the first; once the
+// program is checked, Value is always an *Integer.
+type EnumMember struct {
+	BaseNode
+	Name  *Identifier
+	Value Expression
+	Doc   string
+}
+
+// TemplateDef declares properties with parameters that skills and states
+// can use, such as
+//
+//	template dot(element, dmg) { tick = func(t) { UF.Damage(t, element, dmg) } }
+//
+// It generates nothing by itself.
+type TemplateDef struct {
+	BaseNode
+	Name       *Identifier
+	Parameters []*Identifier
+	Properties []*PropertyDef
+	Doc        string
+}
+
+// UseDecl applies a template in a skill or state, such as
+// use dot(Element.Fire, 30). The checker fills in Expanded with a copy of
+// the properties of the template, its parameters replaced by the arguments.
+type UseDecl struct {
+	BaseNode
+	Template  Expression // name of the template, such as dot or lib.common.dot
+	Arguments []Expression
+	Expanded  []*PropertyDef
 }
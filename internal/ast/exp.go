@@ -18,6 +18,17 @@ type Float struct {
 type Integer struct {
 	BaseNode
 	Value int64
+	Enum  string // dotted name of the enum it is a member of, such as Element; set by the checker
+}
+
+// UnitLiteral is a number with a unit, such as 1.5s, 300ms, 5m or 20%.
+// Value is the number as written and Unit the unit name. The checker
+// replaces it with an Integer or Float in the runtime's base unit before
+// code generation.
+type UnitLiteral struct {
+	BaseNode
+	Value float64
+	Unit  string
 }
 
 type String struct {
@@ -25,17 +36,30 @@ type String struct {
 	Value string
 }
 
+// InterpolatedString is a string with ${...} parts such as
+// "dealt ${dmg} to ${target.name}". Parts holds the text, as *String, and
+// the interpolated expressions in source order; empty text is left out.
+type InterpolatedString struct {
+	BaseNode
+	Parts []Expression
+}
+
 type Boolean struct {
 	BaseNode
 	Value bool
 }
 
+// PrefixExpression applies a unary operator: - (negation), not, or ~
+// (bitwise not).
 type PrefixExpression struct {
 	BaseNode
 	Operator string
 	Right    Expression
 }
 
+// InfixExpression applies a binary operator. Besides the arithmetic,
+// comparison and logical operators these are % (floored modulo), ^ (power,
+// also written **), // (floored division) and the bitwise & | ~ << >>.
 type InfixExpression struct {
 	BaseNode
 	Left     Expression
@@ -43,10 +67,39 @@ type InfixExpression struct {
 	Right    Expression
 }
 
+// IsBitwise reports whether op is a bitwise operator. Their operands must
+// be integers.
+func IsBitwise(op string) bool {
+	switch op {
+	case "&", "|", "~", "<<", ">>":
+		return true
+	}
+	return false
+}
+
+// DotExpression is a member access such as ctx.caster or, with Optional
+// set, unit?.hp, which yields nil when Left is nil.
 type DotExpression struct {
+	BaseNode
+	Left     Expression
+	Right    Expression
+	Optional bool
+}
+
+// IndexExpression is an element access such as units[i] or cfg["key"].
+type IndexExpression struct {
 	BaseNode
 	Left  Expression
-	Right Expression
+	Index Expression
+}
+
+// ConditionalExpression is cond ? a : b. Only the chosen branch is
+// evaluated, and a falsy Consequence is still returned.
+type ConditionalExpression struct {
+	BaseNode
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
 }
 
 type FunctionCall struct {
@@ -59,5 +112,29 @@ type FunctionDef struct {
 	BaseNode
 	Name       Expression
 	Parameters []*Identifier
+	Types      []*Identifier // 参数类型，与 Parameters 对应 / annotated types of Parameters, nil entries for those without one; nil if none has one
+	Returns    *Identifier   // 返回类型 / annotated return type, or nil
 	Body       *CodeBlock
+	Doc        string
+
+	// 捕获的外层变量，由 sema 填写 / declarations of the variables of
+	// enclosing scopes it refers to, filled in by sema
+	Captures []*Identifier
+}
+
+// ParamType returns the annotated type of the i-th parameter of f, such as
+// "float" or "Unit", or "" if it has none.
+func (f *FunctionDef) ParamType(i int) string {
+	if i < len(f.Types) && f.Types[i] != nil {
+		return f.Types[i].Value
+	}
+	return ""
+}
+
+// ReturnType returns the annotated return type of f, or "" if it has none.
+func (f *FunctionDef) ReturnType() string {
+	if f.Returns != nil {
+		return f.Returns.Value
+	}
+	return ""
 }
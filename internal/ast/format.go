@@ -0,0 +1,84 @@
+package ast
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Format renders exp as DSL source, for messages. Nested operations are
+// parenthesized, so the text reads unambiguously whatever the source had;
+// function bodies and table contents are elided.
+func Format(exp Expression) string {
+	switch e := exp.(type) {
+	case nil:
+		return ""
+	case *Identifier:
+		return e.Value
+	case *Integer:
+		return strconv.FormatInt(e.Value, 10)
+	case *Float:
+		return strconv.FormatFloat(e.Value, 'g', -1, 64)
+	case *UnitLiteral:
+		return e.Token.Literal
+	case *String:
+		return strconv.Quote(e.Value)
+	case *InterpolatedString:
+		var sb strings.Builder
+		sb.WriteString(`"`)
+		for _, part := range e.Parts {
+			if s, ok := part.(*String); ok {
+				quoted := strconv.Quote(s.Value)
+				sb.WriteString(quoted[1 : len(quoted)-1])
+			} else {
+				sb.WriteString("${" + Format(part) + "}")
+			}
+		}
+		sb.WriteString(`"`)
+		return sb.String()
+	case *Boolean:
+		return strconv.FormatBool(e.Value)
+	case *TableDef:
+		if len(e.Properties) == 0 {
+			return "{}"
+		}
+		return "{...}"
+	case *FunctionDef:
+		params := make([]string, len(e.Parameters))
+		for i, param := range e.Parameters {
+			params[i] = param.Value
+		}
+		return "func(" + strings.Join(params, ", ") + ") {...}"
+	case *PrefixExpression:
+		if e.Operator == "not" {
+			return "not " + operand(e.Right)
+		}
+		return e.Operator + operand(e.Right)
+	case *InfixExpression:
+		return operand(e.Left) + " " + e.Operator + " " + operand(e.Right)
+	case *DotExpression:
+		if e.Optional {
+			return operand(e.Left) + "?." + Format(e.Right)
+		}
+		return operand(e.Left) + "." + Format(e.Right)
+	case *IndexExpression:
+		return operand(e.Left) + "[" + Format(e.Index) + "]"
+	case *ConditionalExpression:
+		return operand(e.Condition) + " ? " + operand(e.Consequence) + " : " + operand(e.Alternative)
+	case *FunctionCall:
+		args := make([]string, len(e.Arguments))
+		for i, arg := range e.Arguments {
+			args[i] = Format(arg)
+		}
+		return operand(e.Function) + "(" + strings.Join(args, ", ") + ")"
+	}
+	return exp.String()
+}
+
+// operand formats exp as the operand of another expression.
+func operand(exp Expression) string {
+	switch exp.(type) {
+	case *PrefixExpression, *InfixExpression, *ConditionalExpression, *FunctionDef:
+		return "(" + Format(exp) + ")"
+	}
+	return Format(exp)
+}
@@ -0,0 +1,39 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/lexer"
+	"github.com/hsoul/skconf/internal/syntax"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"f(1, x)", "f(1, x)"},
+		{"UE.Rand(1, 2)", "UE.Rand(1, 2)"},
+		{"a + b * c", "a + (b * c)"},
+		{"-(a + 1)", "-(a + 1)"},
+		{"not ok", "not ok"},
+		{"t?.hp", "t?.hp"},
+		{"units[i].hp", "units[i].hp"},
+		{"k ? 1.5 : \"x\"", "k ? 1.5 : \"x\""},
+		{"\"dealt ${dmg} to ${t.name}\"", "\"dealt ${dmg} to ${t.name}\""},
+		{"3s", "3s"},
+		{"{ 1, 2 }", "{...}"},
+		{"func(a, b) { return a }", "func(a, b) {...}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := syntax.New(lexer.New("var v = "+tt.input), "t.dsl")
+			program := p.ParseProgram()
+			if diags := p.Diagnostics(); len(diags) > 0 {
+				t.Fatal(diags)
+			}
+			exp := program.Statements[0].(*ast.VarStatement).Value
+			if got := ast.Format(exp); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package ast
 import (
 	"fmt"
 	"strings"
+
+	"github.com/hsoul/skconf/internal/lexer"
 )
 
 func PrintTree(node Node) string {
@@ -51,29 +53,42 @@ func nodeToString(node Node) string {
 	case *Program:
 		return fmt.Sprintf("Program { Imports: %d, Statements: %d }", len(n.Imports), len(n.Statements))
 	case *SkillDef:
-		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("SkillDef"))
-		return sb.String()
+		return withDoc(withParent("SkillDef", n.Parent), n.Doc)
 	case *StateDef:
-		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("StateDef"))
-		return sb.String()
+		return withDoc(withParent("StateDef", n.Parent), n.Doc)
+	case *EnumDef:
+		return withDoc("EnumDef", n.Doc)
+	case *TemplateDef:
+		return withDoc("TemplateDef", n.Doc)
+	case *UseDecl:
+		return fmt.Sprintf("UseDecl { Template: %s }", parentName(n.Template))
+	case *EnumMember:
+		return withDoc(fmt.Sprintf("EnumMember { Name: %s }", n.Name), n.Doc)
 	case *PropertyDef:
-		return fmt.Sprintf("PropertyDef { Key: %s }", n.Key)
+		return withDoc(fmt.Sprintf("PropertyDef { Key: %s }", n.Key), n.Doc)
 	case *FunctionDef:
-		params := make([]string, len(n.Parameters))
-		for i, p := range n.Parameters {
-			params[i] = p.Value
+		if len(n.Captures) > 0 {
+			names := make([]string, len(n.Captures))
+			for i, id := range n.Captures {
+				names[i] = id.Value
+			}
+			return withDoc(fmt.Sprintf("FunctionDef { Captures: %s }", strings.Join(names, ", ")), n.Doc)
 		}
-		return fmt.Sprintf("FunctionDef")
+		return withDoc("FunctionDef", n.Doc)
+	case *ConstStatement:
+		return withDoc("ConstStatement", n.Doc)
 	case *Identifier:
 		return fmt.Sprintf("Identifier { Value: %s }", n.Value)
 	case *Integer:
 		return fmt.Sprintf("Integer { Value: %d }", n.Value)
 	case *Float:
 		return fmt.Sprintf("Float { Value: %f }", n.Value)
+	case *UnitLiteral:
+		return fmt.Sprintf("UnitLiteral { Value: %g, Unit: %s }", n.Value, n.Unit)
 	case *String:
 		return fmt.Sprintf("String { Value: %s }", n.Value)
+	case *InterpolatedString:
+		return fmt.Sprintf("InterpolatedString { Parts: %d }", len(n.Parts))
 	case *Boolean:
 		return fmt.Sprintf("Boolean { Value: %v }", n.Value)
 	case *CodeBlock:
@@ -82,6 +97,13 @@ func nodeToString(node Node) string {
 		return "IfStatement"
 	case *ReturnStatement:
 		return "ReturnStatement"
+	case *MatchStatement:
+		return fmt.Sprintf("MatchStatement { Arms: %d }", len(n.Arms))
+	case *MatchArm:
+		if n.Patterns == nil {
+			return "MatchArm (default)"
+		}
+		return "MatchArm"
 	case *ImportStatement:
 		return fmt.Sprintf("ImportStatement { Path: %s }", n.Value)
 	case *CommentStatement:
@@ -92,8 +114,17 @@ func nodeToString(node Node) string {
 		return fmt.Sprintf("PrefixExpression")
 	case *ExprStmt:
 		return "ExprStmt"
+	case *AssignStatement:
+		return fmt.Sprintf("AssignStatement { Operator: %s }", n.Operator)
 	case *DotExpression:
+		if n.Optional {
+			return "DotExpression (optional)"
+		}
 		return "DotExpression"
+	case *IndexExpression:
+		return "IndexExpression"
+	case *ConditionalExpression:
+		return "ConditionalExpression"
 	case *FunctionCall:
 		return fmt.Sprintf("FunctionCall { Args: %d }", len(n.Arguments))
 	case *labeledNode:
@@ -103,11 +134,23 @@ func nodeToString(node Node) string {
 			return fmt.Sprintf("ForStatement (Range)")
 		}
 		return fmt.Sprintf("ForStatement (Classic)")
+	case *WhileStatement:
+		return "WhileStatement"
+	case *RepeatStatement:
+		return "RepeatStatement"
 	default:
 		return fmt.Sprintf("%T", node)
 	}
 }
 
+// withDoc appends the doc comment of a definition to its description.
+func withDoc(s, doc string) string {
+	if doc == "" {
+		return s
+	}
+	return fmt.Sprintf("%s (doc: %q)", s, doc)
+}
+
 func getChildren(node Node) []Node {
 	if labeled, ok := node.(*labeledNode); ok {
 		return getChildren(labeled.node)
@@ -125,17 +168,32 @@ func getChildren(node Node) []Node {
 		}
 	case *SkillDef:
 		children = append(children, &labeledNode{"name", n.Name})
-		for _, prop := range n.Properties {
-			if prop.Key != nil {
-				children = append(children, &labeledNode{fmt.Sprintf("property '%s'", prop.Key), prop.Value})
-			} else {
-				children = append(children, &labeledNode{fmt.Sprintf("property "), prop.Value})
-			}
-		}
+		children = append(children, useChildren(n.Uses)...)
+		children = append(children, propertyChildren(n.Parent, n.Uses, n.Properties, n.MergedProperties())...)
 	case *StateDef:
 		children = append(children, &labeledNode{"name", n.Name})
+		children = append(children, useChildren(n.Uses)...)
+		children = append(children, propertyChildren(n.Parent, n.Uses, n.Properties, n.MergedProperties())...)
+	case *TemplateDef:
+		children = append(children, &labeledNode{"name", n.Name})
+		for _, param := range n.Parameters {
+			children = append(children, &labeledNode{"parameter", param})
+		}
 		for _, prop := range n.Properties {
-			children = append(children, &labeledNode{fmt.Sprintf("property '%s'", prop.Key), prop.Value})
+			children = append(children, prop)
+		}
+	case *UseDecl:
+		for _, arg := range n.Arguments {
+			children = append(children, &labeledNode{"argument", arg})
+		}
+	case *EnumDef:
+		children = append(children, &labeledNode{"name", n.Name})
+		for _, member := range n.Members {
+			children = append(children, &labeledNode{"member", member})
+		}
+	case *EnumMember:
+		if n.Value != nil {
+			children = append(children, &labeledNode{"value", n.Value})
 		}
 	case *PropertyDef:
 		children = append(children, &labeledNode{"key", n.Key})
@@ -175,6 +233,18 @@ func getChildren(node Node) []Node {
 		if n.ReturnValue != nil {
 			children = append(children, &labeledNode{"value", n.ReturnValue})
 		}
+	case *MatchStatement:
+		children = append(children, &labeledNode{"subject", n.Subject})
+		for _, arm := range n.Arms {
+			children = append(children, arm)
+		}
+	case *MatchArm:
+		for _, pattern := range n.Patterns {
+			children = append(children, &labeledNode{"pattern", pattern})
+		}
+		if n.Body != nil {
+			children = append(children, &labeledNode{"body", n.Body})
+		}
 	case *FunctionCall:
 		children = append(children, &labeledNode{"function", n.Function})
 		compositionNode := &compositionNode{nodes: make([]Node, 0)}
@@ -187,8 +257,24 @@ func getChildren(node Node) []Node {
 	case *DotExpression:
 		children = append(children, &labeledNode{"left", n.Left})
 		children = append(children, &labeledNode{"right", n.Right})
+	case *IndexExpression:
+		children = append(children, &labeledNode{"left", n.Left})
+		children = append(children, &labeledNode{"index", n.Index})
+	case *InterpolatedString:
+		for _, part := range n.Parts {
+			children = append(children, part)
+		}
+	case *ConditionalExpression:
+		children = append(children, &labeledNode{"condition", n.Condition})
+		children = append(children, &labeledNode{"then", n.Consequence})
+		children = append(children, &labeledNode{"else", n.Alternative})
 	case *ExprStmt:
 		children = append(children, &labeledNode{"expression", n.Expression})
+	case *AssignStatement:
+		children = append(children, &labeledNode{"target", n.Target})
+		if n.Value != nil {
+			children = append(children, &labeledNode{"value", n.Value})
+		}
 	case *TableDef:
 		for _, prop := range n.Properties {
 			if prop.Key != nil {
@@ -222,6 +308,12 @@ func getChildren(node Node) []Node {
 		if n.Body != nil {
 			children = append(children, &labeledNode{"body", n.Body})
 		}
+	case *WhileStatement:
+		children = append(children, &labeledNode{"condition", n.Condition})
+		children = append(children, &labeledNode{"body", n.Body})
+	case *RepeatStatement:
+		children = append(children, &labeledNode{"body", n.Body})
+		children = append(children, &labeledNode{"until", n.Condition})
 	case *PrefixExpression:
 		children = append(children, &labeledNode{fmt.Sprintf("operator '%s'", n.Operator), nil})
 		children = append(children, &labeledNode{"right", n.Right})
@@ -230,6 +322,9 @@ func getChildren(node Node) []Node {
 	case *VarStatement:
 		children = append(children, &labeledNode{fmt.Sprintf("var"), n.Name})
 		children = append(children, &labeledNode{fmt.Sprintf("exp"), n.Value})
+	case *ConstStatement:
+		children = append(children, &labeledNode{"const", n.Name})
+		children = append(children, &labeledNode{"exp", n.Value})
 	case *labeledNode:
 		children = append(children, n.node)
 	case *compositionNode:
@@ -246,6 +341,7 @@ type labeledNode struct {
 }
 
 func (n *labeledNode) TokenLiteral() string { return n.node.TokenLiteral() }
+func (n *labeledNode) Pos() lexer.Position  { return n.node.Pos() }
 func (n *labeledNode) String() string {
 	if n.node == nil {
 		return fmt.Sprintf("%s", n.label)
@@ -257,3 +353,63 @@ type compositionNode struct {
 	BaseNode
 	nodes []Node
 }
+
+func withParent(label string, parent Expression) string {
+	if parent == nil {
+		return label
+	}
+	return fmt.Sprintf("%s { Extends: %s }", label, parentName(parent))
+}
+
+func parentName(parent Expression) string {
+	name, _ := QualifiedName(parent)
+	return name
+}
+
+func useChildren(uses []*UseDecl) []Node {
+	var children []Node
+	for _, use := range uses {
+		children = append(children, &labeledNode{"use", use})
+	}
+	return children
+}
+
+// propertyChildren lists the merged properties of a definition, marking
+// which ones it inherits from parent or gets from a template and which ones
+// it overrides.
+func propertyChildren(parent Expression, uses []*UseDecl, own, merged []*PropertyDef) []Node {
+	declared := map[*PropertyDef]bool{}
+	for _, prop := range own {
+		declared[prop] = true
+	}
+	template := map[*PropertyDef]string{}
+	for _, use := range uses {
+		for _, prop := range use.Expanded {
+			template[prop] = parentName(use.Template)
+		}
+	}
+	origin := func(prop *PropertyDef) string {
+		if name, ok := template[prop]; ok {
+			return "template " + name
+		}
+		return parentName(parent)
+	}
+
+	var children []Node
+	for _, prop := range merged {
+		label := "property "
+		if prop.Key != nil {
+			label = fmt.Sprintf("property '%s'", prop.Key)
+		}
+		switch {
+		case template[prop] != "":
+			label += fmt.Sprintf(" (from %s)", origin(prop))
+		case !declared[prop]:
+			label += fmt.Sprintf(" (inherited from %s)", origin(prop))
+		case prop.Overrides != nil:
+			label += fmt.Sprintf(" (overrides %s)", origin(prop.Overrides))
+		}
+		children = append(children, &labeledNode{label, prop.Value})
+	}
+	return children
+}
@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/hsoul/skconf/internal/lexer"
+
 type Program struct {
 	Imports    []ImportStatement
 	Statements []Statement
@@ -7,6 +9,8 @@ type Program struct {
 
 func (p *Program) TokenLiteral() string { return "" }
 
+func (p *Program) Pos() lexer.Position { return lexer.Position{Line: 1} }
+
 func (p *Program) String() string {
 	var out string
 	for _, imp := range p.Imports {
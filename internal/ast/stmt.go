@@ -1,5 +1,10 @@
 package ast
 
+import (
+	"slices"
+	"strings"
+)
+
 var _ Node = (*ImportStatement)(nil)
 var _ Statement = (*ImportStatement)(nil)
 
@@ -8,9 +13,35 @@ type ExprStmt struct {
 	Expression Expression
 }
 
+// AssignStatement assigns to a variable, a member or an element. Operator
+// is one of = += -= *= /= ++ --; Value is nil for ++ and --.
+type AssignStatement struct {
+	BaseNode
+	Target   Expression
+	Operator string
+	Value    Expression
+}
+
+// Update returns the arithmetic an updating assignment applies to its
+// target, such as "+" and v for x += v or "-" and 1 for x--. op is empty
+// for a plain assignment.
+func (s *AssignStatement) Update() (op string, operand Expression) {
+	switch s.Operator {
+	case "++":
+		return "+", &Integer{BaseNode: s.BaseNode, Value: 1}
+	case "--":
+		return "-", &Integer{BaseNode: s.BaseNode, Value: 1}
+	case "=":
+		return "", s.Value
+	}
+	return strings.TrimSuffix(s.Operator, "="), s.Value
+}
+
+// ImportStatement imports a DSL file or a host module by its dotted path.
 type ImportStatement struct {
 	BaseNode
-	Value Expression
+	Value  Expression
+	Linked bool // names a DSL file, whose declarations are copied in; set by the checker
 }
 
 type VarStatement struct {
@@ -19,6 +50,26 @@ type VarStatement struct {
 	Value Expression
 }
 
+// Recursive reports whether the value of s is a function that refers to
+// the variable s declares, as var f = func(n) { return f(n - 1) } does.
+// The variable is in scope in its own function, so backends declare it
+// before they assign the function. Captures must have been filled in.
+func (s *VarStatement) Recursive() bool {
+	fn, ok := s.Value.(*FunctionDef)
+	return ok && slices.Contains(fn.Captures, s.Name)
+}
+
+// ConstStatement declares a named constant at the top level of a file,
+// such as const GCD = 1.5s. Its value must fold to a literal; the checker
+// substitutes it for every use, including uses as file.NAME in files that
+// import this one.
+type ConstStatement struct {
+	BaseNode
+	Name  *Identifier
+	Value Expression
+	Doc   string
+}
+
 type ElseStatement struct {
 	BaseNode
 	Condition   Expression // nil for else, non-nil for else-if
@@ -32,6 +83,34 @@ type IfStatement struct {
 	Alternatives []*ElseStatement
 }
 
+// MatchStatement runs the first arm one of whose patterns equals Subject,
+// or the default arm if none does, as in
+// match element { Fire => {...}, Ice | Water => {...}, _ => {...} }.
+// Patterns are constants: the checker folds them to literals, resolving a
+// bare name such as Fire to the member of the enum the match is over.
+type MatchStatement struct {
+	BaseNode
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+// MatchArm is an arm of a match. Patterns is nil for the default arm _.
+type MatchArm struct {
+	BaseNode
+	Patterns []Expression
+	Body     *CodeBlock
+}
+
+// Default returns the default arm of s, or nil if it has none.
+func (s *MatchStatement) Default() *MatchArm {
+	for _, arm := range s.Arms {
+		if arm.Patterns == nil {
+			return arm
+		}
+	}
+	return nil
+}
+
 type ReturnStatement struct {
 	BaseNode
 	ReturnValue Expression
@@ -50,15 +129,15 @@ type ContinueStatement struct { // ContinueStatement represents a continue state
 	BaseNode
 }
 
-// ForStatement represents a for loop with three forms:
+// ForStatement represents a for loop with two forms:
 // 1. Classic: for init; condition; post { }
-// 2. Condition-only: for condition { }
-// 3. Range: for key, value = range array { }
+// 2. Range: for key, value = range array { }
+// The condition-only form for condition { } is a WhileStatement.
 type ForStatement struct {
 	BaseNode
-	// Classic for and condition-only for
+	// Classic for
 	Init      Statement  // Optional initialization statement
-	Condition Expression // Loop condition (required for classic and condition-only)
+	Condition Expression // Loop condition, optional
 	Post      Statement  // Optional post statement
 
 	// Range for
@@ -69,4 +148,41 @@ type ForStatement struct {
 	// Common
 	Body        *CodeBlock
 	IsRangeForm bool // true if this is a range-based for loop
+	Bounded     bool // ends after a number of passes known when it starts; set by the checker
+}
+
+// WhileStatement represents while condition { }, also written
+// for condition { }.
+type WhileStatement struct {
+	BaseNode
+	Condition Expression
+	Body      *CodeBlock
+}
+
+// RepeatStatement represents repeat { } until condition. The body runs at
+// least once; unlike in Lua, the condition does not see its variables, so
+// a continue in the body goes straight to the condition.
+type RepeatStatement struct {
+	BaseNode
+	Body      *CodeBlock
+	Condition Expression
+}
+
+// Captured reports whether a function in the body of s captures the
+// variable its init clause declares. That variable is shared by all
+// iterations, so backends whose loops give each iteration its own copy
+// declare it before the loop instead. Captures must have been filled in.
+func (s *ForStatement) Captured() bool {
+	init, ok := s.Init.(*VarStatement)
+	if !ok {
+		return false
+	}
+	captured := false
+	Inspect(s.Body, func(n Node) bool {
+		if fn, ok := n.(*FunctionDef); ok && slices.Contains(fn.Captures, init.Name) {
+			captured = true
+		}
+		return !captured
+	})
+	return captured
 }
@@ -4,8 +4,9 @@ type Number interface {
 	number()
 }
 
-func (f *Float) number()   {}
-func (i *Integer) number() {}
+func (f *Float) number()       {}
+func (i *Integer) number()     {}
+func (u *UnitLiteral) number() {}
 
 type Value interface {
 	value()
@@ -13,6 +14,7 @@ type Value interface {
 
 func (f *Float) value()       {}
 func (i *Integer) value()     {}
+func (u *UnitLiteral) value() {}
 func (t *TableDef) value()    {}
 func (f *FunctionDef) value() {}
 func (i *Identifier) value()  {}
@@ -27,6 +29,8 @@ type Statement interface {
 func (e *ExprStmt) statement()          {}
 func (i *ImportStatement) statement()   {}
 func (v *VarStatement) statement()      {}
+func (c *ConstStatement) statement()    {}
+func (a *AssignStatement) statement()   {}
 func (f *FunctionDef) statement()       {}
 func (i *IfStatement) statement()       {}
 func (r *ReturnStatement) statement()   {}
@@ -35,21 +39,30 @@ func (b *BreakStatement) statement()    {}
 func (c *ContinueStatement) statement() {}
 func (s *SkillDef) statement()          {}
 func (s *StateDef) statement()          {}
+func (e *EnumDef) statement()           {}
+func (t *TemplateDef) statement()       {}
 func (f *ForStatement) statement()      {}
+func (m *MatchStatement) statement()    {}
+func (w *WhileStatement) statement()    {}
+func (r *RepeatStatement) statement()   {}
 
 type Expression interface {
 	Node
 	expression()
 }
 
-func (f *Float) expression()            {}
-func (i *Integer) expression()          {}
-func (t *TableDef) expression()         {}
-func (f *FunctionDef) expression()      {}
-func (i *Identifier) expression()       {}
-func (s *String) expression()           {}
-func (b *Boolean) expression()          {}
-func (p *PrefixExpression) expression() {}
-func (i *InfixExpression) expression()  {}
-func (d *DotExpression) expression()    {}
-func (b *FunctionCall) expression()     {}
+func (f *Float) expression()                 {}
+func (i *Integer) expression()               {}
+func (u *UnitLiteral) expression()           {}
+func (t *TableDef) expression()              {}
+func (f *FunctionDef) expression()           {}
+func (i *Identifier) expression()            {}
+func (s *String) expression()                {}
+func (s *InterpolatedString) expression()    {}
+func (b *Boolean) expression()               {}
+func (p *PrefixExpression) expression()      {}
+func (i *InfixExpression) expression()       {}
+func (d *DotExpression) expression()         {}
+func (i *IndexExpression) expression()       {}
+func (c *ConditionalExpression) expression() {}
+func (b *FunctionCall) expression()          {}
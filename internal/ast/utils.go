@@ -1,6 +1,9 @@
 package ast
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 func FindPropertyByName(propName string, properties []*PropertyDef) string {
 	for _, prop := range properties {
@@ -26,3 +29,23 @@ func IsSameIdentifier(expr1, expr2 Expression) bool {
 	}
 	return ident1.Value == ident2.Value
 }
+
+// QualifiedName renders an identifier or a chain of dotted identifiers,
+// such as EM.Test.
+func QualifiedName(exp Expression) (string, bool) {
+	switch v := exp.(type) {
+	case *Identifier:
+		return v.Value, true
+	case *DotExpression:
+		left, ok := QualifiedName(v.Left)
+		if !ok {
+			return "", false
+		}
+		right, ok := QualifiedName(v.Right)
+		if !ok {
+			return "", false
+		}
+		return strings.Join([]string{left, right}, "."), true
+	}
+	return "", false
+}
@@ -0,0 +1,290 @@
+package ast
+
+import "reflect"
+
+// Inspect traverses the tree rooted at node in depth-first order. It calls
+// f(node) and, if f returns true, descends into the children of node.
+// Nil children are skipped. A skill or state is traversed with the
+// templates it uses and its merged properties, so inherited and expanded
+// properties are seen once for every definition that has them.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || isNil(node) || !f(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		for i := range n.Imports {
+			Inspect(&n.Imports[i], f)
+		}
+		for _, stmt := range n.Statements {
+			Inspect(stmt, f)
+		}
+	case *SkillDef:
+		Inspect(n.Name, f)
+		for _, use := range n.Uses {
+			Inspect(use, f)
+		}
+		for _, prop := range n.MergedProperties() {
+			Inspect(prop, f)
+		}
+	case *StateDef:
+		Inspect(n.Name, f)
+		for _, use := range n.Uses {
+			Inspect(use, f)
+		}
+		for _, prop := range n.MergedProperties() {
+			Inspect(prop, f)
+		}
+	case *TemplateDef:
+		Inspect(n.Name, f)
+		for _, param := range n.Parameters {
+			Inspect(param, f)
+		}
+		for _, prop := range n.Properties {
+			Inspect(prop, f)
+		}
+	case *UseDecl:
+		Inspect(n.Template, f)
+		for _, arg := range n.Arguments {
+			Inspect(arg, f)
+		}
+	case *EnumDef:
+		Inspect(n.Name, f)
+		for _, member := range n.Members {
+			Inspect(member, f)
+		}
+	case *EnumMember:
+		Inspect(n.Name, f)
+		Inspect(n.Value, f)
+	case *PropertyDef:
+		Inspect(n.Key, f)
+		Inspect(n.Value, f)
+	case *CodeBlock:
+		for _, stmt := range n.Statements {
+			Inspect(stmt, f)
+		}
+	case *TableDef:
+		for _, prop := range n.Properties {
+			Inspect(prop, f)
+		}
+	case *PrefixExpression:
+		Inspect(n.Right, f)
+	case *InfixExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *DotExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *IndexExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Index, f)
+	case *InterpolatedString:
+		for _, part := range n.Parts {
+			Inspect(part, f)
+		}
+	case *ConditionalExpression:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+		Inspect(n.Alternative, f)
+	case *FunctionCall:
+		Inspect(n.Function, f)
+		for _, arg := range n.Arguments {
+			Inspect(arg, f)
+		}
+	case *FunctionDef:
+		for _, param := range n.Parameters {
+			Inspect(param, f)
+		}
+		Inspect(n.Body, f)
+	case *ExprStmt:
+		Inspect(n.Expression, f)
+	case *AssignStatement:
+		Inspect(n.Target, f)
+		Inspect(n.Value, f)
+	case *ImportStatement:
+		Inspect(n.Value, f)
+	case *VarStatement:
+		Inspect(n.Name, f)
+		Inspect(n.Value, f)
+	case *ConstStatement:
+		Inspect(n.Name, f)
+		Inspect(n.Value, f)
+	case *IfStatement:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+		for _, alt := range n.Alternatives {
+			Inspect(alt, f)
+		}
+	case *ElseStatement:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+	case *ReturnStatement:
+		Inspect(n.ReturnValue, f)
+	case *MatchStatement:
+		Inspect(n.Subject, f)
+		for _, arm := range n.Arms {
+			Inspect(arm, f)
+		}
+	case *MatchArm:
+		for _, pattern := range n.Patterns {
+			Inspect(pattern, f)
+		}
+		Inspect(n.Body, f)
+	case *ForStatement:
+		Inspect(n.Init, f)
+		Inspect(n.Condition, f)
+		Inspect(n.Post, f)
+		Inspect(n.Key, f)
+		Inspect(n.Value, f)
+		Inspect(n.RangeValue, f)
+		Inspect(n.Body, f)
+	case *WhileStatement:
+		Inspect(n.Condition, f)
+		Inspect(n.Body, f)
+	case *RepeatStatement:
+		Inspect(n.Body, f)
+		Inspect(n.Condition, f)
+	}
+}
+
+// Rewrite replaces every expression below node, children first, with the
+// result of f. f returns its argument to keep an expression. Fields of a
+// concrete type, such as VarStatement.Name, are not rewritten, and neither
+// are the properties a skill or state inherits; those it gets from templates
+// are.
+func Rewrite(node Node, f func(Expression) Expression) {
+	if node == nil || isNil(node) {
+		return
+	}
+
+	rewrite := func(exp Expression) Expression {
+		if exp == nil || isNil(exp) {
+			return exp
+		}
+		Rewrite(exp, f)
+		return f(exp)
+	}
+	rewriteAll := func(exps []Expression) {
+		for i := range exps {
+			exps[i] = rewrite(exps[i])
+		}
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		for _, stmt := range n.Statements {
+			Rewrite(stmt, f)
+		}
+	case *SkillDef:
+		for _, use := range n.Uses {
+			Rewrite(use, f)
+		}
+		for _, prop := range n.Properties {
+			Rewrite(prop, f)
+		}
+	case *StateDef:
+		for _, use := range n.Uses {
+			Rewrite(use, f)
+		}
+		for _, prop := range n.Properties {
+			Rewrite(prop, f)
+		}
+	case *TemplateDef:
+		for _, prop := range n.Properties {
+			Rewrite(prop, f)
+		}
+	case *UseDecl:
+		rewriteAll(n.Arguments)
+		for _, prop := range n.Expanded {
+			Rewrite(prop, f)
+		}
+	case *EnumDef:
+		for _, member := range n.Members {
+			Rewrite(member, f)
+		}
+	case *EnumMember:
+		n.Value = rewrite(n.Value)
+	case *PropertyDef:
+		if _, isName := n.Key.(*Identifier); !isName { // a field name, not a reference
+			n.Key = rewrite(n.Key)
+		}
+		n.Value = rewrite(n.Value)
+	case *CodeBlock:
+		for _, stmt := range n.Statements {
+			Rewrite(stmt, f)
+		}
+	case *TableDef:
+		for _, prop := range n.Properties {
+			Rewrite(prop, f)
+		}
+	case *PrefixExpression:
+		n.Right = rewrite(n.Right)
+	case *InfixExpression:
+		n.Left = rewrite(n.Left)
+		n.Right = rewrite(n.Right)
+	case *DotExpression:
+		n.Left = rewrite(n.Left)
+	case *IndexExpression:
+		n.Left = rewrite(n.Left)
+		n.Index = rewrite(n.Index)
+	case *InterpolatedString:
+		rewriteAll(n.Parts)
+	case *ConditionalExpression:
+		n.Condition = rewrite(n.Condition)
+		n.Consequence = rewrite(n.Consequence)
+		n.Alternative = rewrite(n.Alternative)
+	case *FunctionCall:
+		n.Function = rewrite(n.Function)
+		rewriteAll(n.Arguments)
+	case *FunctionDef:
+		Rewrite(n.Body, f)
+	case *ExprStmt:
+		n.Expression = rewrite(n.Expression)
+	case *AssignStatement:
+		n.Target = rewrite(n.Target)
+		n.Value = rewrite(n.Value)
+	case *VarStatement:
+		n.Value = rewrite(n.Value)
+	case *ConstStatement:
+		n.Value = rewrite(n.Value)
+	case *IfStatement:
+		n.Condition = rewrite(n.Condition)
+		Rewrite(n.Consequence, f)
+		for _, alt := range n.Alternatives {
+			Rewrite(alt, f)
+		}
+	case *ElseStatement:
+		n.Condition = rewrite(n.Condition)
+		Rewrite(n.Consequence, f)
+	case *ReturnStatement:
+		n.ReturnValue = rewrite(n.ReturnValue)
+	case *MatchStatement:
+		n.Subject = rewrite(n.Subject)
+		for _, arm := range n.Arms {
+			Rewrite(arm, f)
+		}
+	case *MatchArm:
+		rewriteAll(n.Patterns)
+		Rewrite(n.Body, f)
+	case *ForStatement:
+		Rewrite(n.Init, f)
+		n.Condition = rewrite(n.Condition)
+		Rewrite(n.Post, f)
+		n.RangeValue = rewrite(n.RangeValue)
+		Rewrite(n.Body, f)
+	case *WhileStatement:
+		n.Condition = rewrite(n.Condition)
+		Rewrite(n.Body, f)
+	case *RepeatStatement:
+		Rewrite(n.Body, f)
+		n.Condition = rewrite(n.Condition)
+	}
+}
+
+// isNil reports whether node is an interface holding a nil pointer, as
+// happens when an optional field such as ForStatement.Key is unset.
+func isNil(node Node) bool {
+	v := reflect.ValueOf(node)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
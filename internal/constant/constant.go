@@ -0,0 +1,224 @@
+// Package constant evaluates DSL operators on constant values with the
+// semantics of Lua 5.3, the reference for every backend. A constant is a
+// bool, int64, float64 or string.
+package constant
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/lexer"
+)
+
+// Of returns the value of a literal expression.
+func Of(exp ast.Expression) (any, bool) {
+	switch e := exp.(type) {
+	case *ast.Boolean:
+		return e.Value, true
+	case *ast.Integer:
+		return e.Value, true
+	case *ast.Float:
+		return e.Value, true
+	case *ast.String:
+		return e.Value, true
+	}
+	return nil, false
+}
+
+// Literal returns a literal expression for v, placed at pos.
+func Literal(v any, pos lexer.Position) ast.Expression {
+	switch v := v.(type) {
+	case bool:
+		typ := lexer.FALSE
+		if v {
+			typ = lexer.TRUE
+		}
+		return &ast.Boolean{BaseNode: node(typ, fmt.Sprint(v), pos), Value: v}
+	case int64:
+		return &ast.Integer{BaseNode: node(lexer.INTEGER, fmt.Sprint(v), pos), Value: v}
+	case float64:
+		return &ast.Float{BaseNode: node(lexer.FLOAT, fmt.Sprint(v), pos), Value: v}
+	case string:
+		return &ast.String{BaseNode: node(lexer.STRING, v, pos), Value: v}
+	}
+	panic(fmt.Sprintf("constant: %T is not a constant", v))
+}
+
+func node(typ lexer.TokenType, literal string, pos lexer.Position) ast.BaseNode {
+	return ast.BaseNode{Token: lexer.Token{Type: typ, Literal: literal, Pos: pos}}
+}
+
+// Truthy reports whether v counts as true: as in Lua, only nil and false
+// are false.
+func Truthy(v any) bool {
+	return v != nil && v != false
+}
+
+// Unary applies the prefix operator op to v. It reports false if the
+// operator does not apply to v.
+func Unary(op string, v any) (any, bool) {
+	switch op {
+	case "-":
+		switch v := v.(type) {
+		case int64:
+			return -v, true
+		case float64:
+			return -v, true
+		}
+	case "not":
+		return !Truthy(v), true
+	case "~":
+		if v, ok := v.(int64); ok {
+			return ^v, true
+		}
+	}
+	return nil, false
+}
+
+// Binary applies the infix operator op to l and r. It reports false if the
+// operator does not apply to them or would fail at runtime, as a division
+// by zero does.
+func Binary(op string, l, r any) (any, bool) {
+	if lb, ok := l.(bool); ok {
+		if rb, ok := r.(bool); ok {
+			switch op {
+			case "and":
+				return lb && rb, true
+			case "or":
+				return lb || rb, true
+			}
+		}
+	}
+
+	li, lInt := l.(int64)
+	ri, rInt := r.(int64)
+	if lInt && rInt {
+		switch op {
+		case "+":
+			return li + ri, true
+		case "-":
+			return li - ri, true
+		case "*":
+			return li * ri, true
+		case "%":
+			if ri != 0 {
+				m := li % ri
+				if m != 0 && (m < 0) != (ri < 0) {
+					m += ri
+				}
+				return m, true
+			}
+			return nil, false
+		case "//":
+			if ri != 0 {
+				q := li / ri
+				if li%ri != 0 && (li < 0) != (ri < 0) {
+					q--
+				}
+				return q, true
+			}
+			return nil, false
+		case "&":
+			return li & ri, true
+		case "|":
+			return li | ri, true
+		case "~":
+			return li ^ ri, true
+		case "==":
+			return li == ri, true
+		case "!=":
+			return li != ri, true
+		case "<":
+			return li < ri, true
+		case ">":
+			return li > ri, true
+		case "<=":
+			return li <= ri, true
+		case ">=":
+			return li >= ri, true
+		case "<<", ">>":
+			if op == ">>" {
+				ri = -ri
+			}
+			switch {
+			case ri <= -64 || ri >= 64:
+				return int64(0), true
+			case ri >= 0:
+				return int64(uint64(li) << ri), true
+			default:
+				return int64(uint64(li) >> -ri), true
+			}
+		}
+	}
+
+	lf, lNum := toFloat(l)
+	rf, rNum := toFloat(r)
+	if lNum && rNum {
+		v, ok := floatBinary(op, lf, rf)
+		if f, isFloat := v.(float64); isFloat && (math.IsInf(f, 0) || math.IsNaN(f)) {
+			return nil, false // inf and nan are left to the runtime
+		}
+		return v, ok
+	}
+
+	switch op {
+	case "==":
+		return l == r, true // values of different types are never equal
+	case "!=":
+		return l != r, true
+	}
+	return nil, false
+}
+
+func floatBinary(op string, lf, rf float64) (any, bool) {
+	switch op {
+	case "+":
+		return lf + rf, true
+	case "-":
+		return lf - rf, true
+	case "*":
+		return lf * rf, true
+	case "/":
+		if rf != 0 {
+			return lf / rf, true
+		}
+	case "//":
+		if rf != 0 {
+			return math.Floor(lf / rf), true
+		}
+	case "%":
+		if rf != 0 {
+			m := math.Mod(lf, rf)
+			if m != 0 && (m < 0) != (rf < 0) {
+				m += rf
+			}
+			return m, true
+		}
+	case "^":
+		return math.Pow(lf, rf), true
+	case "==":
+		return lf == rf, true
+	case "!=":
+		return lf != rf, true
+	case "<":
+		return lf < rf, true
+	case ">":
+		return lf > rf, true
+	case "<=":
+		return lf <= rf, true
+	case ">=":
+		return lf >= rf, true
+	}
+	return nil, false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
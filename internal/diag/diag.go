@@ -0,0 +1,74 @@
+package diag
+
+import (
+	"fmt"
+
+	"github.com/hsoul/skconf/internal/lexer"
+)
+
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+	Info
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Info:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// Diagnostic is a message about a source location produced by any stage of
+// the compiler (lexer, parser, semantic passes or code generators).
+type Diagnostic struct {
+	Severity Severity
+	File     string
+	Pos      lexer.Position
+	Message  string
+	Notes    []Note // related locations, such as the use of a template the error is in
+}
+
+// Note points at another location that explains a Diagnostic.
+type Note struct {
+	File    string
+	Pos     lexer.Position
+	Message string
+}
+
+func (n Note) String() string {
+	return fmt.Sprintf("%s:%d:%d: note: %s", n.File, n.Pos.Line, n.Pos.Column, n.Message)
+}
+
+func (d Diagnostic) String() string {
+	if d.File == "" && d.Pos.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Pos.Line, d.Pos.Column, d.Severity, d.Message)
+}
+
+func Errorf(file string, pos lexer.Position, format string, args ...any) Diagnostic {
+	return Diagnostic{Severity: Error, File: file, Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func Warningf(file string, pos lexer.Position, format string, args ...any) Diagnostic {
+	return Diagnostic{Severity: Warning, File: file, Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// HasErrors reports whether any of the diagnostics is an error.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
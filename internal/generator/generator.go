@@ -2,15 +2,65 @@ package generator
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/manifest"
 )
 
+type ArtifactKind string
+
+const (
+	Code        ArtifactKind = "code"
+	SourceMap   ArtifactKind = "sourcemap"
+	ExportIndex ArtifactKind = "index"
+	TypeStubs   ArtifactKind = "types"
+)
+
+// Artifact is one named output of a generator. Name is a slash separated
+// path relative to the output directory.
+type Artifact struct {
+	Name    string
+	Kind    ArtifactKind
+	Content []byte
+}
+
+// Unit is one parsed source file handed to a generator.
+type Unit struct {
+	Name    string // source name without extension, used to name artifacts
+	File    string // source file name, used in diagnostics
+	Program *ast.Program
+
+	Manifest *manifest.Manifest // host API, nil if none was given
+
+	LoopBudget int // passes after which a loop not known to be bounded fails; 0 for no limit
+}
+
+// Budgeted reports whether loop, a for, while or repeat statement, counts
+// its passes against the loop budget: whether u has one and the checker
+// could not prove the loop bounded.
+func (u *Unit) Budgeted(loop ast.Statement) bool {
+	if u.LoopBudget <= 0 {
+		return false
+	}
+	f, isFor := loop.(*ast.ForStatement)
+	return !isFor || !f.Bounded
+}
+
+// BudgetError is the message of the error a loop raises when it starts a
+// pass past the budget.
+func (u *Unit) BudgetError(loop ast.Statement) string {
+	return fmt.Sprintf("%s:%d: loop ran more than %d times", u.File, loop.Pos().Line, u.LoopBudget)
+}
+
 type CodeGenerator interface {
-	Generate(node ast.Node) string
+	Generate(unit *Unit) ([]Artifact, []diag.Diagnostic)
 }
 
-type GeneratorConstructor func() (CodeGenerator, error)
+type GeneratorConstructor func(opts Options) (CodeGenerator, error)
 
 var generators = make(map[string]GeneratorConstructor)
 
@@ -18,9 +68,69 @@ func Register(lang string, constructor GeneratorConstructor) {
 	generators[lang] = constructor
 }
 
-func New(lang string) (CodeGenerator, error) {
+func New(lang string, opts Options) (CodeGenerator, error) {
 	if constructor, ok := generators[lang]; ok {
-		return constructor()
+		return constructor(opts)
 	}
 	return nil, fmt.Errorf("unsupported language: %s", lang)
 }
+
+func Has(lang string) bool {
+	_, ok := generators[lang]
+	return ok
+}
+
+// Languages returns the registered language names in sorted order.
+func Languages() []string {
+	langs := make([]string, 0, len(generators))
+	for lang := range generators {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Options are the per-target settings passed to a generator constructor.
+type Options map[string]string
+
+// Check returns an error naming the first option that is not in known.
+func (o Options) Check(lang string, known ...string) error {
+	keys := make([]string, 0, len(o))
+	for key := range o {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		found := false
+		for _, k := range known {
+			if k == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown %s option %q (known: %s)", lang, key, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+func (o Options) String(key, def string) string {
+	if v, ok := o[key]; ok {
+		return v
+	}
+	return def
+}
+
+func (o Options) Bool(key string) (bool, error) {
+	v, ok := o[key]
+	if !ok || v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("option %q: %q is not a boolean", key, v)
+	}
+	return b, nil
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/manifest"
+)
+
+// HostModule is a host API module as seen by typed backends, which emit
+// one interface per module.
+type HostModule struct {
+	Name      string
+	Functions []HostFunction
+}
+
+type HostFunction struct {
+	Name      string
+	Signature *manifest.Signature // nil when derived from usage
+}
+
+// HostCall reports whether call invokes a host module function such as
+// UE.AA(...), and returns the module and function names.
+func HostCall(call *ast.FunctionCall, m *manifest.Manifest) (module, name string, ok bool) {
+	dot, ok := call.Function.(*ast.DotExpression)
+	if !ok {
+		return "", "", false
+	}
+	left, ok := dot.Left.(*ast.Identifier)
+	if !ok || !m.IsModule(left.Value) {
+		return "", "", false
+	}
+	right, ok := dot.Right.(*ast.Identifier)
+	if !ok {
+		return "", "", false
+	}
+	return left.Value, right.Value, true
+}
+
+// MethodCall reports whether call invokes a member of a value returned by
+// another call, such as UE.GetTarget(t).Hp(). Such values are host
+// handles, so backends invoke the member as a method of the receiver
+// rather than as a plain function stored in a table.
+func MethodCall(call *ast.FunctionCall) (*ast.DotExpression, bool) {
+	dot, ok := call.Function.(*ast.DotExpression)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := dot.Left.(*ast.FunctionCall); !ok {
+		return nil, false
+	}
+	_, ok = dot.Right.(*ast.Identifier)
+	return dot, ok
+}
+
+// HostAPI returns the host modules a typed backend must declare. With a
+// manifest these are all of its modules and functions; without one they
+// are derived from the host calls the program makes, with nil signatures.
+func HostAPI(program *ast.Program, m *manifest.Manifest) []HostModule {
+	if m != nil {
+		modules := []HostModule{}
+		for _, modName := range m.ModuleNames() {
+			mod := HostModule{Name: modName}
+			for _, fnName := range m.FunctionNames(modName) {
+				sig, _ := m.Function(modName, fnName)
+				mod.Functions = append(mod.Functions, HostFunction{Name: fnName, Signature: sig})
+			}
+			modules = append(modules, mod)
+		}
+		return modules
+	}
+
+	used := map[string]map[string]bool{}
+	ast.Inspect(program, func(node ast.Node) bool {
+		if call, ok := node.(*ast.FunctionCall); ok {
+			if module, name, ok := HostCall(call, nil); ok {
+				if used[module] == nil {
+					used[module] = map[string]bool{}
+				}
+				used[module][name] = true
+			}
+		}
+		return true
+	})
+
+	modules := []HostModule{}
+	for _, modName := range manifest.DefaultModules {
+		mod := HostModule{Name: modName}
+		names := make([]string, 0, len(used[modName]))
+		for name := range used[modName] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			mod.Functions = append(mod.Functions, HostFunction{Name: name})
+		}
+		modules = append(modules, mod)
+	}
+	return modules
+}
@@ -0,0 +1,149 @@
+package csharp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+// definition is a skill or state about to become a C# class.
+type definition struct {
+	kind       string
+	name       string
+	doc        string
+	typeName   string
+	properties []*ast.PropertyDef
+}
+
+func newDefinition(kind, name, doc string, properties []*ast.PropertyDef) *definition {
+	return &definition{
+		kind:       kind,
+		name:       name,
+		doc:        doc,
+		typeName:   typeName(name),
+		properties: properties,
+	}
+}
+
+// fieldType picks the C# type of a literal property; everything that is
+// only known at runtime is stored as object.
+func fieldType(exp ast.Expression) string {
+	switch exp.(type) {
+	case *ast.Integer:
+		return "long"
+	case *ast.Float:
+		return "double"
+	case *ast.String, *ast.InterpolatedString:
+		return "string"
+	case *ast.Boolean:
+		return "bool"
+	case *ast.TableDef:
+		return "DslTable"
+	default:
+		return "object"
+	}
+}
+
+// memberName names a field or method; C# forbids members named like their
+// enclosing class.
+func memberName(def *definition, key string) string {
+	name := generator.PascalCase(key)
+	if name == def.typeName {
+		name += "_"
+	}
+	return name
+}
+
+func (c *csharpGenerator) generateDefinition(def *definition) {
+	var fields, hooks []*ast.PropertyDef
+	for _, prop := range def.properties {
+		if _, ok := prop.Key.(*ast.Identifier); !ok {
+			continue
+		}
+		if _, ok := prop.Value.(*ast.FunctionDef); ok {
+			hooks = append(hooks, prop)
+		} else {
+			fields = append(fields, prop)
+		}
+	}
+
+	if def.doc != "" {
+		c.summary(def.doc)
+	} else {
+		c.line("/// <summary>%s %s</summary>", generator.PascalCase(def.kind), def.name)
+	}
+	c.line("public sealed class %s", def.typeName)
+	c.open()
+	for _, prop := range fields {
+		c.summary(prop.Doc)
+		c.line("public %s %s;", fieldType(prop.Value), memberName(def, prop.Key.(*ast.Identifier).Value))
+	}
+	if len(fields) > 0 {
+		c.line("")
+	}
+
+	c.line("public %s(IHost host)", def.typeName)
+	c.open()
+	c.pushScope()
+	for _, prop := range fields {
+		c.line("%s = %s;", memberName(def, prop.Key.(*ast.Identifier).Value), c.fieldValue(prop.Value))
+	}
+	c.popScope()
+	c.close("")
+
+	for _, prop := range hooks {
+		c.line("")
+		c.summary(prop.Doc)
+		c.generateMethod("public", memberName(def, prop.Key.(*ast.Identifier).Value), prop.Value.(*ast.FunctionDef))
+	}
+
+	c.close("")
+}
+
+// generateMethod declares fn, a hook or a top-level function, as the method
+// name with the given modifiers. It takes the host first, then the
+// parameters of fn with the C# types of their annotations.
+func (c *csharpGenerator) generateMethod(modifiers, name string, fn *ast.FunctionDef) {
+	c.pushScope()
+	params := []string{"IHost host"}
+	for i, param := range fn.Parameters {
+		typ := c.manifest.ValueType(fn.ParamType(i))
+		params = append(params, csType(typ)+" "+c.declare(param.Value, typ))
+	}
+	c.result = c.manifest.ValueType(fn.ReturnType())
+	c.line("%s %s %s(%s)", modifiers, csType(c.result), name, strings.Join(params, ", "))
+	c.open()
+	c.generateBody(fn.Body)
+	c.close("")
+	c.result = ""
+	c.popScope()
+}
+
+// fieldValue renders a property value for a typed field.
+func (c *csharpGenerator) fieldValue(exp ast.Expression) string {
+	switch v := exp.(type) {
+	case *ast.Integer:
+		return strconv.FormatInt(v.Value, 10)
+	case *ast.Float:
+		return formatFloat(v.Value)
+	case *ast.TableDef:
+		return c.table(v)
+	default:
+		return c.expression(exp)
+	}
+}
+
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEn") {
+		s += ".0"
+	}
+	return s
+}
+
+func formatInt(n int64) string {
+	return fmt.Sprintf("%dL", n)
+}
@@ -0,0 +1,292 @@
+package csharp
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+// expression renders exp as a C# expression convertible to object.
+func (c *csharpGenerator) expression(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		if local, ok := c.lookup(e.Value); ok {
+			return local
+		}
+		if c.globals[e.Value] {
+			return "Module." + csLocal(e.Value)
+		}
+		if fn := c.funcs[e.Value]; fn != nil {
+			return c.functionValue(e.Value, fn)
+		}
+		if c.manifest.IsModule(e.Value) {
+			c.errorf(e, "csharp: host module %s can only be called", e.Value)
+			return "null"
+		}
+		return "host.Global(" + quote(e.Value) + ")"
+	case *ast.Integer:
+		return formatInt(e.Value)
+	case *ast.Float:
+		return formatFloat(e.Value) + "D"
+	case *ast.String:
+		return quote(e.Value)
+	case *ast.InterpolatedString:
+		parts := make([]string, len(e.Parts))
+		for i, part := range e.Parts {
+			parts[i] = c.expression(part)
+		}
+		return "Dsl.Concat(" + strings.Join(parts, ", ") + ")"
+	case *ast.Boolean:
+		return strconv.FormatBool(e.Value)
+	case *ast.TableDef:
+		return c.table(e)
+	case *ast.PrefixExpression:
+		switch e.Operator {
+		case "-":
+			return "Dsl.Neg(" + c.expression(e.Right) + ")"
+		case "~":
+			return "Dsl.BNot(" + c.expression(e.Right) + ")"
+		case "not":
+			return "!" + c.condition(e.Right)
+		}
+	case *ast.InfixExpression:
+		return c.infix(e)
+	case *ast.DotExpression:
+		if right, ok := e.Right.(*ast.Identifier); ok {
+			if e.Optional {
+				return "Dsl.IndexOpt(" + c.expression(e.Left) + ", " + quote(right.Value) + ")"
+			}
+			return "Dsl.Index(" + c.expression(e.Left) + ", " + quote(right.Value) + ")"
+		}
+	case *ast.IndexExpression:
+		return "Dsl.Index(" + c.expression(e.Left) + ", " + c.expression(e.Index) + ")"
+	case *ast.ConditionalExpression:
+		return "(" + c.condition(e.Condition) + " ? (object)" + c.expression(e.Consequence) + " : " + c.expression(e.Alternative) + ")"
+	case *ast.FunctionCall:
+		return c.call(e)
+	case *ast.FunctionDef:
+		return c.function(e)
+	}
+
+	c.errorf(exp, "csharp: unsupported expression %T", exp)
+	return "null"
+}
+
+// condition renders exp as a C# bool expression.
+func (c *csharpGenerator) condition(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Boolean:
+		return strconv.FormatBool(e.Value)
+	case *ast.PrefixExpression:
+		if e.Operator == "not" {
+			return "!" + c.condition(e.Right)
+		}
+	case *ast.InfixExpression:
+		switch e.Operator {
+		case "and":
+			return "(" + c.condition(e.Left) + " && " + c.condition(e.Right) + ")"
+		case "or":
+			return "(" + c.condition(e.Left) + " || " + c.condition(e.Right) + ")"
+		case "==", "!=", "<", ">", "<=", ">=":
+			return c.infix(e)
+		}
+	}
+	return "Dsl.Truthy(" + c.expression(exp) + ")"
+}
+
+// convert renders exp as a value of the C# type of the manifest type typ.
+// Conditions, literals and locals of the type need no runtime conversion.
+func (c *csharpGenerator) convert(typ string, exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		if local, ok := c.lookup(e.Value); ok && typ != "" && c.types[local] == typ {
+			return local
+		}
+	case *ast.Integer:
+		if typ == "int" || typ == "float" {
+			return c.expression(exp)
+		}
+	case *ast.Float:
+		if typ == "float" {
+			return c.expression(exp)
+		}
+	case *ast.String:
+		if typ == "string" {
+			return c.expression(exp)
+		}
+	}
+	if typ == "bool" {
+		return c.condition(exp)
+	}
+	return csConvert(typ, c.expression(exp))
+}
+
+func (c *csharpGenerator) infix(exp *ast.InfixExpression) string {
+	left := c.expression(exp.Left)
+
+	switch exp.Operator {
+	case "and", "or":
+		// The right operand is only evaluated when needed, as in Lua.
+		op := "Dsl.And("
+		if exp.Operator == "or" {
+			op = "Dsl.Or("
+		}
+		return op + left + ", () => " + c.expression(exp.Right) + ")"
+	}
+
+	right := c.expression(exp.Right)
+	switch exp.Operator {
+	case "+":
+		return "Dsl.Add(" + left + ", " + right + ")"
+	case "-":
+		return "Dsl.Sub(" + left + ", " + right + ")"
+	case "*":
+		return "Dsl.Mul(" + left + ", " + right + ")"
+	case "/":
+		return "Dsl.Div(" + left + ", " + right + ")"
+	case "//":
+		return "Dsl.IntDiv(" + left + ", " + right + ")"
+	case "%":
+		return "Dsl.Mod(" + left + ", " + right + ")"
+	case "^":
+		return "Dsl.Pow(" + left + ", " + right + ")"
+	case "&":
+		return "Dsl.BAnd(" + left + ", " + right + ")"
+	case "|":
+		return "Dsl.BOr(" + left + ", " + right + ")"
+	case "~":
+		return "Dsl.BXor(" + left + ", " + right + ")"
+	case "<<":
+		return "Dsl.Shl(" + left + ", " + right + ")"
+	case ">>":
+		return "Dsl.Shr(" + left + ", " + right + ")"
+	case "==":
+		return "Dsl.Eq(" + left + ", " + right + ")"
+	case "!=":
+		return "!Dsl.Eq(" + left + ", " + right + ")"
+	case "<":
+		return "Dsl.Less(" + left + ", " + right + ", false)"
+	case "<=":
+		return "Dsl.Less(" + left + ", " + right + ", true)"
+	case ">":
+		return "Dsl.Greater(" + left + ", " + right + ", false)"
+	case ">=":
+		return "Dsl.Greater(" + left + ", " + right + ", true)"
+	}
+
+	c.errorf(exp, "csharp: unsupported operator %s", exp.Operator)
+	return "null"
+}
+
+func (c *csharpGenerator) table(table *ast.TableDef) string {
+	var array, pairs []string
+	for _, prop := range table.Properties {
+		value := c.expression(prop.Value)
+		switch key := prop.Key.(type) {
+		case nil:
+			array = append(array, value)
+		case *ast.Identifier:
+			pairs = append(pairs, quote(key.Value), value)
+		default:
+			pairs = append(pairs, c.expression(prop.Key), value)
+		}
+	}
+
+	args := "null"
+	if len(array) > 0 {
+		args = "new object[] { " + strings.Join(array, ", ") + " }"
+	}
+	for _, pair := range pairs {
+		args += ", " + pair
+	}
+	return "Dsl.Table(" + args + ")"
+}
+
+func (c *csharpGenerator) call(call *ast.FunctionCall) string {
+	if id, ok := call.Function.(*ast.Identifier); ok && c.funcs[id.Value] != nil {
+		if _, local := c.lookup(id.Value); !local {
+			fn := c.funcs[id.Value]
+			args := []string{"host"}
+			for i, arg := range call.Arguments {
+				args = append(args, c.convert(c.manifest.ValueType(fn.ParamType(i)), arg))
+			}
+			return "Module." + csLocal(id.Value) + "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+
+	args := make([]string, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		args[i] = c.expression(arg)
+	}
+
+	if module, name, ok := generator.HostCall(call, c.manifest); ok {
+		// sema has checked the function is declared and its arity.
+		if sig, ok := c.manifest.Function(module, name); ok {
+			for i := range args {
+				param := sig.Params[min(i, len(sig.Params)-1)]
+				args[i] = csConvert(c.manifest.ValueType(param.Type), args[i])
+			}
+		}
+		return "host." + module + "." + name + "(" + strings.Join(args, ", ") + ")"
+	}
+
+	dot, ok := call.Function.(*ast.DotExpression)
+	if !ok || !dot.Optional {
+		if dot, ok := generator.MethodCall(call); ok {
+			return c.member(call, dot, c.expression(dot.Left), args)
+		}
+		return "Dsl.Call(" + strings.Join(append([]string{c.expression(call.Function)}, args...), ", ") + ")"
+	}
+
+	// recv?.f(args) skips the call, arguments included, when recv is nil.
+	recv := c.unique("dslRecv")
+	return "Dsl.IfNotNil(" + c.expression(dot.Left) + ", " + recv + " => " + c.member(call, dot, recv, args) + ")"
+}
+
+// member renders a call of the member dot.Right of the value recv.
+func (c *csharpGenerator) member(call *ast.FunctionCall, dot *ast.DotExpression, recv string, args []string) string {
+	name := quote(dot.Right.(*ast.Identifier).Value)
+	if _, ok := generator.MethodCall(call); ok {
+		return "Dsl.Invoke(" + strings.Join(append([]string{recv, name}, args...), ", ") + ")"
+	}
+	return "Dsl.Call(" + strings.Join(append([]string{"Dsl.Index(" + recv + ", " + name + ")"}, args...), ", ") + ")"
+}
+
+// functionValue renders the top-level function name as a DslFunc, for
+// when it is passed around rather than called.
+func (c *csharpGenerator) functionValue(name string, fn *ast.FunctionDef) string {
+	dslArgs := c.unique("dslArgs")
+	args := []string{"host"}
+	for i := range fn.Parameters {
+		args = append(args, csConvert(c.manifest.ValueType(fn.ParamType(i)), "Dsl.Arg("+dslArgs+", "+strconv.Itoa(i)+")"))
+	}
+	return "new DslFunc(" + dslArgs + " => Module." + csLocal(name) + "(" + strings.Join(args, ", ") + "))"
+}
+
+// function renders a function value as a DslFunc lambda. Parameters are
+// read from the argument array so every function value has the same
+// delegate type; typed parameters and results are converted to their type
+// on the way.
+func (c *csharpGenerator) function(fn *ast.FunctionDef) string {
+	outer, outerIndent, outerInit, outerResult := c.buf, c.indent, c.inInit, c.result
+	c.buf, c.indent, c.inInit, c.result = &strings.Builder{}, outerIndent, false, c.manifest.ValueType(fn.ReturnType())
+
+	c.pushScope()
+	args := c.unique("dslArgs")
+	c.buf.WriteString("new DslFunc(" + args + " =>\n")
+	c.open()
+	for i, param := range fn.Parameters {
+		typ := c.manifest.ValueType(fn.ParamType(i))
+		c.line("%s %s = %s;", csType(typ), c.declare(param.Value, typ), csConvert(typ, "Dsl.Arg("+args+", "+strconv.Itoa(i)+")"))
+	}
+	c.generateBody(fn.Body)
+	c.indent--
+	c.buf.WriteString(strings.Repeat("    ", c.indent) + "})")
+	c.popScope()
+
+	out := c.buf.String()
+	c.buf, c.indent, c.inInit, c.result = outer, outerIndent, outerInit, outerResult
+	return out
+}
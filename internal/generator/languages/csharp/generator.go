@@ -0,0 +1,339 @@
+package csharp
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/generator"
+	"github.com/hsoul/skconf/internal/manifest"
+)
+
+const Language = "csharp"
+
+// Options understood by the csharp generator.
+const (
+	OptNamespace = "namespace" // namespace of the generated code, defaults to Skills.<FileName>
+)
+
+func init() {
+	generator.Register(Language, NewCSharpGenerator)
+}
+
+// csharpGenerator compiles a unit to C# for Unity clients: one class per
+// skill and state with fields for literal properties and methods for hooks,
+// an IHost interface per host module, and registries from tid to
+// constructor. The output avoids reflection and dynamic so it builds under
+// IL2CPP.
+type csharpGenerator struct {
+	buf    *strings.Builder
+	indent int
+
+	unit     *generator.Unit
+	manifest *manifest.Manifest
+	diags    []diag.Diagnostic
+
+	namespace string
+	globals   map[string]bool             // top-level vars, static fields of Module
+	funcs     map[string]*ast.FunctionDef // top-level functions, static methods of Module
+	scopes    []map[string]string         // DSL name -> C# name of enclosing locals
+	used      map[string]bool             // C# local names taken in the current method
+	types     map[string]string           // C# name -> manifest type of typed locals
+	result    string                      // manifest type of the result of the current method
+	renames   int
+	inInit    bool // generating Module.Init, which returns void
+}
+
+func NewCSharpGenerator(opts generator.Options) (generator.CodeGenerator, error) {
+	if err := opts.Check(Language, OptNamespace); err != nil {
+		return nil, err
+	}
+	c := &csharpGenerator{namespace: opts.String(OptNamespace, "")}
+	for _, part := range strings.Split(c.namespace, ".") {
+		if c.namespace != "" && !isIdentifier(part) {
+			return nil, fmt.Errorf("option %q: %q is not a valid namespace", OptNamespace, c.namespace)
+		}
+	}
+	return c, nil
+}
+
+func (c *csharpGenerator) Generate(unit *generator.Unit) ([]generator.Artifact, []diag.Diagnostic) {
+	c.buf = &strings.Builder{}
+	c.indent = 0
+	c.unit = unit
+	c.manifest = unit.Manifest
+	c.diags = nil
+	c.globals = map[string]bool{}
+	c.funcs = map[string]*ast.FunctionDef{}
+	c.scopes = nil
+	c.renames = 0
+
+	namespace := c.namespace
+	if namespace == "" {
+		namespace = "Skills." + generator.PascalCase(path.Base(unit.Name))
+	}
+
+	c.generateProgram(unit.Program, namespace)
+
+	return []generator.Artifact{
+		{Name: unit.Name + ".cs", Kind: generator.Code, Content: []byte(c.buf.String())},
+		{Name: unit.Name + ".Runtime.cs", Kind: generator.Code, Content: []byte(strings.Replace(runtimeSource, "NAMESPACE", namespace, 1))},
+	}, c.diags
+}
+
+func (c *csharpGenerator) errorf(node ast.Node, format string, args ...any) {
+	c.diags = append(c.diags, diag.Errorf(c.unit.File, node.Pos(), format, args...))
+}
+
+func (c *csharpGenerator) line(format string, args ...any) {
+	if format != "" {
+		c.buf.WriteString(strings.Repeat("    ", c.indent))
+		c.buf.WriteString(fmt.Sprintf(format, args...))
+	}
+	c.buf.WriteString("\n")
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// summary writes text as an XML doc <summary>; empty text writes nothing.
+func (c *csharpGenerator) summary(text string) {
+	if text == "" {
+		return
+	}
+	lines := strings.Split(xmlEscaper.Replace(text), "\n")
+	if len(lines) == 1 {
+		c.line("/// <summary>%s</summary>", lines[0])
+		return
+	}
+	c.line("/// <summary>")
+	for _, line := range lines {
+		c.line("%s", strings.TrimRight("/// "+line, " "))
+	}
+	c.line("/// </summary>")
+}
+
+func (c *csharpGenerator) open() {
+	c.line("{")
+	c.indent++
+}
+
+func (c *csharpGenerator) close(suffix string) {
+	c.indent--
+	c.line("}" + suffix)
+}
+
+func (c *csharpGenerator) generateProgram(program *ast.Program, namespace string) {
+	c.line("// <auto-generated>")
+	c.line("// Generated by skconf from %s. Do not edit.", c.unit.File)
+	c.line("// </auto-generated>")
+	c.line("")
+	c.line("using System;")
+	c.line("using System.Collections.Generic;")
+	c.line("")
+	c.line("namespace %s", namespace)
+	c.open()
+
+	c.generateHost(program)
+
+	var skills, states []*definition
+	var topLevel []ast.Statement
+	var funcs []*ast.FunctionDef
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.SkillDef:
+			skills = append(skills, newDefinition("skill", s.Name.Value, s.Doc, s.MergedProperties()))
+		case *ast.StateDef:
+			states = append(states, newDefinition("state", s.Name.Value, s.Doc, s.MergedProperties()))
+		case *ast.VarStatement:
+			c.globals[s.Name.Value] = true
+			topLevel = append(topLevel, stmt)
+		case *ast.FunctionDef:
+			if name, ok := s.Name.(*ast.Identifier); ok {
+				c.funcs[name.Value] = s
+				funcs = append(funcs, s)
+				continue
+			}
+			topLevel = append(topLevel, stmt)
+		case *ast.ConstStatement:
+			// Folded into its uses before code generation.
+		case *ast.TemplateDef:
+			// Expanded into the skills and states that use it.
+		case *ast.EnumDef:
+			c.generateEnum(s)
+		default:
+			topLevel = append(topLevel, stmt)
+		}
+	}
+
+	c.line("/// <summary>Top-level variables, statements and functions of %s.</summary>", c.unit.File)
+	c.line("public static class Module")
+	c.open()
+	for _, name := range sortedNames(c.globals) {
+		c.line("public static object %s;", csLocal(name))
+	}
+	if len(c.globals) > 0 {
+		c.line("")
+	}
+	c.line("public static void Init(IHost host)")
+	c.open()
+	c.inInit = true
+	c.pushScope()
+	for _, stmt := range topLevel {
+		c.generateStatement(stmt)
+	}
+	c.popScope()
+	c.inInit = false
+	c.close("")
+	for _, fn := range funcs {
+		c.line("")
+		c.summary(fn.Doc)
+		c.generateMethod("public static", csLocal(fn.Name.(*ast.Identifier).Value), fn)
+	}
+	c.close("")
+
+	for _, def := range skills {
+		c.line("")
+		c.generateDefinition(def)
+	}
+	for _, def := range states {
+		c.line("")
+		c.generateDefinition(def)
+	}
+
+	c.line("")
+	c.line("public static class Registry")
+	c.open()
+	c.generateRegistry("Skills", skills)
+	c.line("")
+	c.generateRegistry("States", states)
+	c.close("")
+
+	c.close("")
+}
+
+// generateHost declares IHost and one interface per host module.
+// Implementations carry the context of the skill being executed.
+func (c *csharpGenerator) generateHost(program *ast.Program) {
+	modules := generator.HostAPI(program, c.manifest)
+
+	c.line("/// <summary>Gives generated code access to the game.</summary>")
+	c.line("public interface IHost")
+	c.open()
+	for _, mod := range modules {
+		c.line("%s %s { get; }", moduleType(mod.Name), mod.Name)
+	}
+	c.line("/// <summary>Returns a name the DSL uses without declaring it, such as EM or print.</summary>")
+	c.line("object Global(string name);")
+	c.close("")
+
+	for _, mod := range modules {
+		c.line("")
+		c.line("public interface %s", moduleType(mod.Name))
+		c.open()
+		for _, fn := range mod.Functions {
+			c.line("%s;", csSignature(fn.Name, fn.Signature, c.manifest))
+		}
+		c.close("")
+	}
+	c.line("")
+}
+
+// generateEnum declares an enum backed by long. Uses of the members are
+// folded to their values, so the DSL only ever sees longs; the type is for
+// host code.
+func (c *csharpGenerator) generateEnum(enum *ast.EnumDef) {
+	c.summary(enum.Doc)
+	c.line("public enum %s : long", typeName(enum.Name.Value))
+	c.open()
+	for _, member := range enum.Members {
+		c.summary(member.Doc)
+		c.line("%s = %d,", generator.PascalCase(member.Name.Value), member.Value.(*ast.Integer).Value)
+	}
+	c.close("")
+	c.line("")
+}
+
+func (c *csharpGenerator) generateRegistry(name string, defs []*definition) {
+	c.line("public static readonly Dictionary<long, Func<IHost, object>> %s = new Dictionary<long, Func<IHost, object>>", name)
+	c.open()
+	for _, def := range defs {
+		tid := ast.FindPropertyByName("tid", def.properties)
+		if tid == "" {
+			continue
+		}
+		c.line("{ %sL, host => new %s(host) },", tid, def.typeName)
+	}
+	c.close(";")
+}
+
+func moduleType(name string) string {
+	return "I" + generator.PascalCase(name) + "Module"
+}
+
+// csSignature renders a host function declaration. Dimension types take
+// the C# type of their base unit in m.
+func csSignature(name string, sig *manifest.Signature, m *manifest.Manifest) string {
+	if sig == nil {
+		return "object " + name + "(params object[] args)"
+	}
+	params := make([]string, len(sig.Params))
+	for i, param := range sig.Params {
+		typ := csType(m.ValueType(param.Type))
+		if sig.Variadic && i == len(sig.Params)-1 {
+			typ = "params " + typ + "[]"
+		}
+		params[i] = typ + " " + csLocal(param.Name)
+	}
+	return csType(m.ValueType(sig.Returns)) + " " + name + "(" + strings.Join(params, ", ") + ")"
+}
+
+// csType maps a manifest type to a C# type. Host types such as Unit are
+// opaque to the generated code and map to object.
+func csType(typ string) string {
+	switch typ {
+	case "int":
+		return "long"
+	case "float":
+		return "double"
+	case "bool", "string":
+		return typ
+	case "table":
+		return "DslTable"
+	default:
+		return "object"
+	}
+}
+
+// csZero renders the default value of the C# type of a manifest type.
+func csZero(typ string) string {
+	switch csType(typ) {
+	case "long", "double":
+		return "0"
+	case "bool":
+		return "false"
+	case "string":
+		return `""`
+	default:
+		return "null"
+	}
+}
+
+// csConvert converts a dynamic value to the C# type of a manifest type.
+func csConvert(typ, value string) string {
+	switch typ {
+	case "int":
+		return "Dsl.ToLong(" + value + ")"
+	case "float":
+		return "Dsl.ToDouble(" + value + ")"
+	case "bool":
+		return "Dsl.Truthy(" + value + ")"
+	case "string":
+		return "Dsl.ToStr(" + value + ")"
+	case "table":
+		return "Dsl.ToTable(" + value + ")"
+	default:
+		return value
+	}
+}
@@ -0,0 +1,106 @@
+package csharp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+var keywords = map[string]bool{
+	"abstract": true, "as": true, "base": true, "bool": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "checked": true, "class": true, "const": true,
+	"continue": true, "decimal": true, "default": true, "delegate": true, "do": true,
+	"double": true, "else": true, "enum": true, "event": true, "explicit": true, "extern": true,
+	"false": true, "finally": true, "fixed": true, "float": true, "for": true, "foreach": true,
+	"goto": true, "if": true, "implicit": true, "in": true, "int": true, "interface": true,
+	"internal": true, "is": true, "lock": true, "long": true, "namespace": true, "new": true,
+	"null": true, "object": true, "operator": true, "out": true, "override": true, "params": true,
+	"private": true, "protected": true, "public": true, "readonly": true, "ref": true,
+	"return": true, "sbyte": true, "sealed": true, "short": true, "sizeof": true,
+	"stackalloc": true, "static": true, "string": true, "struct": true, "switch": true,
+	"this": true, "throw": true, "true": true, "try": true, "typeof": true, "uint": true,
+	"ulong": true, "unchecked": true, "unsafe": true, "ushort": true, "using": true,
+	"virtual": true, "void": true, "volatile": true, "while": true,
+}
+
+// reservedTypes are the type names the generator declares itself.
+var reservedTypes = map[string]bool{
+	"Module": true, "Registry": true, "Dsl": true, "DslTable": true, "DslFunc": true, "IHost": true,
+}
+
+// csLocal turns a DSL identifier into a C# identifier for locals, fields
+// of Module and parameters.
+func csLocal(name string) string {
+	name = strings.ReplaceAll(name, "-", "_")
+	switch {
+	case keywords[name]:
+		return "@" + name
+	case name == "host" || reservedTypes[name] || strings.HasPrefix(name, "dsl"):
+		return name + "_"
+	}
+	return name
+}
+
+func typeName(name string) string {
+	typ := generator.PascalCase(name)
+	if reservedTypes[typ] {
+		typ += "Def"
+	}
+	return typ
+}
+
+func isIdentifier(name string) bool {
+	if name == "" || keywords[name] {
+		return false
+	}
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || i > 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// quote renders s as a C# string literal.
+func quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case 0:
+			sb.WriteString(`\0`)
+		default:
+			if unicode.IsPrint(r) {
+				sb.WriteRune(r)
+			} else if r > 0xffff {
+				sb.WriteString(fmt.Sprintf(`\U%08x`, r))
+			} else {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,371 @@
+package csharp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+// pushScope opens a block scope. The outermost scope of a method also
+// resets the set of C# names in use, since C# forbids a local from
+// shadowing another local anywhere in the same method.
+func (c *csharpGenerator) pushScope() {
+	if len(c.scopes) == 0 {
+		c.used = map[string]bool{}
+		c.types = map[string]string{}
+	}
+	c.scopes = append(c.scopes, map[string]string{})
+}
+
+func (c *csharpGenerator) popScope() {
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+// declare introduces a local of the manifest type typ, or a dynamic one if
+// typ is empty, in the innermost scope and returns its C# name, renamed if
+// the method already uses it.
+func (c *csharpGenerator) declare(name, typ string) string {
+	local := c.unique(csLocal(name))
+	c.scopes[len(c.scopes)-1][name] = local
+	if typ != "" {
+		c.types[local] = typ
+	}
+	return local
+}
+
+// unique reserves a C# local name for the current method.
+func (c *csharpGenerator) unique(local string) string {
+	base := local
+	for c.used[local] {
+		c.renames++
+		local = fmt.Sprintf("%s_%d", base, c.renames)
+	}
+	c.used[local] = true
+	return local
+}
+
+func (c *csharpGenerator) lookup(name string) (string, bool) {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if local, ok := c.scopes[i][name]; ok {
+			return local, true
+		}
+	}
+	return "", false
+}
+
+// generateBody writes the statements of a method whose result has the
+// manifest type c.result and makes sure it ends in a return.
+func (c *csharpGenerator) generateBody(body *ast.CodeBlock) {
+	returns := false
+	if body != nil {
+		for _, stmt := range body.Statements {
+			c.generateStatement(stmt)
+		}
+		if n := len(body.Statements); n > 0 {
+			returns = generator.Terminates(body.Statements[n-1])
+		}
+	}
+	if !returns {
+		c.line("return %s;", csZero(c.result))
+	}
+}
+
+func (c *csharpGenerator) generateBlock(body *ast.CodeBlock) {
+	c.open()
+	c.pushScope()
+	if body != nil {
+		for _, stmt := range body.Statements {
+			c.generateStatement(stmt)
+		}
+	}
+	c.popScope()
+	c.close("")
+}
+
+// budget declares the pass counter of loop if it counts its passes against
+// the loop budget, and returns its name; otherwise it returns "".
+func (c *csharpGenerator) budget(loop ast.Statement) string {
+	if !c.unit.Budgeted(loop) {
+		return ""
+	}
+	counter := c.unique("dslPasses")
+	c.line("var %s = 0;", counter)
+	return counter
+}
+
+// generateLoopBlock writes the body of loop, which first counts the pass
+// if counter is not empty, followed by tail, such as the condition of a
+// do ... while.
+func (c *csharpGenerator) generateLoopBlock(loop ast.Statement, counter string, body *ast.CodeBlock, tail string) {
+	c.open()
+	c.pushScope()
+	if counter != "" {
+		c.line("if (++%s > %d) throw new InvalidOperationException(%s);", counter, c.unit.LoopBudget, quote(c.unit.BudgetError(loop)))
+	}
+	if body != nil {
+		for _, stmt := range body.Statements {
+			c.generateStatement(stmt)
+		}
+	}
+	c.popScope()
+	c.close(tail)
+}
+
+func (c *csharpGenerator) generateStatement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VarStatement:
+		c.generateVarStatement(s)
+	case *ast.ExprStmt, *ast.AssignStatement:
+		if simple, ok := c.simpleStatement(s); ok {
+			c.line("%s;", simple)
+		}
+	case *ast.IfStatement:
+		c.generateIfStatement(s)
+	case *ast.MatchStatement:
+		c.generateMatchStatement(s)
+	case *ast.ForStatement:
+		c.generateForStatement(s)
+	case *ast.WhileStatement:
+		counter := c.budget(s)
+		c.line("while (%s)", c.condition(s.Condition))
+		c.generateLoopBlock(s, counter, s.Body, "")
+	case *ast.RepeatStatement:
+		counter := c.budget(s)
+		c.line("do")
+		c.generateLoopBlock(s, counter, s.Body, fmt.Sprintf(" while (%s);", generator.Not(c.condition(s.Condition))))
+	case *ast.ReturnStatement:
+		c.generateReturnStatement(s)
+	case *ast.BreakStatement:
+		c.line("break;")
+	case *ast.ContinueStatement:
+		c.line("continue;")
+	case *ast.FunctionDef: // unnamed; named ones are static methods
+		c.line("_ = %s;", c.expression(s))
+	default:
+		c.errorf(stmt, "csharp: unsupported statement %T", stmt)
+	}
+}
+
+func (c *csharpGenerator) generateVarStatement(stmt *ast.VarStatement) {
+	global := c.inInit && len(c.scopes) == 1 && c.globals[stmt.Name.Value]
+	if _, ok := c.scopes[len(c.scopes)-1][stmt.Name.Value]; stmt.Recursive() && !global && !ok {
+		c.line("object %s = null;", c.declare(stmt.Name.Value, "")) // the function refers to itself
+	}
+	value := c.expression(stmt.Value)
+
+	if global {
+		c.line("Module.%s = %s;", csLocal(stmt.Name.Value), value)
+		return
+	}
+	if local, ok := c.scopes[len(c.scopes)-1][stmt.Name.Value]; ok {
+		c.line("%s = %s;", local, value)
+		return
+	}
+
+	c.line("object %s = %s;", c.declare(stmt.Name.Value, ""), value)
+}
+
+// updateFuncs are the runtime helpers applying the arithmetic of x op= v.
+var updateFuncs = map[string]string{
+	"+": "Dsl.Add",
+	"-": "Dsl.Sub",
+	"*": "Dsl.Mul",
+	"/": "Dsl.Div",
+}
+
+// simpleStatement renders an expression statement or an assignment as one
+// C# statement expression, so it also fits the iterator of a for loop.
+func (c *csharpGenerator) simpleStatement(stmt ast.Statement) (string, bool) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		if call, ok := s.Expression.(*ast.FunctionCall); ok {
+			return c.expression(call), true
+		}
+		return "_ = " + c.expression(s.Expression), true
+	case *ast.AssignStatement:
+		return c.assignStatement(s)
+	}
+	c.errorf(stmt, "csharp: unsupported statement %T", stmt)
+	return "", false
+}
+
+// assignStatement renders an assignment. Updating a member or an element
+// goes through Dsl.UpdateIndex, which evaluates the object and key once.
+func (c *csharpGenerator) assignStatement(stmt *ast.AssignStatement) (string, bool) {
+	op, operand := stmt.Update()
+	if op == "" {
+		return c.assignment(stmt.Target, c.expression(stmt.Value))
+	}
+
+	switch t := stmt.Target.(type) {
+	case *ast.DotExpression:
+		if right, ok := t.Right.(*ast.Identifier); ok {
+			return "Dsl.UpdateIndex(" + c.expression(t.Left) + ", " + quote(right.Value) + ", " + updateFuncs[op] + ", " + c.expression(operand) + ")", true
+		}
+	case *ast.IndexExpression:
+		return "Dsl.UpdateIndex(" + c.expression(t.Left) + ", " + c.expression(t.Index) + ", " + updateFuncs[op] + ", " + c.expression(operand) + ")", true
+	}
+	return c.assignment(stmt.Target, c.infix(&ast.InfixExpression{BaseNode: stmt.BaseNode, Left: stmt.Target, Operator: op, Right: operand}))
+}
+
+func (c *csharpGenerator) assignment(target ast.Expression, value string) (string, bool) {
+	switch t := target.(type) {
+	case *ast.Identifier:
+		if local, ok := c.lookup(t.Value); ok {
+			return local + " = " + csConvert(c.types[local], value), true
+		}
+		if c.globals[t.Value] {
+			return "Module." + csLocal(t.Value) + " = " + value, true
+		}
+		c.errorf(t, "csharp: cannot assign to undeclared name %s", t.Value)
+		return "", false
+	case *ast.DotExpression:
+		if right, ok := t.Right.(*ast.Identifier); ok && !t.Optional {
+			return "Dsl.SetIndex(" + c.expression(t.Left) + ", " + quote(right.Value) + ", " + value + ")", true
+		}
+	case *ast.IndexExpression:
+		return "Dsl.SetIndex(" + c.expression(t.Left) + ", " + c.expression(t.Index) + ", " + value + ")", true
+	}
+	c.errorf(target, "csharp: cannot assign to %s", target)
+	return "", false
+}
+
+func (c *csharpGenerator) generateIfStatement(stmt *ast.IfStatement) {
+	c.line("if (%s)", c.condition(stmt.Condition))
+	c.generateBlock(stmt.Consequence)
+	for _, alt := range stmt.Alternatives {
+		if alt.Condition != nil {
+			c.line("else if (%s)", c.condition(alt.Condition))
+		} else {
+			c.line("else")
+		}
+		c.generateBlock(alt.Consequence)
+	}
+}
+
+// generateMatchStatement writes a match as a switch over the subject. Since
+// break in a switch leaves the switch, a match with an arm breaking out of
+// the loop around it is written as an if chain instead.
+func (c *csharpGenerator) generateMatchStatement(stmt *ast.MatchStatement) {
+	if len(stmt.Arms) == 0 {
+		c.line("_ = %s;", c.expression(stmt.Subject)) // an empty switch is a warning
+		return
+	}
+	if generator.MatchBreaks(stmt) {
+		if stmt.Arms[0].Patterns == nil {
+			c.line("_ = %s;", c.expression(stmt.Subject))
+			c.generateBlock(stmt.Arms[0].Body)
+			return
+		}
+		match := c.unique("dslMatch")
+		c.line("object %s = %s;", match, c.expression(stmt.Subject))
+		for i, arm := range stmt.Arms {
+			if arm.Patterns == nil {
+				c.line("else")
+			} else {
+				conds := make([]string, len(arm.Patterns))
+				for j, pattern := range arm.Patterns {
+					conds[j] = fmt.Sprintf("Dsl.Eq(%s, %s)", match, c.expression(pattern))
+				}
+				if i == 0 {
+					c.line("if (%s)", strings.Join(conds, " || "))
+				} else {
+					c.line("else if (%s)", strings.Join(conds, " || "))
+				}
+			}
+			c.generateBlock(arm.Body)
+		}
+		return
+	}
+
+	c.line("switch (Dsl.Key(%s))", c.expression(stmt.Subject))
+	c.open()
+	for _, arm := range stmt.Arms {
+		if arm.Patterns == nil {
+			c.line("default:")
+		}
+		for _, pattern := range arm.Patterns {
+			c.line("case %s:", c.expression(generator.CaseLabel(pattern)))
+		}
+		c.open()
+		c.pushScope()
+		for _, s := range arm.Body.Statements {
+			c.generateStatement(s)
+		}
+		if !generator.LeavesCase(arm.Body) {
+			c.line("break;")
+		}
+		c.popScope()
+		c.close("")
+	}
+	c.close("")
+}
+
+func (c *csharpGenerator) generateForStatement(stmt *ast.ForStatement) {
+	if stmt.IsRangeForm {
+		pair := c.unique("dslPair")
+		c.line("foreach (var %s in Dsl.Pairs(%s))", pair, c.expression(stmt.RangeValue))
+		c.open()
+		c.pushScope()
+		if stmt.Key != nil {
+			c.line("object %s = %s.Key;", c.declare(stmt.Key.Value, ""), pair)
+		}
+		c.line("object %s = %s.Value;", c.declare(stmt.Value.Value, ""), pair)
+		c.pushScope()
+		if stmt.Body != nil {
+			for _, s := range stmt.Body.Statements {
+				c.generateStatement(s)
+			}
+		}
+		c.popScope()
+		c.popScope()
+		c.close("")
+		return
+	}
+
+	if stmt.Init == nil && stmt.Post == nil {
+		counter := c.budget(stmt)
+		cond := "true"
+		if stmt.Condition != nil {
+			cond = c.condition(stmt.Condition)
+		}
+		c.line("while (%s)", cond)
+		c.generateLoopBlock(stmt, counter, stmt.Body, "")
+		return
+	}
+
+	c.open()
+	c.pushScope()
+	if stmt.Init != nil {
+		c.generateStatement(stmt.Init)
+	}
+	counter := c.budget(stmt)
+	cond, post := "", ""
+	if stmt.Condition != nil {
+		cond = c.condition(stmt.Condition)
+	}
+	if stmt.Post != nil {
+		post, _ = c.simpleStatement(stmt.Post)
+	}
+	c.line("for (; %s; %s)", cond, post)
+	c.generateLoopBlock(stmt, counter, stmt.Body, "")
+	c.popScope()
+	c.close("")
+}
+
+func (c *csharpGenerator) generateReturnStatement(stmt *ast.ReturnStatement) {
+	if c.inInit {
+		if stmt.ReturnValue != nil {
+			c.errorf(stmt, "csharp: top-level return cannot have a value")
+		}
+		c.line("return;")
+		return
+	}
+	if stmt.ReturnValue == nil {
+		c.line("return %s;", csZero(c.result))
+		return
+	}
+	c.line("return %s;", c.convert(c.result, stmt.ReturnValue))
+}
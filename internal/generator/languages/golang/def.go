@@ -0,0 +1,153 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+)
+
+// definition is a skill or state about to become a Go struct.
+type definition struct {
+	kind       string
+	name       string
+	doc        string
+	typeName   string
+	properties []*ast.PropertyDef
+}
+
+func newDefinition(kind, name, doc string, properties []*ast.PropertyDef) *definition {
+	return &definition{
+		kind:       kind,
+		name:       name,
+		doc:        doc,
+		typeName:   goExported(name),
+		properties: properties,
+	}
+}
+
+// fieldType picks the Go type of a literal property; everything that is
+// only known at runtime is stored as any.
+func fieldType(exp ast.Expression) string {
+	switch exp.(type) {
+	case *ast.Integer:
+		return "int64"
+	case *ast.Float:
+		return "float64"
+	case *ast.String, *ast.InterpolatedString:
+		return "string"
+	case *ast.Boolean:
+		return "bool"
+	case *ast.TableDef:
+		return "*Table"
+	default:
+		return "any"
+	}
+}
+
+func (g *goGenerator) generateDefinition(def *definition) {
+	var fields, hooks []*ast.PropertyDef
+	for _, prop := range def.properties {
+		if _, ok := prop.Key.(*ast.Identifier); !ok {
+			continue
+		}
+		if _, ok := prop.Value.(*ast.FunctionDef); ok {
+			hooks = append(hooks, prop)
+		} else {
+			fields = append(fields, prop)
+		}
+	}
+
+	g.line("// %s is %s %s.", def.typeName, def.kind, def.name)
+	if def.doc != "" {
+		g.line("//")
+		g.comment(def.doc)
+	}
+	g.line("type %s struct {", def.typeName)
+	g.indent++
+	for _, prop := range fields {
+		g.comment(prop.Doc)
+		typ := fieldType(prop.Value)
+		if enum, _, ok := g.enumMember(prop.Value); ok {
+			typ = enum
+		}
+		g.line("%s %s", goExported(prop.Key.(*ast.Identifier).Value), typ)
+	}
+	g.indent--
+	g.line("}")
+	g.line("")
+
+	g.line("// New%s creates %s %s.", def.typeName, def.kind, def.name)
+	g.line("func New%s(host Host) *%s {", def.typeName, def.typeName)
+	g.indent++
+	g.line("return &%s{", def.typeName)
+	g.indent++
+	g.pushScope()
+	for _, prop := range fields {
+		value := g.fieldValue(prop.Value)
+		if _, member, ok := g.enumMember(prop.Value); ok {
+			value = member
+		}
+		g.line("%s: %s,", goExported(prop.Key.(*ast.Identifier).Value), value)
+	}
+	g.popScope()
+	g.indent--
+	g.line("}")
+	g.indent--
+	g.line("}")
+	g.line("")
+
+	for _, prop := range hooks {
+		g.comment(prop.Doc)
+		g.generateFunction(fmt.Sprintf("func (self *%s) %s", def.typeName, goExported(prop.Key.(*ast.Identifier).Value)), prop.Value.(*ast.FunctionDef))
+	}
+}
+
+// generateFunction declares fn, a hook or a top-level function, as the Go
+// function or method head. It takes the host first, then the parameters of
+// fn with the Go types of their annotations.
+func (g *goGenerator) generateFunction(head string, fn *ast.FunctionDef) {
+	g.pushScope()
+	params := []string{"host Host"}
+	for i, param := range fn.Parameters {
+		typ := g.manifest.ValueType(fn.ParamType(i))
+		g.declare(param.Value, typ)
+		params = append(params, goLocal(param.Value)+" "+goType(typ))
+	}
+	g.result = g.manifest.ValueType(fn.ReturnType())
+	g.line("%s(%s) %s {", head, strings.Join(params, ", "), goType(g.result))
+	g.generateBody(fn.Body)
+	g.line("}")
+	g.line("")
+	g.result = ""
+	g.popScope()
+}
+
+// enumMember returns the Go type and constant of exp if it is a member of
+// an enum of the unit. Members of imported and host enums have no Go type
+// and stay integers.
+func (g *goGenerator) enumMember(exp ast.Expression) (typ, member string, ok bool) {
+	lit, isInt := exp.(*ast.Integer)
+	if !isInt || g.enums[lit.Enum] == nil {
+		return "", "", false
+	}
+	typ = goExported(lit.Enum)
+	for _, m := range g.enums[lit.Enum].Members {
+		if m.Value.(*ast.Integer).Value == lit.Value {
+			return typ, typ + goExported(m.Name.Value), true
+		}
+	}
+	return typ, fmt.Sprintf("%s(%d)", typ, lit.Value), true
+}
+
+// fieldValue renders a property value for a typed struct field.
+func (g *goGenerator) fieldValue(exp ast.Expression) string {
+	switch v := exp.(type) {
+	case *ast.Integer:
+		return fmt.Sprintf("%d", v.Value)
+	case *ast.Float:
+		return formatFloat(v.Value)
+	default:
+		return g.expression(exp)
+	}
+}
@@ -0,0 +1,290 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// expression renders exp as a Go expression of type any (or bool, for
+// comparisons, which is assignable to any).
+func (g *goGenerator) expression(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		if g.isLocal(e.Value) || g.globals[e.Value] {
+			return goLocal(e.Value)
+		}
+		if fn := g.funcs[e.Value]; fn != nil {
+			return g.functionValue(e.Value, fn)
+		}
+		if g.manifest.IsModule(e.Value) {
+			g.errorf(e, "go: host module %s can only be called", e.Value)
+			return "nil"
+		}
+		return "host.Global(" + quote(e.Value) + ")"
+	case *ast.Integer:
+		return fmt.Sprintf("int64(%d)", e.Value)
+	case *ast.Float:
+		return "float64(" + formatFloat(e.Value) + ")"
+	case *ast.String:
+		return quote(e.Value)
+	case *ast.InterpolatedString:
+		parts := make([]string, len(e.Parts))
+		for i, part := range e.Parts {
+			parts[i] = g.expression(part)
+		}
+		return "dslConcat(" + strings.Join(parts, ", ") + ")"
+	case *ast.Boolean:
+		return strconv.FormatBool(e.Value)
+	case *ast.TableDef:
+		return g.table(e)
+	case *ast.PrefixExpression:
+		switch e.Operator {
+		case "-":
+			return "dslNeg(" + g.expression(e.Right) + ")"
+		case "~":
+			return "dslBnot(" + g.expression(e.Right) + ")"
+		case "not":
+			return "!" + g.condition(e.Right)
+		}
+	case *ast.InfixExpression:
+		return g.infix(e)
+	case *ast.DotExpression:
+		if right, ok := e.Right.(*ast.Identifier); ok {
+			if e.Optional {
+				return "dslIndexOpt(" + g.expression(e.Left) + ", " + quote(right.Value) + ")"
+			}
+			return "dslIndex(" + g.expression(e.Left) + ", " + quote(right.Value) + ")"
+		}
+	case *ast.IndexExpression:
+		return "dslIndex(" + g.expression(e.Left) + ", " + g.expression(e.Index) + ")"
+	case *ast.ConditionalExpression:
+		return "func() any {\nif " + g.condition(e.Condition) + " {\nreturn " + g.expression(e.Consequence) + "\n}\n" +
+			"return " + g.expression(e.Alternative) + "\n}()"
+	case *ast.FunctionCall:
+		return g.call(e)
+	case *ast.FunctionDef:
+		return g.function(e)
+	}
+
+	g.errorf(exp, "go: unsupported expression %T", exp)
+	return "nil"
+}
+
+// condition renders exp as a Go bool expression.
+func (g *goGenerator) condition(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Boolean:
+		return strconv.FormatBool(e.Value)
+	case *ast.PrefixExpression:
+		if e.Operator == "not" {
+			return "!" + g.condition(e.Right)
+		}
+	case *ast.InfixExpression:
+		switch e.Operator {
+		case "and":
+			return "(" + g.condition(e.Left) + " && " + g.condition(e.Right) + ")"
+		case "or":
+			return "(" + g.condition(e.Left) + " || " + g.condition(e.Right) + ")"
+		case "==", "!=", "<", ">", "<=", ">=":
+			return g.infix(e)
+		}
+	}
+	return "dslTruthy(" + g.expression(exp) + ")"
+}
+
+// convert renders exp as a value of the Go type of the manifest type typ.
+// Conditions, literals and locals of the type need no runtime conversion.
+func (g *goGenerator) convert(typ string, exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		if typ != "" && g.typeOf(e.Value) == typ {
+			return g.expression(exp)
+		}
+	case *ast.Integer:
+		if typ == "int" || typ == "float" {
+			return g.fieldValue(exp)
+		}
+	case *ast.Float:
+		if typ == "float" {
+			return g.fieldValue(exp)
+		}
+	case *ast.String:
+		if typ == "string" {
+			return g.expression(exp)
+		}
+	}
+	if typ == "bool" {
+		return g.condition(exp)
+	}
+	return goConvert(typ, g.expression(exp))
+}
+
+func (g *goGenerator) infix(exp *ast.InfixExpression) string {
+	left, right := g.expression(exp.Left), g.expression(exp.Right)
+
+	switch exp.Operator {
+	case "+":
+		return "dslAdd(" + left + ", " + right + ")"
+	case "-":
+		return "dslSub(" + left + ", " + right + ")"
+	case "*":
+		return "dslMul(" + left + ", " + right + ")"
+	case "/":
+		return "dslDiv(" + left + ", " + right + ")"
+	case "//":
+		return "dslIntDiv(" + left + ", " + right + ")"
+	case "%":
+		return "dslMod(" + left + ", " + right + ")"
+	case "^":
+		return "dslPow(" + left + ", " + right + ")"
+	case "&":
+		return "dslBand(" + left + ", " + right + ")"
+	case "|":
+		return "dslBor(" + left + ", " + right + ")"
+	case "~":
+		return "dslBxor(" + left + ", " + right + ")"
+	case "<<":
+		return "dslShl(" + left + ", " + right + ")"
+	case ">>":
+		return "dslShr(" + left + ", " + right + ")"
+	case "==":
+		return "dslEq(" + left + ", " + right + ")"
+	case "!=":
+		return "!dslEq(" + left + ", " + right + ")"
+	case "<":
+		return "dslLess(" + left + ", " + right + ", false)"
+	case "<=":
+		return "dslLess(" + left + ", " + right + ", true)"
+	case ">":
+		return "dslGreater(" + left + ", " + right + ", false)"
+	case ">=":
+		return "dslGreater(" + left + ", " + right + ", true)"
+	case "and":
+		return "dslAnd(" + left + ", func() any { return " + right + " })"
+	case "or":
+		return "dslOr(" + left + ", func() any { return " + right + " })"
+	}
+
+	g.errorf(exp, "go: unsupported operator %s", exp.Operator)
+	return "nil"
+}
+
+func (g *goGenerator) table(table *ast.TableDef) string {
+	var array, pairs []string
+	for _, prop := range table.Properties {
+		value := g.expression(prop.Value)
+		switch key := prop.Key.(type) {
+		case nil:
+			array = append(array, value)
+		case *ast.Identifier:
+			pairs = append(pairs, quote(key.Value), value)
+		default:
+			pairs = append(pairs, g.expression(prop.Key), value)
+		}
+	}
+
+	args := "nil"
+	if len(array) > 0 {
+		args = "[]any{" + strings.Join(array, ", ") + "}"
+	}
+	for _, pair := range pairs {
+		args += ", " + pair
+	}
+	return "NewTable(" + args + ")"
+}
+
+func (g *goGenerator) call(call *ast.FunctionCall) string {
+	if id, ok := call.Function.(*ast.Identifier); ok && !g.isLocal(id.Value) && g.funcs[id.Value] != nil {
+		fn := g.funcs[id.Value]
+		args := []string{"host"}
+		for i, arg := range call.Arguments {
+			args = append(args, g.convert(g.manifest.ValueType(fn.ParamType(i)), arg))
+		}
+		return goLocal(id.Value) + "(" + strings.Join(args, ", ") + ")"
+	}
+
+	args := make([]string, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		args[i] = g.expression(arg)
+	}
+
+	if module, name, ok := generator.HostCall(call, g.manifest); ok {
+		// sema has checked the function is declared and its arity.
+		if sig, ok := g.manifest.Function(module, name); ok {
+			for i := range args {
+				param := sig.Params[min(i, len(sig.Params)-1)]
+				args[i] = goConvert(g.manifest.ValueType(param.Type), args[i])
+			}
+		}
+		return "host." + module + "()." + name + "(" + strings.Join(args, ", ") + ")"
+	}
+
+	dot, ok := call.Function.(*ast.DotExpression)
+	if !ok || !dot.Optional {
+		if dot, ok := generator.MethodCall(call); ok {
+			return g.member(call, dot, g.expression(dot.Left), args)
+		}
+		return "dslCall(" + strings.Join(append([]string{g.expression(call.Function)}, args...), ", ") + ")"
+	}
+
+	// recv?.f(args) skips the call, arguments included, when recv is nil.
+	return "func() any {\nif dslRecv := " + g.expression(dot.Left) + "; dslRecv != nil {\n" +
+		"return " + g.member(call, dot, "dslRecv", args) + "\n}\nreturn nil\n}()"
+}
+
+// member renders a call of the member dot.Right of the value recv.
+func (g *goGenerator) member(call *ast.FunctionCall, dot *ast.DotExpression, recv string, args []string) string {
+	name := quote(dot.Right.(*ast.Identifier).Value)
+	if _, ok := generator.MethodCall(call); ok {
+		return "dslInvoke(" + strings.Join(append([]string{recv, name}, args...), ", ") + ")"
+	}
+	return "dslCall(" + strings.Join(append([]string{"dslIndex(" + recv + ", " + name + ")"}, args...), ", ") + ")"
+}
+
+// functionValue renders the top-level function name as a function value,
+// for when it is passed around rather than called.
+func (g *goGenerator) functionValue(name string, fn *ast.FunctionDef) string {
+	args := []string{"host"}
+	for i := range fn.Parameters {
+		args = append(args, goConvert(g.manifest.ValueType(fn.ParamType(i)), fmt.Sprintf("dslArg(args, %d)", i)))
+	}
+	return "Func(func(args ...any) any {\nreturn " + goLocal(name) + "(" + strings.Join(args, ", ") + ")\n})"
+}
+
+// function renders a function value. Parameters are read from the variadic
+// argument list so every function value has the same Func type; typed
+// parameters and results are converted to their type on the way.
+func (g *goGenerator) function(fn *ast.FunctionDef) string {
+	outer, outerIndent, outerInit, outerResult := g.buf, g.indent, g.inInit, g.result
+	g.buf, g.indent, g.inInit, g.result = &strings.Builder{}, 0, false, g.manifest.ValueType(fn.ReturnType())
+
+	g.pushScope()
+	g.buf.WriteString("Func(func(args ...any) any {\n")
+	g.indent++
+	for i, param := range fn.Parameters {
+		typ := g.manifest.ValueType(fn.ParamType(i))
+		g.declare(param.Value, typ)
+		g.line("%s := %s", goLocal(param.Value), goConvert(typ, fmt.Sprintf("dslArg(args, %d)", i)))
+		g.line("_ = %s", goLocal(param.Value))
+	}
+	g.indent--
+	g.generateBody(fn.Body)
+	g.buf.WriteString("})")
+	g.popScope()
+
+	out := g.buf.String()
+	g.buf, g.indent, g.inInit, g.result = outer, outerIndent, outerInit, outerResult
+	return out
+}
@@ -0,0 +1,332 @@
+package golang
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/generator"
+	"github.com/hsoul/skconf/internal/manifest"
+)
+
+const Language = "go"
+
+// Options understood by the go generator.
+const (
+	OptPackage = "package" // package name, defaults to the source file name
+)
+
+func init() {
+	generator.Register(Language, NewGoGenerator)
+}
+
+// goGenerator compiles a unit to a self-contained Go package: one struct
+// per skill and state with typed fields for literal properties and methods
+// for hooks, a Host interface derived from the API manifest, and registries
+// from tid to constructor. The package lives in a directory named after the
+// unit, next to a runtime.go holding the dynamic helpers.
+type goGenerator struct {
+	buf    *strings.Builder
+	indent int
+
+	unit     *generator.Unit
+	manifest *manifest.Manifest
+	diags    []diag.Diagnostic
+
+	pkg     string
+	globals map[string]bool             // top-level vars, declared at package level
+	funcs   map[string]*ast.FunctionDef // top-level functions, declared as Go functions
+	enums   map[string]*ast.EnumDef     // enums of the unit, declared as named types
+	scopes  []map[string]string         // locals of the enclosing blocks and their types
+	inInit  bool                        // generating Init, which has no result
+	result  string                      // manifest type of the result of the current function
+	passes  int                         // pass counters of loops declared so far
+}
+
+func NewGoGenerator(opts generator.Options) (generator.CodeGenerator, error) {
+	if err := opts.Check(Language, OptPackage); err != nil {
+		return nil, err
+	}
+	g := &goGenerator{pkg: opts.String(OptPackage, "")}
+	if g.pkg != "" && !isGoIdentifier(g.pkg) {
+		return nil, fmt.Errorf("option %q: %q is not a valid package name", OptPackage, g.pkg)
+	}
+	return g, nil
+}
+
+func (g *goGenerator) Generate(unit *generator.Unit) ([]generator.Artifact, []diag.Diagnostic) {
+	g.buf = &strings.Builder{}
+	g.indent = 0
+	g.unit = unit
+	g.manifest = unit.Manifest
+	g.diags = nil
+	g.globals = map[string]bool{}
+	g.funcs = map[string]*ast.FunctionDef{}
+	g.passes = 0
+	g.enums = map[string]*ast.EnumDef{}
+	g.scopes = nil
+
+	pkg := g.pkg
+	if pkg == "" {
+		pkg = packageName(path.Base(unit.Name))
+	}
+	dir := path.Join(path.Dir(unit.Name), pkg)
+
+	g.generateProgram(unit.Program, pkg)
+
+	code, err := format.Source([]byte(g.buf.String()))
+	if err != nil {
+		g.diags = append(g.diags, diag.Diagnostic{
+			Severity: diag.Error,
+			File:     unit.File,
+			Message:  fmt.Sprintf("go: generated code does not parse: %v", err),
+		})
+		code = []byte(g.buf.String())
+	}
+
+	return []generator.Artifact{
+		{Name: dir + "/" + pkg + ".go", Kind: generator.Code, Content: code},
+		{Name: dir + "/runtime.go", Kind: generator.Code, Content: []byte(strings.Replace(runtimeSource, "PACKAGE", pkg, 1))},
+	}, g.diags
+}
+
+func (g *goGenerator) errorf(node ast.Node, format string, args ...any) {
+	g.diags = append(g.diags, diag.Errorf(g.unit.File, node.Pos(), format, args...))
+}
+
+func (g *goGenerator) line(format string, args ...any) {
+	g.buf.WriteString(strings.Repeat("\t", g.indent))
+	g.buf.WriteString(fmt.Sprintf(format, args...))
+	g.buf.WriteString("\n")
+}
+
+// comment writes text as // lines; empty text writes nothing.
+func (g *goGenerator) comment(text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		g.line("%s", strings.TrimRight("// "+line, " "))
+	}
+}
+
+func (g *goGenerator) generateProgram(program *ast.Program, pkg string) {
+	g.line("// Code generated by skconf from %s. DO NOT EDIT.", g.unit.File)
+	g.line("")
+	g.line("package %s", pkg)
+	g.line("")
+
+	g.generateHost(program)
+
+	var skills, states []*definition
+	var topLevel []ast.Statement
+	var funcs []*ast.FunctionDef
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.SkillDef:
+			skills = append(skills, newDefinition("skill", s.Name.Value, s.Doc, s.MergedProperties()))
+		case *ast.StateDef:
+			states = append(states, newDefinition("state", s.Name.Value, s.Doc, s.MergedProperties()))
+		case *ast.VarStatement:
+			g.globals[s.Name.Value] = true
+			topLevel = append(topLevel, stmt)
+		case *ast.FunctionDef:
+			if name, ok := s.Name.(*ast.Identifier); ok {
+				g.funcs[name.Value] = s
+				funcs = append(funcs, s)
+				continue
+			}
+			topLevel = append(topLevel, stmt)
+		case *ast.ConstStatement:
+			// Folded into its uses before code generation.
+		case *ast.TemplateDef:
+			// Expanded into the skills and states that use it.
+		case *ast.EnumDef:
+			g.generateEnum(s)
+		default:
+			topLevel = append(topLevel, stmt)
+		}
+	}
+
+	for _, name := range sortedNames(g.globals) {
+		g.line("var %s any", goLocal(name))
+	}
+	if len(g.globals) > 0 {
+		g.line("")
+	}
+
+	g.line("// Init runs the top-level statements of %s.", g.unit.File)
+	g.line("func Init(host Host) {")
+	g.indent++
+	g.inInit = true
+	g.pushScope()
+	for _, stmt := range topLevel {
+		g.generateStatement(stmt)
+	}
+	g.popScope()
+	g.inInit = false
+	g.indent--
+	g.line("}")
+	g.line("")
+
+	for _, fn := range funcs {
+		g.comment(fn.Doc)
+		g.generateFunction("func "+goLocal(fn.Name.(*ast.Identifier).Value), fn)
+	}
+
+	for _, def := range skills {
+		g.generateDefinition(def)
+	}
+	for _, def := range states {
+		g.generateDefinition(def)
+	}
+
+	g.generateRegistry("Skills", "skill", skills)
+	g.generateRegistry("States", "state", states)
+}
+
+// generateHost declares the Host interface and one interface per host
+// module. Implementations carry the skill context the Lua backend passes
+// around as ctx.
+func (g *goGenerator) generateHost(program *ast.Program) {
+	modules := generator.HostAPI(program, g.manifest)
+
+	g.line("// Host gives generated code access to the game. Implementations carry")
+	g.line("// the context of the skill being executed.")
+	g.line("type Host interface {")
+	g.indent++
+	for _, mod := range modules {
+		g.line("%s() %s", mod.Name, moduleType(mod.Name))
+	}
+	g.line("// Global returns the value of a name the DSL uses without declaring it,")
+	g.line("// such as EM or print.")
+	g.line("Global(name string) any")
+	g.indent--
+	g.line("}")
+	g.line("")
+
+	for _, mod := range modules {
+		g.line("// %s is the %s host module.", moduleType(mod.Name), mod.Name)
+		g.line("type %s interface {", moduleType(mod.Name))
+		g.indent++
+		for _, fn := range mod.Functions {
+			g.line("%s%s", fn.Name, goSignature(fn.Signature, g.manifest))
+		}
+		g.indent--
+		g.line("}")
+		g.line("")
+	}
+}
+
+// generateEnum declares an enum as a named integer type with a constant
+// per member. Uses of the members are folded to their values, so the DSL
+// only ever sees int64s; the type is for host code.
+func (g *goGenerator) generateEnum(enum *ast.EnumDef) {
+	g.enums[enum.Name.Value] = enum
+	typeName := goExported(enum.Name.Value)
+	if enum.Doc != "" {
+		g.comment(enum.Doc)
+	} else {
+		g.line("// %s is the %s enum.", typeName, enum.Name.Value)
+	}
+	g.line("type %s int64", typeName)
+	g.line("")
+	g.line("const (")
+	g.indent++
+	for _, member := range enum.Members {
+		g.comment(member.Doc)
+		g.line("%s%s %s = %d", typeName, goExported(member.Name.Value), typeName, member.Value.(*ast.Integer).Value)
+	}
+	g.indent--
+	g.line(")")
+	g.line("")
+}
+
+func (g *goGenerator) generateRegistry(varName, kind string, defs []*definition) {
+	g.line("// %s maps %s tids to constructors.", varName, kind)
+	g.line("var %s = map[int64]func(Host) any{", varName)
+	g.indent++
+	for _, def := range defs {
+		tid := ast.FindPropertyByName("tid", def.properties)
+		if tid == "" {
+			continue
+		}
+		g.line("%s: func(host Host) any { return New%s(host) },", tid, def.typeName)
+	}
+	g.indent--
+	g.line("}")
+	g.line("")
+}
+
+func moduleType(name string) string {
+	return goExported(name) + "Module"
+}
+
+// goSignature renders the parameters and result of a host function.
+// Dimension types take the Go type of their base unit in m.
+func goSignature(sig *manifest.Signature, m *manifest.Manifest) string {
+	if sig == nil {
+		return "(args ...any) any"
+	}
+	params := make([]string, len(sig.Params))
+	for i, param := range sig.Params {
+		typ := goType(m.ValueType(param.Type))
+		if sig.Variadic && i == len(sig.Params)-1 {
+			typ = "..." + typ
+		}
+		params[i] = goLocal(param.Name) + " " + typ
+	}
+	return "(" + strings.Join(params, ", ") + ") " + goType(m.ValueType(sig.Returns))
+}
+
+// goType maps a manifest type to a Go type. Host types such as Unit are
+// opaque to the generated code and map to any.
+func goType(typ string) string {
+	switch typ {
+	case "int":
+		return "int64"
+	case "float":
+		return "float64"
+	case "bool", "string":
+		return typ
+	case "table":
+		return "*Table"
+	default:
+		return "any"
+	}
+}
+
+// goZero renders the zero value of the Go type of a manifest type.
+func goZero(typ string) string {
+	switch goType(typ) {
+	case "int64", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	case "string":
+		return `""`
+	default:
+		return "nil"
+	}
+}
+
+// goConvert converts a dynamic value to the Go type of a manifest type.
+func goConvert(typ, value string) string {
+	switch typ {
+	case "int":
+		return "dslInt(" + value + ")"
+	case "float":
+		return "dslFloat(" + value + ")"
+	case "bool":
+		return "dslTruthy(" + value + ")"
+	case "string":
+		return "dslString(" + value + ")"
+	case "table":
+		return "dslTable(" + value + ")"
+	default:
+		return value
+	}
+}
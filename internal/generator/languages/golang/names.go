@@ -0,0 +1,69 @@
+package golang
+
+import (
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+// reserved are names a DSL identifier must not take in generated code:
+// Go predeclared identifiers and the names the generator itself uses.
+var reserved = map[string]bool{
+	"any": true, "bool": true, "byte": true, "error": true, "float64": true,
+	"int": true, "int64": true, "string": true, "nil": true, "true": true,
+	"false": true, "len": true, "append": true, "make": true, "new": true,
+	"panic": true, "print": true, "println": true, "delete": true, "copy": true,
+	"cap": true, "close": true, "iota": true, "rune": true, "uint": true,
+	"host": true, "self": true, "args": true, "fmt": true, "math": true, "sort": true,
+}
+
+// goLocal turns a DSL identifier into a Go identifier for locals and
+// parameters.
+func goLocal(name string) string {
+	name = strings.ReplaceAll(name, "-", "_")
+	if token.IsKeyword(name) || reserved[name] || strings.HasPrefix(name, "dsl") {
+		return name + "_"
+	}
+	return name
+}
+
+// goExported turns DSL names into exported Go names: ack_s becomes AckS.
+// Names starting with a letter that has no upper case, such as 伤害, get an
+// X in front so that Go exports them.
+func goExported(name string) string {
+	name = generator.PascalCase(name)
+	if !token.IsExported(name) {
+		name = "X" + name
+	}
+	return name
+}
+
+func packageName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	pkg := sb.String()
+	if pkg == "" || !unicode.IsLetter([]rune(pkg)[0]) || token.IsKeyword(pkg) {
+		pkg = "skills" + pkg
+	}
+	return pkg
+}
+
+func isGoIdentifier(name string) bool {
+	return token.IsIdentifier(name)
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
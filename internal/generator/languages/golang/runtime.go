@@ -0,0 +1,415 @@
+package golang
+
+// runtimeSource holds the helpers every generated package relies on. They
+// implement the dynamic semantics of the DSL (Lua-like truthiness, numbers
+// that are either int64 or float64, tables with 1-based positional values).
+const runtimeSource = `// Code generated by skconf. DO NOT EDIT.
+
+package PACKAGE
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Func is a DSL function value.
+type Func func(args ...any) any
+
+// Object is implemented by host values whose members DSL code reads and
+// whose methods it calls, such as the unit UE.GetTarget(t) returns.
+type Object interface {
+	Member(name string) any
+	Invoke(method string, args ...any) any
+}
+
+// Table is a DSL table. Positional values live in Array, so index 1 is
+// Array[0]; all other keys live in Hash.
+type Table struct {
+	Array []any
+	Hash  map[any]any
+}
+
+// NewTable creates a table from positional values and key/value pairs.
+// As in Lua, a positional value wins over an explicit key for the same
+// index.
+func NewTable(array []any, pairs ...any) *Table {
+	t := &Table{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		t.Set(pairs[i], pairs[i+1])
+	}
+	for i, v := range array {
+		t.Set(int64(i+1), v)
+	}
+	return t
+}
+
+func (t *Table) Get(key any) any {
+	key = dslKey(key)
+	if i, ok := key.(int64); ok && i >= 1 && i <= int64(len(t.Array)) {
+		return t.Array[i-1]
+	}
+	return t.Hash[key]
+}
+
+func (t *Table) Set(key, value any) {
+	key = dslKey(key)
+	if i, ok := key.(int64); ok && i >= 1 {
+		if i <= int64(len(t.Array)) {
+			t.Array[i-1] = value
+			return
+		}
+		if i == int64(len(t.Array))+1 && value != nil {
+			t.Array = append(t.Array, value)
+			return
+		}
+	}
+	if value == nil {
+		delete(t.Hash, key)
+		return
+	}
+	if t.Hash == nil {
+		t.Hash = make(map[any]any)
+	}
+	t.Hash[key] = value
+}
+
+// Pair is one entry visited by a range loop.
+type Pair struct {
+	Key, Value any
+}
+
+// Pairs returns the positional entries of t followed by the other entries
+// ordered by their printed key.
+func (t *Table) Pairs() []Pair {
+	pairs := make([]Pair, 0, len(t.Array)+len(t.Hash))
+	for i, v := range t.Array {
+		pairs = append(pairs, Pair{Key: int64(i + 1), Value: v})
+	}
+	start := len(pairs)
+	for k, v := range t.Hash {
+		pairs = append(pairs, Pair{Key: k, Value: v})
+	}
+	rest := pairs[start:]
+	sort.Slice(rest, func(i, j int) bool {
+		return fmt.Sprint(rest[i].Key) < fmt.Sprint(rest[j].Key)
+	})
+	return pairs
+}
+
+func dslKey(key any) any {
+	if f, ok := key.(float64); ok && f == math.Trunc(f) {
+		return int64(f)
+	}
+	return key
+}
+
+func dslTruthy(v any) bool {
+	return v != nil && v != false
+}
+
+func dslArg(args []any, i int) any {
+	if i < len(args) {
+		return args[i]
+	}
+	return nil
+}
+
+func dslFloat(v any) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	panic(fmt.Sprintf("attempt to use %T as a number", v))
+}
+
+func dslInt(v any) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case float64:
+		if n == math.Trunc(n) {
+			return int64(n)
+		}
+	}
+	panic(fmt.Sprintf("attempt to use %v as an integer", v))
+}
+
+func dslString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	panic(fmt.Sprintf("attempt to use %T as a string", v))
+}
+
+// dslToString formats v as Lua's tostring does.
+func dslToString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return "inf"
+		case math.IsInf(v, -1):
+			return "-inf"
+		case math.IsNaN(v):
+			return "nan"
+		}
+		s := strconv.FormatFloat(v, 'g', 14, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s
+	case *Table:
+		return fmt.Sprintf("table: %p", v)
+	case Func:
+		return fmt.Sprintf("function: %p", v)
+	}
+	return fmt.Sprint(v)
+}
+
+// dslConcat joins the parts of an interpolated string.
+func dslConcat(parts ...any) string {
+	var sb strings.Builder
+	for _, part := range parts {
+		sb.WriteString(dslToString(part))
+	}
+	return sb.String()
+}
+
+func dslTable(v any) *Table {
+	if t, ok := v.(*Table); ok || v == nil {
+		return t
+	}
+	panic(fmt.Sprintf("attempt to use %T as a table", v))
+}
+
+func dslArith(op byte, a, b any) any {
+	x, xInt := a.(int64)
+	y, yInt := b.(int64)
+	if xInt && yInt && op != '/' {
+		switch op {
+		case '+':
+			return x + y
+		case '-':
+			return x - y
+		case '*':
+			return x * y
+		}
+	}
+	f, g := dslFloat(a), dslFloat(b)
+	switch op {
+	case '+':
+		return f + g
+	case '-':
+		return f - g
+	case '*':
+		return f * g
+	case '/':
+		return f / g
+	}
+	panic(fmt.Sprintf("unknown operator %c", op))
+}
+
+func dslAdd(a, b any) any { return dslArith('+', a, b) }
+func dslSub(a, b any) any { return dslArith('-', a, b) }
+func dslMul(a, b any) any { return dslArith('*', a, b) }
+func dslDiv(a, b any) any { return dslArith('/', a, b) }
+
+// dslMod implements %. As in Lua the result takes the sign of the divisor.
+func dslMod(a, b any) any {
+	x, xInt := a.(int64)
+	y, yInt := b.(int64)
+	if xInt && yInt {
+		if y == 0 {
+			panic("attempt to perform 'n%0'")
+		}
+		m := x % y
+		if m != 0 && (m < 0) != (y < 0) {
+			m += y
+		}
+		return m
+	}
+	f, g := dslFloat(a), dslFloat(b)
+	m := math.Mod(f, g)
+	if m != 0 && (m < 0) != (g < 0) {
+		m += g
+	}
+	return m
+}
+
+// dslIntDiv implements //, division rounded towards minus infinity.
+func dslIntDiv(a, b any) any {
+	x, xInt := a.(int64)
+	y, yInt := b.(int64)
+	if xInt && yInt {
+		if y == 0 {
+			panic("attempt to perform 'n//0'")
+		}
+		q := x / y
+		if x%y != 0 && (x < 0) != (y < 0) {
+			q--
+		}
+		return q
+	}
+	return math.Floor(dslFloat(a) / dslFloat(b))
+}
+
+// dslPow implements ^, which always yields a float.
+func dslPow(a, b any) any {
+	return math.Pow(dslFloat(a), dslFloat(b))
+}
+
+// dslBit implements the bitwise operators on the integer values of a and
+// b. As in Lua, shifts are logical and shifting by 64 bits or more yields
+// 0; a negative shift goes the other way.
+func dslBit(op byte, a, b any) any {
+	x, y := dslInt(a), dslInt(b)
+	switch op {
+	case '&':
+		return x & y
+	case '|':
+		return x | y
+	case '~':
+		return x ^ y
+	case '>':
+		y = -y
+	}
+	switch {
+	case y <= -64 || y >= 64:
+		return int64(0)
+	case y >= 0:
+		return int64(uint64(x) << y)
+	default:
+		return int64(uint64(x) >> -y)
+	}
+}
+
+func dslBand(a, b any) any { return dslBit('&', a, b) }
+func dslBor(a, b any) any  { return dslBit('|', a, b) }
+func dslBxor(a, b any) any { return dslBit('~', a, b) }
+func dslShl(a, b any) any  { return dslBit('<', a, b) }
+func dslShr(a, b any) any  { return dslBit('>', a, b) }
+
+func dslBnot(a any) any {
+	return ^dslInt(a)
+}
+
+func dslNeg(a any) any {
+	if n, ok := a.(int64); ok {
+		return -n
+	}
+	return -dslFloat(a)
+}
+
+func dslEq(a, b any) bool {
+	switch a.(type) {
+	case int64, float64:
+		switch b.(type) {
+		case int64, float64:
+			return dslFloat(a) == dslFloat(b)
+		}
+	}
+	return a == b
+}
+
+func dslLess(a, b any, orEqual bool) bool {
+	if s, ok := a.(string); ok {
+		t := dslString(b)
+		return s < t || orEqual && s == t
+	}
+	x, y := dslFloat(a), dslFloat(b)
+	return x < y || orEqual && x == y
+}
+
+func dslGreater(a, b any, orEqual bool) bool {
+	return dslLess(b, a, orEqual)
+}
+
+func dslAnd(a any, b func() any) any {
+	if !dslTruthy(a) {
+		return a
+	}
+	return b()
+}
+
+func dslOr(a any, b func() any) any {
+	if dslTruthy(a) {
+		return a
+	}
+	return b()
+}
+
+func dslIndex(v, key any) any {
+	if t, ok := v.(*Table); ok && t != nil {
+		return t.Get(key)
+	}
+	if o, ok := v.(Object); ok {
+		if name, ok := key.(string); ok {
+			return o.Member(name)
+		}
+	}
+	panic(fmt.Sprintf("attempt to index %T with %v", v, key))
+}
+
+// dslIndexOpt implements ?., which yields nil for a nil value.
+func dslIndexOpt(v, key any) any {
+	if v == nil {
+		return nil
+	}
+	return dslIndex(v, key)
+}
+
+func dslSetIndex(v, key, value any) {
+	if t, ok := v.(*Table); ok && t != nil {
+		t.Set(key, value)
+		return
+	}
+	panic(fmt.Sprintf("attempt to index %T with %v", v, key))
+}
+
+// dslUpdateIndex implements v[key] op= operand, evaluating v and key once.
+func dslUpdateIndex(v, key any, op func(a, b any) any, operand any) {
+	dslSetIndex(v, key, op(dslIndex(v, key), operand))
+}
+
+func dslCall(f any, args ...any) any {
+	switch fn := f.(type) {
+	case Func:
+		return fn(args...)
+	case func(...any) any:
+		return fn(args...)
+	}
+	panic(fmt.Sprintf("attempt to call %T", f))
+}
+
+// dslInvoke calls a method of a value returned by a host call. Members of
+// tables are plain functions and get no receiver.
+func dslInvoke(v any, method string, args ...any) any {
+	if o, ok := v.(Object); ok {
+		return o.Invoke(method, args...)
+	}
+	if t, ok := v.(*Table); ok && t != nil {
+		return dslCall(t.Get(method), args...)
+	}
+	panic(fmt.Sprintf("attempt to call method %s of %T", method, v))
+}
+
+func dslPairs(v any) []Pair {
+	if t, ok := v.(*Table); ok && t != nil {
+		return t.Pairs()
+	}
+	panic(fmt.Sprintf("attempt to range over %T", v))
+}
+`
@@ -0,0 +1,367 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+func (g *goGenerator) pushScope() {
+	g.scopes = append(g.scopes, map[string]string{})
+}
+
+func (g *goGenerator) popScope() {
+	g.scopes = g.scopes[:len(g.scopes)-1]
+}
+
+// declare declares a local of the manifest type typ, or a dynamic one if
+// typ is empty.
+func (g *goGenerator) declare(name, typ string) {
+	g.scopes[len(g.scopes)-1][name] = typ
+}
+
+// declared reports whether name is declared in the innermost block.
+func (g *goGenerator) declared(name string) bool {
+	_, ok := g.scopes[len(g.scopes)-1][name]
+	return ok
+}
+
+func (g *goGenerator) isLocal(name string) bool {
+	_, ok := g.lookup(name)
+	return ok
+}
+
+// typeOf returns the manifest type of the local name, or "" if its values
+// are dynamic.
+func (g *goGenerator) typeOf(name string) string {
+	typ, _ := g.lookup(name)
+	return typ
+}
+
+func (g *goGenerator) lookup(name string) (string, bool) {
+	for i := len(g.scopes) - 1; i >= 0; i-- {
+		if typ, ok := g.scopes[i][name]; ok {
+			return typ, true
+		}
+	}
+	return "", false
+}
+
+// generateBody writes the statements of a function whose result has the
+// manifest type g.result and makes sure it ends in a return.
+func (g *goGenerator) generateBody(body *ast.CodeBlock) {
+	g.indent++
+	returns := false
+	if body != nil {
+		for _, stmt := range body.Statements {
+			g.generateStatement(stmt)
+		}
+		if n := len(body.Statements); n > 0 {
+			returns = generator.Terminates(body.Statements[n-1])
+		}
+	}
+	if !returns {
+		g.line("return %s", goZero(g.result))
+	}
+	g.indent--
+}
+
+func (g *goGenerator) generateBlock(body *ast.CodeBlock) {
+	g.indent++
+	g.pushScope()
+	if body != nil {
+		for _, stmt := range body.Statements {
+			g.generateStatement(stmt)
+		}
+	}
+	g.popScope()
+	g.indent--
+}
+
+// budget declares the pass counter of loop if it counts its passes against
+// the loop budget, and returns its name; otherwise it returns "".
+func (g *goGenerator) budget(loop ast.Statement) string {
+	if !g.unit.Budgeted(loop) {
+		return ""
+	}
+	g.passes++
+	counter := fmt.Sprintf("dslPasses%d", g.passes)
+	g.line("%s := 0", counter)
+	return counter
+}
+
+// generateLoopBlock writes the body of loop, which first counts the pass
+// if counter is not empty.
+func (g *goGenerator) generateLoopBlock(loop ast.Statement, counter string, body *ast.CodeBlock) {
+	if counter != "" {
+		g.indent++
+		g.line("%s++", counter)
+		g.line("if %s > %d {", counter, g.unit.LoopBudget)
+		g.indent++
+		g.line("panic(%s)", quote(g.unit.BudgetError(loop)))
+		g.indent--
+		g.line("}")
+		g.indent--
+	}
+	g.generateBlock(body)
+}
+
+func (g *goGenerator) generateStatement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VarStatement:
+		g.generateVarStatement(s)
+	case *ast.ExprStmt, *ast.AssignStatement:
+		if simple, ok := g.simpleStatement(s); ok {
+			g.line("%s", simple)
+		}
+	case *ast.IfStatement:
+		g.generateIfStatement(s)
+	case *ast.MatchStatement:
+		g.generateMatchStatement(s)
+	case *ast.ForStatement:
+		g.generateForStatement(s)
+	case *ast.WhileStatement:
+		counter := g.budget(s)
+		g.line("for %s {", g.condition(s.Condition))
+		g.generateLoopBlock(s, counter, s.Body)
+		g.line("}")
+	case *ast.RepeatStatement:
+		g.generateRepeatStatement(s)
+	case *ast.ReturnStatement:
+		g.generateReturnStatement(s)
+	case *ast.BreakStatement:
+		g.line("break")
+	case *ast.ContinueStatement:
+		g.line("continue")
+	case *ast.FunctionDef: // unnamed; named ones are Go functions
+		g.line("_ = %s", g.expression(s))
+	default:
+		g.errorf(stmt, "go: unsupported statement %T", stmt)
+	}
+}
+
+func (g *goGenerator) generateVarStatement(stmt *ast.VarStatement) {
+	name := goLocal(stmt.Name.Value)
+	global := g.inInit && len(g.scopes) == 1 && g.globals[stmt.Name.Value]
+	if stmt.Recursive() && !global && !g.declared(stmt.Name.Value) {
+		g.declare(stmt.Name.Value, "") // the function refers to itself
+		g.line("var %s any", name)
+	}
+	value := g.expression(stmt.Value)
+
+	if global {
+		g.line("%s = %s", name, value)
+		return
+	}
+	if g.declared(stmt.Name.Value) {
+		g.line("%s = %s", name, goConvert(g.typeOf(stmt.Name.Value), value))
+		return
+	}
+
+	g.declare(stmt.Name.Value, "")
+	g.line("var %s any = %s", name, value)
+	g.line("_ = %s", name)
+}
+
+// updateFuncs are the runtime helpers applying the arithmetic of x op= v.
+var updateFuncs = map[string]string{
+	"+": "dslAdd",
+	"-": "dslSub",
+	"*": "dslMul",
+	"/": "dslDiv",
+}
+
+// simpleStatement renders an expression statement or an assignment as one
+// Go simple statement, so it also fits the post statement of a for loop.
+func (g *goGenerator) simpleStatement(stmt ast.Statement) (string, bool) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		if call, ok := s.Expression.(*ast.FunctionCall); ok {
+			return g.expression(call), true
+		}
+		return "_ = " + g.expression(s.Expression), true
+	case *ast.AssignStatement:
+		return g.assignStatement(s)
+	}
+	g.errorf(stmt, "go: unsupported statement %T", stmt)
+	return "", false
+}
+
+// assignStatement renders an assignment. Updating a member or an element
+// goes through dslUpdateIndex, which evaluates the object and key once.
+func (g *goGenerator) assignStatement(stmt *ast.AssignStatement) (string, bool) {
+	op, operand := stmt.Update()
+	if op == "" {
+		return g.assignment(stmt.Target, g.expression(stmt.Value))
+	}
+
+	switch t := stmt.Target.(type) {
+	case *ast.DotExpression:
+		if right, ok := t.Right.(*ast.Identifier); ok {
+			return "dslUpdateIndex(" + g.expression(t.Left) + ", " + quote(right.Value) + ", " + updateFuncs[op] + ", " + g.expression(operand) + ")", true
+		}
+	case *ast.IndexExpression:
+		return "dslUpdateIndex(" + g.expression(t.Left) + ", " + g.expression(t.Index) + ", " + updateFuncs[op] + ", " + g.expression(operand) + ")", true
+	}
+	return g.assignment(stmt.Target, g.infix(&ast.InfixExpression{BaseNode: stmt.BaseNode, Left: stmt.Target, Operator: op, Right: operand}))
+}
+
+func (g *goGenerator) assignment(target ast.Expression, value string) (string, bool) {
+	switch t := target.(type) {
+	case *ast.Identifier:
+		if !g.isLocal(t.Value) && !g.globals[t.Value] {
+			g.errorf(t, "go: cannot assign to undeclared name %s", t.Value)
+			return "", false
+		}
+		return goLocal(t.Value) + " = " + goConvert(g.typeOf(t.Value), value), true
+	case *ast.DotExpression:
+		if right, ok := t.Right.(*ast.Identifier); ok && !t.Optional {
+			return "dslSetIndex(" + g.expression(t.Left) + ", " + quote(right.Value) + ", " + value + ")", true
+		}
+	case *ast.IndexExpression:
+		return "dslSetIndex(" + g.expression(t.Left) + ", " + g.expression(t.Index) + ", " + value + ")", true
+	}
+	g.errorf(target, "go: cannot assign to %s", target)
+	return "", false
+}
+
+func (g *goGenerator) generateIfStatement(stmt *ast.IfStatement) {
+	g.line("if %s {", g.condition(stmt.Condition))
+	g.generateBlock(stmt.Consequence)
+	for _, alt := range stmt.Alternatives {
+		if alt.Condition != nil {
+			g.line("} else if %s {", g.condition(alt.Condition))
+		} else {
+			g.line("} else {")
+		}
+		g.generateBlock(alt.Consequence)
+	}
+	g.line("}")
+}
+
+// generateMatchStatement writes a match as a switch over the subject. Since
+// break in a switch leaves the switch, a match with an arm breaking out of
+// the loop around it is written as an if chain instead.
+func (g *goGenerator) generateMatchStatement(stmt *ast.MatchStatement) {
+	if generator.MatchBreaks(stmt) {
+		for i, arm := range stmt.Arms {
+			switch {
+			case arm.Patterns == nil && i == 0:
+				g.line("if _ = %s; true {", g.expression(stmt.Subject))
+			case arm.Patterns == nil:
+				g.line("} else {")
+			default:
+				conds := make([]string, len(arm.Patterns))
+				for j, pattern := range arm.Patterns {
+					conds[j] = fmt.Sprintf("dslEq(dslMatch, %s)", g.expression(pattern))
+				}
+				if i == 0 {
+					g.line("if dslMatch := %s; %s {", g.expression(stmt.Subject), strings.Join(conds, " || "))
+				} else {
+					g.line("} else if %s {", strings.Join(conds, " || "))
+				}
+			}
+			g.generateBlock(arm.Body)
+		}
+		g.line("}")
+		return
+	}
+
+	g.line("switch dslKey(%s) {", g.expression(stmt.Subject))
+	for _, arm := range stmt.Arms {
+		if arm.Patterns == nil {
+			g.line("default:")
+		} else {
+			cases := make([]string, len(arm.Patterns))
+			for i, pattern := range arm.Patterns {
+				cases[i] = g.expression(generator.CaseLabel(pattern))
+			}
+			g.line("case %s:", strings.Join(cases, ", "))
+		}
+		g.generateBlock(arm.Body)
+	}
+	g.line("}")
+}
+
+// generateRepeatStatement writes repeat { } until cond as a for loop whose
+// post statement evaluates the condition, which a continue also reaches.
+func (g *goGenerator) generateRepeatStatement(stmt *ast.RepeatStatement) {
+	counter := g.budget(stmt)
+	g.line("for dslMore := true; dslMore; dslMore = %s {", generator.Not(g.condition(stmt.Condition)))
+	g.generateLoopBlock(stmt, counter, stmt.Body)
+	g.line("}")
+}
+
+func (g *goGenerator) generateForStatement(stmt *ast.ForStatement) {
+	if stmt.IsRangeForm {
+		g.line("for _, dslPair := range dslPairs(%s) {", g.expression(stmt.RangeValue))
+		g.indent++
+		g.pushScope()
+		value := goLocal(stmt.Value.Value)
+		g.declare(stmt.Value.Value, "")
+		if stmt.Key != nil {
+			key := goLocal(stmt.Key.Value)
+			g.declare(stmt.Key.Value, "")
+			g.line("%s, %s := dslPair.Key, dslPair.Value", key, value)
+			g.line("_, _ = %s, %s", key, value)
+		} else {
+			g.line("%s := dslPair.Value", value)
+			g.line("_ = %s", value)
+		}
+		g.indent--
+		g.generateBlock(stmt.Body)
+		g.popScope()
+		g.line("}")
+		return
+	}
+
+	if stmt.Init == nil && stmt.Post == nil {
+		counter := g.budget(stmt)
+		if stmt.Condition == nil {
+			g.line("for {")
+		} else {
+			g.line("for %s {", g.condition(stmt.Condition))
+		}
+		g.generateLoopBlock(stmt, counter, stmt.Body)
+		g.line("}")
+		return
+	}
+
+	g.line("{")
+	g.indent++
+	g.pushScope()
+	if stmt.Init != nil {
+		g.generateStatement(stmt.Init)
+	}
+	counter := g.budget(stmt)
+	cond, post := "", ""
+	if stmt.Condition != nil {
+		cond = g.condition(stmt.Condition)
+	}
+	if stmt.Post != nil {
+		post, _ = g.simpleStatement(stmt.Post)
+	}
+	g.line("for ; %s; %s {", cond, post)
+	g.generateLoopBlock(stmt, counter, stmt.Body)
+	g.line("}")
+	g.popScope()
+	g.indent--
+	g.line("}")
+}
+
+func (g *goGenerator) generateReturnStatement(stmt *ast.ReturnStatement) {
+	if g.inInit {
+		if stmt.ReturnValue != nil {
+			g.errorf(stmt, "go: top-level return cannot have a value")
+		}
+		g.line("return")
+		return
+	}
+	if stmt.ReturnValue == nil {
+		g.line("return %s", goZero(g.result))
+		return
+	}
+	g.line("return %s", g.convert(g.result, stmt.ReturnValue))
+}
@@ -0,0 +1,189 @@
+package json
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/constant"
+)
+
+// eval turns a property value into one of nil, bool, int64, float64,
+// string, []any or *object. path names the value in function references.
+func (j *jsonGenerator) eval(exp ast.Expression, path string) any {
+	switch v := exp.(type) {
+	case *ast.Integer:
+		return v.Value
+	case *ast.Float:
+		return v.Value
+	case *ast.String:
+		return v.Value
+	case *ast.InterpolatedString:
+		return j.evalInterpolated(v, path)
+	case *ast.Boolean:
+		return v.Value
+	case *ast.TableDef:
+		return j.evalTable(v, path)
+	case *ast.FunctionDef:
+		return funcRef(path)
+	case *ast.Identifier, *ast.DotExpression:
+		if name, ok := ast.QualifiedName(v); ok {
+			ref := &object{}
+			ref.set("$ref", name)
+			return ref
+		}
+	case *ast.PrefixExpression:
+		return j.evalPrefix(v, path)
+	case *ast.InfixExpression:
+		return j.evalInfix(v, path)
+	case *ast.ConditionalExpression:
+		return j.evalConditional(v, path)
+	}
+
+	j.errorf(exp, "json: cannot evaluate %s statically", ast.Format(exp))
+	return nil
+}
+
+func (j *jsonGenerator) evalTable(table *ast.TableDef, path string) any {
+	positional := true
+	for _, prop := range table.Properties {
+		if prop.Key != nil {
+			positional = false
+			break
+		}
+	}
+
+	if positional {
+		list := make([]any, 0, len(table.Properties))
+		for i, prop := range table.Properties {
+			list = append(list, j.eval(prop.Value, path+"."+strconv.Itoa(i+1)))
+		}
+		return list
+	}
+
+	obj := &object{}
+	index := 0
+	for _, prop := range table.Properties {
+		var key string
+		switch k := prop.Key.(type) {
+		case nil:
+			index++
+			key = strconv.Itoa(index)
+		case *ast.Identifier:
+			key = k.Value
+		case *ast.String:
+			key = k.Value
+		case *ast.Integer:
+			key = strconv.FormatInt(k.Value, 10)
+		default:
+			j.errorf(prop.Key, "json: table key %s is not a literal", ast.Format(prop.Key))
+			continue
+		}
+
+		if j.omitFuncs {
+			if _, isFunc := prop.Value.(*ast.FunctionDef); isFunc {
+				continue
+			}
+		}
+		obj.set(key, j.eval(prop.Value, path+"."+key))
+	}
+	return obj
+}
+
+func (j *jsonGenerator) evalPrefix(exp *ast.PrefixExpression, path string) any {
+	errs := len(j.diags)
+	right := j.eval(exp.Right, path)
+	if len(j.diags) > errs {
+		return nil // operand already reported
+	}
+	if v, ok := constant.Unary(exp.Operator, right); ok && isScalar(right) {
+		return v
+	}
+
+	j.errorf(exp, "json: cannot evaluate %s statically", ast.Format(exp))
+	return nil
+}
+
+// evalConditional folds cond ? a : b when cond is a constant; as in Lua,
+// only nil and false count as false. A reference is not a constant.
+func (j *jsonGenerator) evalConditional(exp *ast.ConditionalExpression, path string) any {
+	if _, isRef := ast.QualifiedName(exp.Condition); isRef {
+		j.errorf(exp, "json: cannot evaluate %s statically", ast.Format(exp))
+		return nil
+	}
+	errs := len(j.diags)
+	cond := j.eval(exp.Condition, path)
+	if len(j.diags) > errs {
+		return nil
+	}
+	if cond != nil && cond != false {
+		return j.eval(exp.Consequence, path)
+	}
+	return j.eval(exp.Alternative, path)
+}
+
+// evalInterpolated folds a string whose parts are all constants, formatting
+// them as Lua's tostring does.
+func (j *jsonGenerator) evalInterpolated(exp *ast.InterpolatedString, path string) any {
+	var sb strings.Builder
+	for _, part := range exp.Parts {
+		errs := len(j.diags)
+		value := j.eval(part, path)
+		if len(j.diags) > errs {
+			return nil
+		}
+		switch v := value.(type) {
+		case string:
+			sb.WriteString(v)
+		case bool:
+			sb.WriteString(strconv.FormatBool(v))
+		case int64:
+			sb.WriteString(strconv.FormatInt(v, 10))
+		case float64:
+			s := strconv.FormatFloat(v, 'g', 14, 64)
+			switch {
+			case math.IsInf(v, 0) || math.IsNaN(v):
+				s = strings.ToLower(strings.TrimPrefix(s, "+"))
+			case !strings.ContainsAny(s, ".e"):
+				s += ".0"
+			}
+			sb.WriteString(s)
+		default:
+			j.errorf(exp, "json: cannot evaluate %s statically", ast.Format(exp))
+			return nil
+		}
+	}
+	return sb.String()
+}
+
+func (j *jsonGenerator) evalInfix(exp *ast.InfixExpression, path string) any {
+	errs := len(j.diags)
+	left := j.eval(exp.Left, path)
+	right := j.eval(exp.Right, path)
+	if len(j.diags) > errs {
+		return nil // operand already reported
+	}
+	if v, ok := constant.Binary(exp.Operator, left, right); ok && isScalar(left) && isScalar(right) {
+		return v
+	}
+
+	j.errorf(exp, "json: cannot evaluate %s statically", ast.Format(exp))
+	return nil
+}
+
+// isScalar reports whether v is a constant rather than a table or a
+// reference, which only exist at runtime.
+func isScalar(v any) bool {
+	switch v.(type) {
+	case bool, int64, float64, string:
+		return true
+	}
+	return false
+}
+
+func funcRef(path string) *object {
+	ref := &object{}
+	ref.set("$func", path)
+	return ref
+}
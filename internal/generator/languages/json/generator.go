@@ -0,0 +1,146 @@
+package json
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+const Language = "json"
+
+// Options understood by the json generator.
+const (
+	OptFormat    = "format"    // "json" (default), "msgpack" or "both"
+	OptFunctions = "functions" // "ref" (default) replaces hooks by {"$func": "skill.hook"}, "omit" drops them
+	OptCompact   = "compact"   // write JSON without indentation
+)
+
+func init() {
+	generator.Register(Language, NewJSONGenerator)
+}
+
+// jsonGenerator exports the static data of every skill and state, and the
+// members of every enum. Literal properties and tables are evaluated;
+// references to names that only exist at runtime, such as EM.Test without a
+// manifest declaring EM, are written as {"$ref": "EM.Test"}.
+type jsonGenerator struct {
+	unit  *generator.Unit
+	diags []diag.Diagnostic
+
+	writeJSON    bool
+	writeMsgpack bool
+	omitFuncs    bool
+	compact      bool
+}
+
+func NewJSONGenerator(opts generator.Options) (generator.CodeGenerator, error) {
+	if err := opts.Check(Language, OptFormat, OptFunctions, OptCompact); err != nil {
+		return nil, err
+	}
+
+	j := &jsonGenerator{}
+
+	switch format := opts.String(OptFormat, "json"); format {
+	case "json":
+		j.writeJSON = true
+	case "msgpack":
+		j.writeMsgpack = true
+	case "both":
+		j.writeJSON, j.writeMsgpack = true, true
+	default:
+		return nil, fmt.Errorf("option %q: unknown format %q", OptFormat, format)
+	}
+
+	switch functions := opts.String(OptFunctions, "ref"); functions {
+	case "ref":
+	case "omit":
+		j.omitFuncs = true
+	default:
+		return nil, fmt.Errorf("option %q: expected ref or omit, got %q", OptFunctions, functions)
+	}
+
+	var err error
+	if j.compact, err = opts.Bool(OptCompact); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+func (j *jsonGenerator) Generate(unit *generator.Unit) ([]generator.Artifact, []diag.Diagnostic) {
+	j.unit = unit
+	j.diags = nil
+
+	skills := &object{}
+	states := &object{}
+	enums := &object{}
+
+	for _, stmt := range unit.Program.Statements {
+		switch def := stmt.(type) {
+		case *ast.SkillDef:
+			skills.set(def.Name.Value, j.evalDef(def.Name.Value, def.MergedProperties()))
+		case *ast.StateDef:
+			states.set(def.Name.Value, j.evalDef(def.Name.Value, def.MergedProperties()))
+		case *ast.EnumDef:
+			members := &object{}
+			for _, member := range def.Members {
+				members.set(member.Name.Value, j.eval(member.Value, def.Name.Value+"."+member.Name.Value))
+			}
+			enums.set(def.Name.Value, members)
+		}
+	}
+
+	root := &object{}
+	root.set("skills", skills)
+	root.set("states", states)
+	if len(enums.keys) > 0 {
+		root.set("enums", enums)
+	}
+
+	var artifacts []generator.Artifact
+	if j.writeJSON {
+		var sb strings.Builder
+		indent := "  "
+		if j.compact {
+			indent = ""
+		}
+		writeJSON(&sb, root, indent, "")
+		sb.WriteString("\n")
+		artifacts = append(artifacts, generator.Artifact{
+			Name:    unit.Name + ".json",
+			Kind:    generator.Code,
+			Content: []byte(sb.String()),
+		})
+	}
+	if j.writeMsgpack {
+		artifacts = append(artifacts, generator.Artifact{
+			Name:    unit.Name + ".msgpack",
+			Kind:    generator.Code,
+			Content: appendMsgpack(nil, root),
+		})
+	}
+
+	return artifacts, j.diags
+}
+
+func (j *jsonGenerator) errorf(node ast.Node, format string, args ...any) {
+	j.diags = append(j.diags, diag.Errorf(j.unit.File, node.Pos(), format, args...))
+}
+
+func (j *jsonGenerator) evalDef(name string, properties []*ast.PropertyDef) *object {
+	obj := &object{}
+	for _, prop := range properties {
+		key, ok := prop.Key.(*ast.Identifier)
+		if !ok {
+			continue
+		}
+		if _, isFunc := prop.Value.(*ast.FunctionDef); isFunc && j.omitFuncs {
+			continue
+		}
+		obj.set(key.Value, j.eval(prop.Value, name+"."+key.Value))
+	}
+	return obj
+}
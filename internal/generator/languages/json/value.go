@@ -0,0 +1,185 @@
+package json
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// object is a JSON object that keeps the order its keys were set in, so
+// exported properties appear in source order.
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *object) set(key string, value any) {
+	if o.values == nil {
+		o.values = make(map[string]any)
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func writeJSON(sb *strings.Builder, v any, indent, prefix string) {
+	newline := func(prefix string) {
+		if indent != "" {
+			sb.WriteString("\n")
+			sb.WriteString(prefix)
+		}
+	}
+
+	switch val := v.(type) {
+	case nil:
+		sb.WriteString("null")
+	case bool:
+		sb.WriteString(strconv.FormatBool(val))
+	case int64:
+		sb.WriteString(strconv.FormatInt(val, 10))
+	case float64:
+		sb.WriteString(strconv.FormatFloat(val, 'g', -1, 64))
+	case string:
+		writeJSONString(sb, val)
+	case []any:
+		if len(val) == 0 {
+			sb.WriteString("[]")
+			return
+		}
+		sb.WriteString("[")
+		for i, item := range val {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			newline(prefix + indent)
+			writeJSON(sb, item, indent, prefix+indent)
+		}
+		newline(prefix)
+		sb.WriteString("]")
+	case *object:
+		if len(val.keys) == 0 {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString("{")
+		for i, key := range val.keys {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			newline(prefix + indent)
+			writeJSONString(sb, key)
+			sb.WriteString(":")
+			if indent != "" {
+				sb.WriteString(" ")
+			}
+			writeJSON(sb, val.values[key], indent, prefix+indent)
+		}
+		newline(prefix)
+		sb.WriteString("}")
+	default:
+		panic(fmt.Sprintf("json: unexpected value %T", v))
+	}
+}
+
+func writeJSONString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+}
+
+// appendMsgpack encodes v in the MessagePack format using the smallest
+// representation for every integer, string, array and map.
+func appendMsgpack(b []byte, v any) []byte {
+	switch val := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if val {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case int64:
+		return appendMsgpackInt(b, val)
+	case float64:
+		b = append(b, 0xcb)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(val))
+	case string:
+		n := len(val)
+		switch {
+		case n < 32:
+			b = append(b, 0xa0|byte(n))
+		case n <= math.MaxUint8:
+			b = append(b, 0xd9, byte(n))
+		case n <= math.MaxUint16:
+			b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+		default:
+			b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+		}
+		return append(b, val...)
+	case []any:
+		b = appendMsgpackLen(b, len(val), 0x90, 0xdc, 0xdd)
+		for _, item := range val {
+			b = appendMsgpack(b, item)
+		}
+		return b
+	case *object:
+		b = appendMsgpackLen(b, len(val.keys), 0x80, 0xde, 0xdf)
+		for _, key := range val.keys {
+			b = appendMsgpack(b, key)
+			b = appendMsgpack(b, val.values[key])
+		}
+		return b
+	default:
+		panic(fmt.Sprintf("msgpack: unexpected value %T", v))
+	}
+}
+
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= 0x7f:
+		return append(b, byte(n))
+	case n >= -32 && n < 0:
+		return append(b, byte(n))
+	case n >= math.MinInt8 && n <= math.MaxInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16 && n <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+	}
+}
+
+func appendMsgpackLen(b []byte, n int, fix, len16, len32 byte) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, len16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, len32), uint32(n))
+	}
+}
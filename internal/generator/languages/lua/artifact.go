@@ -0,0 +1,160 @@
+package lua
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+	"github.com/hsoul/skconf/internal/units"
+)
+
+// lineMap records which source line produced each generated statement.
+type lineMap struct {
+	counted  int      // bytes of the buffer already scanned for newlines
+	line     int      // current generated line, 1-based
+	mappings [][2]int // {generated line, source line}
+}
+
+func (l *luaGenerator) mark(node ast.Node) {
+	if !l.sourceMap {
+		return
+	}
+
+	out := l.buf.String()
+	l.lines.line += strings.Count(out[l.lines.counted:], "\n")
+	l.lines.counted = len(out)
+
+	srcLine := node.Pos().Line
+	if srcLine <= 0 {
+		return
+	}
+	if n := len(l.lines.mappings); n > 0 && l.lines.mappings[n-1][0] == l.lines.line {
+		return // first statement on a line wins
+	}
+	l.lines.mappings = append(l.lines.mappings, [2]int{l.lines.line, srcLine})
+}
+
+func (l *luaGenerator) generateSourceMap(file string, lines *lineMap) generator.Artifact {
+	content, _ := json.MarshalIndent(struct {
+		Version  int      `json:"version"`
+		File     string   `json:"file"`
+		Source   string   `json:"source"`
+		Mappings [][2]int `json:"mappings"`
+	}{
+		Version:  1,
+		File:     file,
+		Source:   l.unit.File,
+		Mappings: lines.mappings,
+	}, "", "  ")
+
+	return generator.Artifact{
+		Name:    file + ".map",
+		Kind:    generator.SourceMap,
+		Content: content,
+	}
+}
+
+func (l *luaGenerator) generateIndex() generator.Artifact {
+	content, _ := json.MarshalIndent(struct {
+		Module string            `json:"module"`
+		Skills map[string]string `json:"skills"`
+		States map[string]string `json:"states"`
+	}{
+		Module: strings.ReplaceAll(l.unit.Name, "/", "."),
+		Skills: l.skillMap,
+		States: l.stateMap,
+	}, "", "  ")
+
+	return generator.Artifact{
+		Name:    l.unit.Name + ".index.json",
+		Kind:    generator.ExportIndex,
+		Content: content,
+	}
+}
+
+// generateStubs writes LuaLS annotations describing the shape of every
+// skill and state, so editors can complete and check code using them.
+func (l *luaGenerator) generateStubs(program *ast.Program) generator.Artifact {
+	var sb strings.Builder
+	sb.WriteString("---@meta\n")
+
+	for _, stmt := range program.Statements {
+		var name, doc string
+		var properties []*ast.PropertyDef
+		switch def := stmt.(type) {
+		case *ast.SkillDef:
+			name, doc, properties = def.Name.Value, def.Doc, def.MergedProperties()
+		case *ast.StateDef:
+			name, doc, properties = def.Name.Value, def.Doc, def.MergedProperties()
+		default:
+			continue
+		}
+
+		sb.WriteString("\n")
+		if doc != "" {
+			for _, line := range strings.Split(doc, "\n") {
+				sb.WriteString(strings.TrimRight("--- "+line, " ") + "\n")
+			}
+		}
+		sb.WriteString(fmt.Sprintf("---@class %s\n", luaName(name)))
+		for _, prop := range properties {
+			if key, ok := prop.Key.(*ast.Identifier); ok {
+				field := fmt.Sprintf("---@field %s %s", fieldName(key.Value), stubType(prop.Value))
+				if prop.Doc != "" {
+					field += " " + strings.Join(strings.Fields(prop.Doc), " ")
+				}
+				sb.WriteString(field + "\n")
+			}
+		}
+	}
+
+	return generator.Artifact{
+		Name:    l.unit.Name + ".meta.lua",
+		Kind:    generator.TypeStubs,
+		Content: []byte(sb.String()),
+	}
+}
+
+func stubType(exp ast.Expression) string {
+	switch v := exp.(type) {
+	case *ast.Integer:
+		return "integer"
+	case *ast.Float:
+		return "number"
+	case *ast.String, *ast.InterpolatedString:
+		return "string"
+	case *ast.Boolean:
+		return "boolean"
+	case *ast.TableDef:
+		return "table"
+	case *ast.FunctionDef:
+		params := []string{}
+		if isSkillProcessFunc(v) {
+			params = append(params, "ctx: any")
+		}
+		for i, param := range v.Parameters {
+			params = append(params, param.Value+": "+stubTypeName(v.ParamType(i)))
+		}
+		return "fun(" + strings.Join(params, ", ") + "): " + stubTypeName(v.ReturnType())
+	default:
+		return "any"
+	}
+}
+
+// stubTypeName maps a manifest type to a LuaLS type. Quantities are
+// numbers; host types are opaque.
+func stubTypeName(typ string) string {
+	switch {
+	case typ == "int":
+		return "integer"
+	case typ == "float" || units.IsDimension(typ):
+		return "number"
+	case typ == "bool":
+		return "boolean"
+	case typ == "string" || typ == "table":
+		return typ
+	}
+	return "any"
+}
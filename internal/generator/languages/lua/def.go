@@ -1,28 +1,33 @@
 package lua
 
 import (
+	"strings"
+
 	"github.com/hsoul/skconf/internal/ast"
 )
 
 func (l *luaGenerator) generateSkillDef(skill *ast.SkillDef) {
+	l.generateDoc(skill.Doc)
 	l.buf.WriteString(l.indent_str())
 	l.buf.WriteString("local ")
 
 	l.generateExpression(skill.Name)
 	l.buf.WriteString(" = {\n")
 
+	properties := skill.MergedProperties()
 	l.indent++
-	for i, prop := range skill.Properties {
+	for i, prop := range properties {
+		l.generateDoc(prop.Doc)
 		l.buf.WriteString(l.indent_str())
 		if prop.Key != nil {
-			l.generateExpression(prop.Key)
+			l.generatePropertyKey(prop.Key)
 			l.buf.WriteString(" = ")
 			if fn, ok := prop.Value.(*ast.FunctionDef); ok {
 				fn.Name = prop.Key
 			}
 		}
 		l.generateExpression(prop.Value)
-		if i < len(skill.Properties)-1 {
+		if i < len(properties)-1 {
 			l.buf.WriteString(",")
 		}
 		l.buf.WriteString("\n")
@@ -32,30 +37,33 @@ func (l *luaGenerator) generateSkillDef(skill *ast.SkillDef) {
 	l.buf.WriteString(l.indent_str())
 	l.buf.WriteString("}\n")
 
-	tid := ast.FindPropertyByName("tid", skill.Properties)
+	tid := ast.FindPropertyByName("tid", properties)
 	if tid != "" {
-		l.skillMap[tid] = skill.Name.Value
+		l.skillMap[tid] = luaName(skill.Name.Value)
 	}
 }
 
 func (l *luaGenerator) generateStateDef(state *ast.StateDef) {
+	l.generateDoc(state.Doc)
 	l.buf.WriteString(l.indent_str())
 	l.buf.WriteString("local ")
 	l.generateExpression(state.Name)
 	l.buf.WriteString(" = {\n")
 
+	properties := state.MergedProperties()
 	l.indent++
-	for i, prop := range state.Properties {
+	for i, prop := range properties {
+		l.generateDoc(prop.Doc)
 		l.buf.WriteString(l.indent_str())
 		if prop.Key != nil {
-			l.generateExpression(prop.Key)
+			l.generatePropertyKey(prop.Key)
 			l.buf.WriteString(" = ")
 			if fn, ok := prop.Value.(*ast.FunctionDef); ok {
 				fn.Name = prop.Key
 			}
 		}
 		l.generateExpression(prop.Value)
-		if i < len(state.Properties)-1 {
+		if i < len(properties)-1 {
 			l.buf.WriteString(",")
 		}
 		l.buf.WriteString("\n")
@@ -65,8 +73,60 @@ func (l *luaGenerator) generateStateDef(state *ast.StateDef) {
 	l.buf.WriteString(l.indent_str())
 	l.buf.WriteString("}\n")
 
-	tid := ast.FindPropertyByName("tid", state.Properties)
+	tid := ast.FindPropertyByName("tid", properties)
 	if tid != "" {
-		l.stateMap[tid] = state.Name.Value
+		l.stateMap[tid] = luaName(state.Name.Value)
+	}
+}
+
+// generateEnumDef writes an enum as a table from member name to value,
+// annotated for LuaLS. Uses of its members are folded to their values.
+func (l *luaGenerator) generateEnumDef(enum *ast.EnumDef) {
+	name := luaName(enum.Name.Value)
+	l.generateDoc(enum.Doc)
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("---@enum " + name + "\n")
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("local " + name + " = {\n")
+
+	l.indent++
+	for i, member := range enum.Members {
+		l.generateDoc(member.Doc)
+		l.buf.WriteString(l.indent_str())
+		l.generateKey(member.Name.Value)
+		l.buf.WriteString(" = ")
+		l.generateExpression(member.Value)
+		if i < len(enum.Members)-1 {
+			l.buf.WriteString(",")
+		}
+		l.buf.WriteString("\n")
+	}
+	l.indent--
+
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("}\n")
+
+	l.enums = append(l.enums, name)
+}
+
+// generatePropertyKey writes the key of a skill or state property.
+func (l *luaGenerator) generatePropertyKey(key ast.Expression) {
+	if name, ok := key.(*ast.Identifier); ok {
+		l.generateKey(name.Value)
+		return
+	}
+	l.generateExpression(key)
+}
+
+// generateDoc writes the doc comment of a definition as "---" lines, which
+// LuaLS shows on hover.
+func (l *luaGenerator) generateDoc(doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString(strings.TrimRight("--- "+line, " "))
+		l.buf.WriteString("\n")
 	}
 }
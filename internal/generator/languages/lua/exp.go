@@ -2,11 +2,50 @@ package lua
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/hsoul/skconf/internal/ast"
 )
 
+// formatInt spells an integer. Lua reads 9223372036854775808 as a float,
+// so the smallest integer is written as an expression.
+func formatInt(n int64) string {
+	if n == math.MinInt64 {
+		return "(-9223372036854775807 - 1)"
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// formatFloat spells a float so that Lua reads back the same value and
+// keeps it a float: 2.0 stays 2.0 rather than the integer 2.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "math.huge"
+	case math.IsInf(f, -1):
+		return "(-math.huge)"
+	case math.IsNaN(f):
+		return "(0/0)"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+func isNegative(number ast.Expression) bool {
+	switch n := number.(type) {
+	case *ast.Integer:
+		return n.Value < 0
+	case *ast.Float:
+		return math.Signbit(n.Value)
+	}
+	return false
+}
+
 func (l *luaGenerator) generateExpression(exp ast.Expression) {
 	if exp == nil {
 		return
@@ -14,13 +53,15 @@ func (l *luaGenerator) generateExpression(exp ast.Expression) {
 
 	switch n := exp.(type) {
 	case *ast.Identifier:
-		l.buf.WriteString(strings.ReplaceAll(n.Value, "-", "_"))
+		l.buf.WriteString(luaName(n.Value))
 	case *ast.Integer:
-		l.buf.WriteString(fmt.Sprintf("%d", n.Value))
+		l.buf.WriteString(formatInt(n.Value))
 	case *ast.Float:
-		l.buf.WriteString(fmt.Sprintf("%.1f", n.Value))
+		l.buf.WriteString(formatFloat(n.Value))
 	case *ast.String:
-		l.buf.WriteString(fmt.Sprintf("%q", n.Value))
+		l.buf.WriteString(quote(n.Value))
+	case *ast.InterpolatedString:
+		l.generateInterpolatedString(n)
 	case *ast.Boolean:
 		if n.Value {
 			l.buf.WriteString("true")
@@ -35,16 +76,86 @@ func (l *luaGenerator) generateExpression(exp ast.Expression) {
 		l.generateFunctionCall(n)
 	case *ast.DotExpression:
 		l.generateDotExpression(n)
+	case *ast.IndexExpression:
+		l.generateIndexExpression(n)
+	case *ast.ConditionalExpression:
+		l.generateConditionalExpression(n)
 	case *ast.TableDef:
 		l.generateTableDef(n)
 	case *ast.FunctionDef:
 		l.generateFunctionDef(n)
 	default:
-		l.buf.WriteString(fmt.Sprintf("-- Unhandled expression type: %T", exp))
+		l.errorf(exp, "lua: unsupported expression type %T", exp)
+	}
+}
+
+// quote renders s as a Lua string literal. Control characters use decimal
+// escapes, which every Lua version understands; other bytes, UTF-8
+// included, are written as they are.
+func quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if c < ' ' || c == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\%03d`, c))
+			} else {
+				sb.WriteByte(c)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// generateInterpolatedString writes "a${x}b" as string.format("a%sb", x).
+// Before Lua 5.2 %s only takes strings and numbers, so there the values go
+// through tostring first.
+func (l *luaGenerator) generateInterpolatedString(exp *ast.InterpolatedString) {
+	var format strings.Builder
+	var args []ast.Expression
+	for _, part := range exp.Parts {
+		if text, ok := part.(*ast.String); ok {
+			format.WriteString(strings.ReplaceAll(text.Value, "%", "%%"))
+			continue
+		}
+		format.WriteString("%s")
+		args = append(args, part)
 	}
+
+	l.buf.WriteString("string.format(" + quote(format.String()))
+	for _, arg := range args {
+		_, isString := arg.(*ast.InterpolatedString)
+		wrap := (l.dialect == Lua51 || l.dialect == LuaJIT) && !isString
+		l.buf.WriteString(", ")
+		if wrap {
+			l.buf.WriteString("tostring(")
+		}
+		l.generateExpression(arg)
+		if wrap {
+			l.buf.WriteString(")")
+		}
+	}
+	l.buf.WriteString(")")
 }
 
 func (l *luaGenerator) generateInfixExpression(exp *ast.InfixExpression) {
+	if l.lowersToCall(exp.Operator) {
+		l.generateOperatorCall(exp)
+		return
+	}
+
 	// 生成左表达式
 	l.generateSubExpression(exp.Left, exp, true)
 
@@ -86,22 +197,76 @@ func (l *luaGenerator) generateInfixExpression(exp *ast.InfixExpression) {
 	l.generateSubExpression(exp.Right, exp, false)
 }
 
+// generateOperatorCall writes an operator the dialect lacks as a call:
+// a // b becomes math.floor(a / b) and a & b becomes bit.band(a, b) or
+// bit32.band(a, b).
+func (l *luaGenerator) generateOperatorCall(exp *ast.InfixExpression) {
+	if exp.Operator == "//" {
+		l.buf.WriteString("math.floor(")
+		l.generateInfixExpression(&ast.InfixExpression{BaseNode: exp.BaseNode, Left: exp.Left, Operator: "/", Right: exp.Right})
+		l.buf.WriteString(")")
+		return
+	}
+
+	l.buf.WriteString(l.bitLib() + "." + bitFuncs[exp.Operator] + "(")
+	l.generateExpression(exp.Left)
+	l.buf.WriteString(", ")
+	l.generateExpression(exp.Right)
+	l.buf.WriteString(")")
+}
+
+// bitFuncs names the bit and bit32 functions of the bitwise operators.
+var bitFuncs = map[string]string{
+	"&":  "band",
+	"|":  "bor",
+	"~":  "bxor",
+	"<<": "lshift",
+	">>": "rshift",
+}
+
+// lowersToCall reports whether the binary operator op is written as a
+// call because the dialect lacks it.
+func (l *luaGenerator) lowersToCall(op string) bool {
+	return !l.hasIntegers() && (op == "//" || ast.IsBitwise(op))
+}
+
 func (l *luaGenerator) generateSubExpression(sub ast.Expression, parent *ast.InfixExpression, isLeft bool) {
-	if subInfix, ok := sub.(*ast.InfixExpression); ok {
-		needParens := needParentheses(subInfix, parent, isLeft)
+	switch e := sub.(type) {
+	case *ast.InfixExpression:
+		needParens := l.needParentheses(e, parent, isLeft)
 		if needParens {
 			l.buf.WriteString("(")
 		}
-		l.generateInfixExpression(subInfix)
+		l.generateInfixExpression(e)
 		if needParens {
 			l.buf.WriteString(")")
 		}
-	} else {
+	case *ast.Integer, *ast.Float:
+		if parent.Operator == "^" && isLeft && isNegative(e) { // (-2) ^ 2
+			l.buf.WriteString("(")
+			l.generateExpression(e)
+			l.buf.WriteString(")")
+			return
+		}
+		l.generateExpression(e)
+	case *ast.PrefixExpression:
+		if parent.Operator == "^" && isLeft { // -x ^ 2 would read as -(x ^ 2)
+			l.buf.WriteString("(")
+			l.generatePrefixExpression(e)
+			l.buf.WriteString(")")
+			return
+		}
+		l.generatePrefixExpression(e)
+	default:
 		l.generateExpression(sub)
 	}
 }
 
-func needParentheses(sub *ast.InfixExpression, parent *ast.InfixExpression, isLeft bool) bool {
+func (l *luaGenerator) needParentheses(sub *ast.InfixExpression, parent *ast.InfixExpression, isLeft bool) bool {
+	if l.lowersToCall(sub.Operator) {
+		return false
+	}
+
 	subPrec := operatorPrecedence(sub.Operator)
 	parentPrec := operatorPrecedence(parent.Operator)
 
@@ -109,25 +274,89 @@ func needParentheses(sub *ast.InfixExpression, parent *ast.InfixExpression, isLe
 		return true
 	}
 
-	if subPrec == parentPrec { // 对于同优先级，需考虑结合性
-		switch parent.Operator {
-		case "and", "or":
-			if !isLeft {
-				return true
-			}
-		}
+	if subPrec == parentPrec { // 除 ^ 外同优先级的运算符都是左结合的，a - (b - c) 需保留括号
+		return !isLeft || parent.Operator == "^"
 	}
 
 	return false
 }
 
 func (l *luaGenerator) generateDotExpression(exp *ast.DotExpression) {
-	l.generateExpression(exp.Left)
-	l.buf.WriteString(".")
-	if id, ok := exp.Right.(*ast.Identifier); ok {
-		l.buf.WriteString(id.Value)
-	} else {
-		l.generateExpression(exp.Right)
+	if exp.Optional {
+		l.generateOptional(exp.Left, func() {
+			l.generateMember(exp.Right.(*ast.Identifier).Value)
+		})
+		return
+	}
+	l.generatePrefixOperand(exp.Left)
+	l.generateMember(exp.Right.(*ast.Identifier).Value)
+}
+
+// generateOptional lowers ?. to a function applied to the receiver, so the
+// receiver is evaluated once and the rest, written by member, only runs
+// when it is not nil. Unlike "recv and recv.x" it keeps false results.
+func (l *luaGenerator) generateOptional(recv ast.Expression, member func()) {
+	l.buf.WriteString("(function(_recv) if _recv ~= nil then return _recv")
+	member()
+	l.buf.WriteString(" end end)(")
+	l.generateExpression(recv)
+	l.buf.WriteString(")")
+}
+
+// generateConditionalExpression writes cond ? a : b. The and/or idiom
+// returns b when a is false or nil, so it is only used when a is a literal
+// that is always truthy; otherwise the branches go into a function that is
+// called on the spot. Both forms are parenthesized.
+func (l *luaGenerator) generateConditionalExpression(exp *ast.ConditionalExpression) {
+	if isTruthyLiteral(exp.Consequence) {
+		l.buf.WriteString("(")
+		l.generateSubExpression(exp.Condition, &ast.InfixExpression{Operator: "and"}, true)
+		l.buf.WriteString(" and ")
+		l.generateSubExpression(exp.Consequence, &ast.InfixExpression{Operator: "and"}, false)
+		l.buf.WriteString(" or ")
+		l.generateSubExpression(exp.Alternative, &ast.InfixExpression{Operator: "or"}, false)
+		l.buf.WriteString(")")
+		return
+	}
+
+	l.buf.WriteString("(function() if ")
+	l.generateExpression(exp.Condition)
+	l.buf.WriteString(" then return ")
+	l.generateExpression(exp.Consequence)
+	l.buf.WriteString(" else return ")
+	l.generateExpression(exp.Alternative)
+	l.buf.WriteString(" end end)()")
+}
+
+// isTruthyLiteral reports whether exp is a literal that Lua treats as true.
+func isTruthyLiteral(exp ast.Expression) bool {
+	switch e := exp.(type) {
+	case *ast.Integer, *ast.Float, *ast.String, *ast.InterpolatedString, *ast.TableDef, *ast.FunctionDef:
+		return true
+	case *ast.Boolean:
+		return e.Value
+	}
+	return false
+}
+
+func (l *luaGenerator) generateIndexExpression(exp *ast.IndexExpression) {
+	l.generatePrefixOperand(exp.Left)
+	l.buf.WriteString("[")
+	l.generateExpression(exp.Index)
+	l.buf.WriteString("]")
+}
+
+// generatePrefixOperand writes the object being indexed. Lua only accepts
+// names, calls, index expressions and parenthesized expressions there, so
+// literals such as {1, 2}[1] need parentheses.
+func (l *luaGenerator) generatePrefixOperand(exp ast.Expression) {
+	switch exp.(type) {
+	case *ast.Identifier, *ast.DotExpression, *ast.IndexExpression, *ast.FunctionCall, *ast.ConditionalExpression:
+		l.generateExpression(exp)
+	default:
+		l.buf.WriteString("(")
+		l.generateExpression(exp)
+		l.buf.WriteString(")")
 	}
 }
 
@@ -136,6 +365,16 @@ func (l *luaGenerator) generatePrefixExpression(exp *ast.PrefixExpression) {
 	case "not":
 		l.buf.WriteString("not ")
 		l.generateExpression(exp.Right)
+	case "~":
+		if lib := l.bitLib(); lib != "" {
+			l.buf.WriteString(lib + ".bnot(")
+			l.generateExpression(exp.Right)
+			l.buf.WriteString(")")
+			return
+		}
+		l.buf.WriteString("~(")
+		l.generateExpression(exp.Right)
+		l.buf.WriteString(")")
 	default:
 		l.buf.WriteString(exp.Operator)
 		l.buf.WriteString("(")
@@ -146,24 +385,79 @@ func (l *luaGenerator) generatePrefixExpression(exp *ast.PrefixExpression) {
 
 func (l *luaGenerator) generateExpressionStatement(stmt *ast.ExprStmt) {
 	l.buf.WriteString(l.indent_str())
+	if l.startsWithParen(stmt.Expression) {
+		// Lua would read the parenthesis as a call of the previous line.
+		l.buf.WriteString(";")
+	}
 	l.generateExpression(stmt.Expression)
 }
 
+// startsWithParen reports whether the Lua code for exp begins with "(".
+func (l *luaGenerator) startsWithParen(exp ast.Expression) bool {
+	switch e := exp.(type) {
+	case *ast.DotExpression:
+		return e.Optional || l.prefixStartsWithParen(e.Left)
+	case *ast.IndexExpression:
+		return l.prefixStartsWithParen(e.Left)
+	case *ast.FunctionCall:
+		if dot, ok := e.Function.(*ast.DotExpression); ok {
+			return dot.Optional || l.prefixStartsWithParen(dot.Left)
+		}
+		return l.prefixStartsWithParen(e.Function)
+	case *ast.ConditionalExpression:
+		return true
+	case *ast.InfixExpression:
+		if l.lowersToCall(e.Operator) {
+			return false
+		}
+		switch left := e.Left.(type) {
+		case *ast.InfixExpression:
+			if l.needParentheses(left, e, true) {
+				return true
+			}
+		case *ast.PrefixExpression:
+			return e.Operator == "^"
+		}
+		return l.startsWithParen(e.Left)
+	}
+	return false
+}
+
+// prefixStartsWithParen is startsWithParen for an operand written by
+// generatePrefixOperand.
+func (l *luaGenerator) prefixStartsWithParen(exp ast.Expression) bool {
+	switch exp.(type) {
+	case *ast.Identifier, *ast.DotExpression, *ast.IndexExpression, *ast.FunctionCall, *ast.ConditionalExpression:
+		return l.startsWithParen(exp)
+	}
+	return true
+}
+
+// operatorPrecedence follows the Lua 5.3 manual; unary operators sit
+// between the multiplicative operators and ^.
 func operatorPrecedence(op string) int {
 	switch op {
 	case "^":
+		return 10
+	case "*", "/", "//", "%":
+		return 9
+	case "+", "-":
+		return 8
+	case "<<", ">>":
 		return 7
-	case "*", "/", "%":
+	case "&":
 		return 6
-	case "+", "-":
+	case "~":
 		return 5
-	case "==", "~=", "<", ">", "<=", ">=":
+	case "|":
 		return 4
-	case "and":
+	case "==", "!=", "<", ">", "<=", ">=":
 		return 3
-	case "or":
+	case "and":
 		return 2
-	default:
+	case "or":
 		return 1
+	default:
+		return 0
 	}
 }
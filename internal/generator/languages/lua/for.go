@@ -1,13 +1,20 @@
 package lua
 
 import (
+	"fmt"
+
 	"github.com/hsoul/skconf/internal/ast"
 )
 
 func (l *luaGenerator) generateForStatement(stmt *ast.ForStatement) {
+	counter := l.budget(stmt)
 	l.buf.WriteString(l.indent_str())
 
 	isBraced := false
+	// A numeric for gives each iteration its own variable; a function that
+	// captures it must share it with the other iterations, as on the other
+	// targets.
+	numeric := isNumericForLoop(stmt) && !stmt.Captured()
 
 	if stmt.IsRangeForm {
 		l.buf.WriteString("for ")
@@ -19,7 +26,7 @@ func (l *luaGenerator) generateForStatement(stmt *ast.ForStatement) {
 		l.buf.WriteString(" in pairs(")
 		l.generateExpression(stmt.RangeValue)
 		l.buf.WriteString(") do\n")
-	} else if isNumericForLoop(stmt) {
+	} else if numeric {
 		l.buf.WriteString("for ")
 		ok := l.generateNumericForParams(stmt)
 		if !ok {
@@ -27,42 +34,28 @@ func (l *luaGenerator) generateForStatement(stmt *ast.ForStatement) {
 		}
 		l.buf.WriteString(" do\n")
 	} else {
-		if stmt.Init != nil {
-			if varStmt, ok := stmt.Init.(*ast.VarStatement); ok {
-				isBraced = true
-				l.indent++
-				l.buf.WriteString("do\n")
-				l.buf.WriteString(l.indent_str())
-				l.buf.WriteString("local ")
-				l.generateExpression(varStmt.Name)
-				l.buf.WriteString(" = ")
-				l.generateExpression(varStmt.Value)
-				l.buf.WriteString("\n")
-				l.buf.WriteString(l.indent_str())
-				l.buf.WriteString("while ")
-				l.generateExpression(stmt.Condition)
-				l.buf.WriteString(" do\n")
-			}
-		} else {
-			l.buf.WriteString("while ")
+		if stmt.Init != nil { // do ... end keeps a variable declared by init local to the loop
+			isBraced = true
+			l.indent++
+			l.buf.WriteString("do\n")
+			l.generateNode(stmt.Init)
+			l.buf.WriteString(l.indent_str())
+		}
+		l.buf.WriteString("while ")
+		if stmt.Condition != nil {
 			l.generateExpression(stmt.Condition)
-			l.buf.WriteString(" do\n")
+		} else {
+			l.buf.WriteString("true")
 		}
+		l.buf.WriteString(" do\n")
 	}
 
 	l.indent++
-	if stmt.Body != nil {
-		for _, s := range stmt.Body.Statements {
-			l.generateNode(s)
-		}
-	}
+	hasPost := !stmt.IsRangeForm && !numeric && stmt.Post != nil
+	l.generateLoopBody(stmt, counter, stmt.Body, hasPost)
 
-	if !stmt.IsRangeForm && !isNumericForLoop(stmt) && stmt.Post != nil {
-		if exprStmt, ok := stmt.Post.(*ast.ExprStmt); ok {
-			l.buf.WriteString(l.indent_str())
-			l.generateExpression(exprStmt.Expression)
-			l.buf.WriteString("\n")
-		}
+	if hasPost {
+		l.generateNode(stmt.Post)
 	}
 	l.indent--
 
@@ -75,6 +68,153 @@ func (l *luaGenerator) generateForStatement(stmt *ast.ForStatement) {
 	}
 }
 
+func (l *luaGenerator) generateWhileStatement(stmt *ast.WhileStatement) {
+	counter := l.budget(stmt)
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("while ")
+	l.generateExpression(stmt.Condition)
+	l.buf.WriteString(" do\n")
+	l.indent++
+	l.generateLoopBody(stmt, counter, stmt.Body, false)
+	l.indent--
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("end\n")
+}
+
+func (l *luaGenerator) generateRepeatStatement(stmt *ast.RepeatStatement) {
+	counter := l.budget(stmt)
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("repeat\n")
+	l.indent++
+	l.generateLoopBody(stmt, counter, stmt.Body, true)
+	l.indent--
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("until ")
+	l.generateExpression(stmt.Condition)
+	l.buf.WriteString("\n")
+}
+
+// budget declares the pass counter of loop if it counts its passes against
+// the loop budget, and returns its name; otherwise it returns "".
+func (l *luaGenerator) budget(loop ast.Statement) string {
+	if !l.unit.Budgeted(loop) {
+		return ""
+	}
+	l.passes++
+	counter := fmt.Sprintf("_passes_%d", l.passes)
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("local " + counter + " = 0\n")
+	return counter
+}
+
+// generateLoopBody writes the statements of the body of loop, which first
+// counts the pass if counter is not empty. Lua has no continue,
+// so a body that uses it gets a goto label at its end. When code follows the
+// body (the post statement of a classic for, the condition of a repeat) a
+// body with a continue or a local is wrapped in do ... end, so that the goto
+// never jumps into the scope of a body local and the code after it never
+// sees one.
+func (l *luaGenerator) generateLoopBody(loop ast.Statement, counter string, body *ast.CodeBlock, hasTail bool) {
+	label := ""
+	if body != nil && hasContinue(body.Statements) {
+		l.labels++
+		label = fmt.Sprintf("continue_%d", l.labels)
+	}
+
+	l.loops = append(l.loops, label)
+	defer func() { l.loops = l.loops[:len(l.loops)-1] }()
+
+	if counter != "" {
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString(counter + " = " + counter + " + 1\n")
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString(fmt.Sprintf("if %s > %d then error(%s, 0) end\n", counter, l.unit.LoopBudget, quote(l.unit.BudgetError(loop))))
+	}
+
+	wrap := hasTail && (label != "" || body != nil && declaresLocal(body.Statements))
+	if wrap {
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString("do\n")
+		l.indent++
+	}
+
+	if body != nil {
+		for _, s := range body.Statements {
+			l.generateNode(s)
+		}
+	}
+
+	if wrap {
+		l.indent--
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString("end\n")
+	}
+
+	if label != "" {
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString("::" + label + "::\n")
+	}
+}
+
+func (l *luaGenerator) generateBreakStatement(stmt *ast.BreakStatement) {
+	if len(l.loops) == 0 {
+		l.errorf(stmt, "break is not in a loop")
+		return
+	}
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("break\n")
+}
+
+func (l *luaGenerator) generateContinueStatement(stmt *ast.ContinueStatement) {
+	if len(l.loops) == 0 {
+		l.errorf(stmt, "continue is not in a loop")
+		return
+	}
+	if l.dialect == Lua51 {
+		l.errorf(stmt, "lua: continue needs goto, which Lua 5.1 lacks")
+		return
+	}
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("goto " + l.loops[len(l.loops)-1] + "\n")
+}
+
+// hasContinue reports whether a continue in stmts targets the loop owning
+// them, i.e. one that is not nested in an inner loop or function.
+func hasContinue(stmts []ast.Statement) bool {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.ContinueStatement:
+			return true
+		case *ast.IfStatement:
+			if s.Consequence != nil && hasContinue(s.Consequence.Statements) {
+				return true
+			}
+			for _, alt := range s.Alternatives {
+				if alt.Consequence != nil && hasContinue(alt.Consequence.Statements) {
+					return true
+				}
+			}
+		case *ast.MatchStatement:
+			for _, arm := range s.Arms {
+				if hasContinue(arm.Body.Statements) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// declaresLocal reports whether stmts declare a variable of their block.
+func declaresLocal(stmts []ast.Statement) bool {
+	for _, stmt := range stmts {
+		if _, ok := stmt.(*ast.VarStatement); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func isNumericForLoop(stmt *ast.ForStatement) bool {
 	initVar, initVal := extractInitStatement(stmt)
 	if initVar == nil || initVal == nil {
@@ -151,7 +291,13 @@ func (l *luaGenerator) generateNumericForParams(stmt *ast.ForStatement) bool {
 			if postOp == "-" {
 				l.buf.WriteString("-")
 			}
-			l.generateExpression(step)
+			if _, ok := step.(*ast.InfixExpression); ok && postOp == "-" {
+				l.buf.WriteString("(")
+				l.generateExpression(step)
+				l.buf.WriteString(")")
+			} else {
+				l.generateExpression(step)
+			}
 		}
 	}
 
@@ -177,37 +323,30 @@ func extractCondition(expr ast.Expression) (varExpr ast.Expression, operator str
 	return nil, "", nil
 }
 
+// extractPostStatement matches a post statement that steps the loop
+// variable: i++, i--, i += n, i -= n, i = i + n or i = i - n.
 func extractPostStatement(stmt ast.Statement) (varExpr ast.Expression, operator string, valExpr ast.Expression) {
-	if stmt == nil {
-		return nil, "", nil
-	}
-
-	exprStmt, ok := stmt.(*ast.ExprStmt)
+	assign, ok := stmt.(*ast.AssignStatement)
 	if !ok {
 		return nil, "", nil
 	}
 
-	assignInfix, ok := exprStmt.Expression.(*ast.InfixExpression)
-	if !ok {
-		return nil, "", nil
-	}
-
-	if assignInfix.Operator != "=" {
-		return nil, "", nil
-	}
-
-	operInfix, ok := assignInfix.Right.(*ast.InfixExpression)
-	if !ok {
+	if _, ok := assign.Target.(*ast.Identifier); !ok {
 		return nil, "", nil
 	}
 
-	if !ast.IsSameIdentifier(assignInfix.Left, operInfix.Left) {
-		return nil, "", nil
+	op, operand := assign.Update()
+	if op == "" {
+		operInfix, ok := assign.Value.(*ast.InfixExpression)
+		if !ok || !ast.IsSameIdentifier(assign.Target, operInfix.Left) {
+			return nil, "", nil
+		}
+		op, operand = operInfix.Operator, operInfix.Right
 	}
 
-	switch operInfix.Operator {
+	switch op {
 	case "+", "-":
-		return assignInfix.Left, operInfix.Operator, operInfix.Right
+		return assign.Target, op, operand
 	default:
 		return nil, "", nil
 	}
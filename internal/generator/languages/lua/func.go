@@ -1,7 +1,10 @@
 package lua
 
 import (
+	"strings"
+
 	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
 )
 
 func isSkillProcessFunc(fn *ast.FunctionDef) bool {
@@ -34,7 +37,14 @@ func isSkillFuncCall(call *ast.FunctionCall) bool {
 }
 
 func (l *luaGenerator) generateFunctionDef(fn *ast.FunctionDef) {
-	l.buf.WriteString("function(")
+	l.buf.WriteString("function")
+	l.generateFunctionBody(fn)
+}
+
+// generateFunctionBody writes the parameters and the body of fn, which
+// follow "function" or "function name".
+func (l *luaGenerator) generateFunctionBody(fn *ast.FunctionDef) {
+	l.buf.WriteString("(")
 	if isSkillProcessFunc(fn) {
 		l.buf.WriteString("ctx")
 		if len(fn.Parameters) > 0 {
@@ -43,13 +53,17 @@ func (l *luaGenerator) generateFunctionDef(fn *ast.FunctionDef) {
 	}
 
 	for i, param := range fn.Parameters {
-		l.buf.WriteString(param.Value)
+		l.buf.WriteString(luaName(param.Value))
 		if i < len(fn.Parameters)-1 {
 			l.buf.WriteString(", ")
 		}
 	}
 	l.buf.WriteString(")\n")
 
+	loops := l.loops
+	l.loops = nil
+	defer func() { l.loops = loops }()
+
 	l.indent++
 	if fn.Body != nil {
 		for _, stmt := range fn.Body.Statements {
@@ -62,8 +76,74 @@ func (l *luaGenerator) generateFunctionDef(fn *ast.FunctionDef) {
 	l.buf.WriteString("end")
 }
 
+// functionDecls returns the functions declared at the top level of program.
+func functionDecls(program *ast.Program) []*ast.FunctionDef {
+	var fns []*ast.FunctionDef
+	for _, stmt := range program.Statements {
+		if fn, ok := stmt.(*ast.FunctionDef); ok && fn.Name != nil {
+			fns = append(fns, fn)
+		}
+	}
+	return fns
+}
+
+// generateFunctionNames declares the locals of the functions of program
+// up front, so that each can call the others whatever their order. It
+// reports whether program has any.
+func (l *luaGenerator) generateFunctionNames(program *ast.Program) bool {
+	fns := functionDecls(program)
+	if len(fns) == 0 {
+		return false
+	}
+	names := make([]string, len(fns))
+	for i, fn := range fns {
+		names[i] = luaName(fn.Name.(*ast.Identifier).Value)
+	}
+	l.buf.WriteString("local " + strings.Join(names, ", ") + "\n\n")
+	return true
+}
+
+// generateFunctionDecl writes a function declared at the top level of the
+// file as an assignment to its local.
+func (l *luaGenerator) generateFunctionDecl(fn *ast.FunctionDef) {
+	l.generateDoc(fn.Doc)
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("function " + luaName(fn.Name.(*ast.Identifier).Value))
+	l.generateFunctionBody(fn)
+	l.buf.WriteString("\n\n")
+}
+
 func (l *luaGenerator) generateFunctionCall(call *ast.FunctionCall) {
-	l.generateExpression(call.Function)
+	dot, ok := call.Function.(*ast.DotExpression)
+	if !ok {
+		l.generatePrefixOperand(call.Function)
+		l.generateArguments(call)
+		return
+	}
+
+	// Members of values returned by calls are host handle methods.
+	name := dot.Right.(*ast.Identifier).Value
+	member := func() { l.generateMember(name) }
+	if _, ok := generator.MethodCall(call); ok {
+		if !isASCII(name) {
+			l.errorf(dot, "lua: method %s cannot be called with :, Lua names are ASCII", name)
+			return
+		}
+		member = func() { l.buf.WriteString(":" + fieldName(name)) }
+	}
+	if dot.Optional { // recv?.f(args) skips the call, arguments included, when recv is nil
+		l.generateOptional(dot.Left, func() {
+			member()
+			l.generateArguments(call)
+		})
+		return
+	}
+	l.generatePrefixOperand(dot.Left)
+	member()
+	l.generateArguments(call)
+}
+
+func (l *luaGenerator) generateArguments(call *ast.FunctionCall) {
 	l.buf.WriteString("(")
 
 	if isSkillFuncCall(call) {
@@ -2,42 +2,151 @@ package lua
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
 	"github.com/hsoul/skconf/internal/generator"
 )
 
 const Language = "lua"
 
+// Options understood by the lua generator.
+const (
+	OptSplit     = "split"     // emit every skill and state as its own module
+	OptSourceMap = "sourcemap" // emit <name>.lua.map with generated to source line mappings
+	OptIndex     = "index"     // emit <name>.index.json mapping tids to definitions
+	OptStubs     = "stubs"     // emit <name>.meta.lua with LuaLS type annotations
+	OptDialect   = "dialect"   // target Lua version, one of the dialects below
+)
+
+// Dialects accepted by OptDialect. Lua 5.3 introduced integers together
+// with the // and bitwise operators; older versions get math.floor and the
+// bit (LuaJIT, or LuaBitOp on 5.1) or bit32 (5.2) library instead.
+const (
+	Lua51  = "5.1"
+	Lua52  = "5.2"
+	Lua53  = "5.3" // the default
+	Lua54  = "5.4"
+	LuaJIT = "luajit"
+)
+
 func init() {
 	generator.Register(Language, NewLuaGenerator)
 }
 
 type luaGenerator struct {
 	indent int
-	buf    strings.Builder
+	buf    *strings.Builder
+	lines  *lineMap
 
 	skillMap map[string]string
 	stateMap map[string]string
+	enums    []string // Lua names of the enum tables, in source order
+
+	unit      *generator.Unit
+	artifacts []generator.Artifact
+	diags     []diag.Diagnostic
+
+	loops  []string // continue label of every enclosing loop, "" if unused
+	labels int
+	passes int // pass counters of loops declared so far
+
+	split     bool
+	sourceMap bool
+	index     bool
+	stubs     bool
+	dialect   string
+	usesBit   bool // the unit needs the bit library of the dialect
 }
 
-func NewLuaGenerator() (generator.CodeGenerator, error) {
-	return &luaGenerator{
-		indent:   0,
-		buf:      strings.Builder{},
-		skillMap: make(map[string]string),
-		stateMap: make(map[string]string),
-	}, nil
+func NewLuaGenerator(opts generator.Options) (generator.CodeGenerator, error) {
+	if err := opts.Check(Language, OptSplit, OptSourceMap, OptIndex, OptStubs, OptDialect); err != nil {
+		return nil, err
+	}
+
+	l := &luaGenerator{dialect: opts.String(OptDialect, Lua53)}
+	switch l.dialect {
+	case Lua51, Lua52, Lua53, Lua54, LuaJIT:
+	default:
+		return nil, fmt.Errorf("option %q: unknown dialect %q", OptDialect, l.dialect)
+	}
+
+	var err error
+	if l.split, err = opts.Bool(OptSplit); err != nil {
+		return nil, err
+	}
+	if l.sourceMap, err = opts.Bool(OptSourceMap); err != nil {
+		return nil, err
+	}
+	if l.index, err = opts.Bool(OptIndex); err != nil {
+		return nil, err
+	}
+	if l.stubs, err = opts.Bool(OptStubs); err != nil {
+		return nil, err
+	}
+
+	return l, nil
 }
 
-func (l *luaGenerator) Generate(node ast.Node) string {
-	l.generateNode(node)
-	return l.buf.String()
+func (l *luaGenerator) Generate(unit *generator.Unit) ([]generator.Artifact, []diag.Diagnostic) {
+	l.indent = 0
+	l.buf = &strings.Builder{}
+	l.lines = &lineMap{line: 1}
+	l.skillMap = make(map[string]string)
+	l.stateMap = make(map[string]string)
+	l.enums = nil
+	l.unit = unit
+	l.artifacts = nil
+	l.diags = nil
+	l.loops = nil
+	l.labels = 0
+	l.passes = 0
+	l.usesBit = false
+	if l.bitLib() != "" {
+		ast.Inspect(unit.Program, func(n ast.Node) bool {
+			switch e := n.(type) {
+			case *ast.InfixExpression:
+				l.usesBit = l.usesBit || ast.IsBitwise(e.Operator)
+			case *ast.PrefixExpression:
+				l.usesBit = l.usesBit || ast.IsBitwise(e.Operator)
+			}
+			return !l.usesBit
+		})
+	}
+
+	l.generateNode(unit.Program)
+
+	artifacts := []generator.Artifact{{
+		Name:    unit.Name + ".lua",
+		Kind:    generator.Code,
+		Content: []byte(l.buf.String()),
+	}}
+	if l.sourceMap {
+		artifacts = append(artifacts, l.generateSourceMap(unit.Name+".lua", l.lines))
+	}
+	artifacts = append(artifacts, l.artifacts...)
+	if l.index {
+		artifacts = append(artifacts, l.generateIndex())
+	}
+	if l.stubs {
+		artifacts = append(artifacts, l.generateStubs(unit.Program))
+	}
+
+	return artifacts, l.diags
+}
+
+func (l *luaGenerator) errorf(node ast.Node, format string, args ...any) {
+	l.diags = append(l.diags, diag.Errorf(l.unit.File, node.Pos(), format, args...))
 }
 
 func (l *luaGenerator) generateNode(node ast.Node) {
+	if _, ok := node.(ast.Statement); ok {
+		l.mark(node)
+	}
+
 	switch n := node.(type) {
 	case *ast.Program:
 		l.generateProgram(n)
@@ -46,21 +155,38 @@ func (l *luaGenerator) generateNode(node ast.Node) {
 	case *ast.StateDef:
 		l.generateStateDef(n)
 	case *ast.FunctionDef:
-		l.generateFunctionDef(n)
+		l.generateFunctionDecl(n)
 	case *ast.VarStatement:
 		l.generateVarStatement(n)
+	case *ast.ConstStatement:
+		// Folded into its uses before code generation.
+	case *ast.TemplateDef:
+		// Expanded into the skills and states that use it.
+	case *ast.EnumDef:
+		l.generateEnumDef(n)
 	case *ast.ImportStatement:
 		l.generateImportStatement(n)
 	case *ast.ReturnStatement:
 		l.generateReturnStatement(n)
 	case *ast.ExprStmt:
-		l.buf.WriteString(l.indent_str())
-		l.generateExpression(n.Expression)
+		l.generateExpressionStatement(n)
 		l.buf.WriteString("\n")
+	case *ast.AssignStatement:
+		l.generateAssignStatement(n)
 	case *ast.IfStatement:
 		l.generateIfStatement(n)
+	case *ast.MatchStatement:
+		l.generateMatchStatement(n)
 	case *ast.ForStatement:
 		l.generateForStatement(n)
+	case *ast.WhileStatement:
+		l.generateWhileStatement(n)
+	case *ast.RepeatStatement:
+		l.generateRepeatStatement(n)
+	case *ast.BreakStatement:
+		l.generateBreakStatement(n)
+	case *ast.ContinueStatement:
+		l.generateContinueStatement(n)
 	case *ast.TableDef:
 		l.generateTableDef(n)
 	case *ast.CodeBlock:
@@ -70,7 +196,7 @@ func (l *luaGenerator) generateNode(node ast.Node) {
 			}
 		}
 	default:
-		l.buf.WriteString(fmt.Sprintf("-- Unhandled node type: %T\n", node))
+		l.errorf(node, "lua: unsupported node type %T", node)
 	}
 }
 
@@ -79,22 +205,26 @@ func (l *luaGenerator) indent_str() string {
 }
 
 func (l *luaGenerator) generateProgram(program *ast.Program) {
-	l.buf.WriteString("-- Generated by DSL\n")
-	l.buf.WriteString("-- " + time.Now().Format("2006-01-02 15:04:05") + "\n\n")
-
+	l.generateBanner()
 	l.generateHeader()
 
+	required := false
 	for _, imp := range program.Imports {
 		l.generateNode(&imp)
+		required = required || !imp.Linked
 	}
-
-	if len(program.Imports) > 0 {
+	if required {
 		l.buf.WriteString("\n")
 	}
 
+	l.generateFunctionNames(program)
 	for _, stmt := range program.Statements {
+		if l.split && isBlockStatement(stmt) {
+			l.generateSplitDef(program, stmt)
+			continue
+		}
 		l.generateNode(stmt)
-		if isBlockStatement(stmt) {
+		if _, isEnum := stmt.(*ast.EnumDef); isEnum || isBlockStatement(stmt) {
 			l.buf.WriteString("\n")
 		}
 	}
@@ -111,28 +241,109 @@ func isBlockStatement(stmt ast.Statement) bool {
 	}
 }
 
+func (l *luaGenerator) generateBanner() {
+	l.buf.WriteString("-- Generated by DSL\n")
+	l.buf.WriteString("-- " + time.Now().Format("2006-01-02 15:04:05") + "\n\n")
+}
+
 func (l *luaGenerator) generateHeader() {
 	l.buf.WriteString("local UE = RE\n")
 	l.buf.WriteString("local UF = FC\n")
+	if l.usesBit && l.bitLib() == "bit" {
+		l.buf.WriteString("local bit = require(\"bit\")\n")
+	}
 	l.buf.WriteString("\n")
 }
 
+// hasIntegers reports whether the dialect has the // and bitwise operators.
+func (l *luaGenerator) hasIntegers() bool {
+	return l.dialect == Lua53 || l.dialect == Lua54
+}
+
+// bitLib returns the library the bitwise operators lower to, or "" when
+// the dialect has them.
+func (l *luaGenerator) bitLib() string {
+	switch l.dialect {
+	case Lua51, LuaJIT:
+		return "bit"
+	case Lua52:
+		return "bit32"
+	}
+	return ""
+}
+
+// generateSplitDef writes a skill or state definition to its own module and
+// requires that module from the main one. The module gets its own copy of
+// the functions of program, which its hooks may call.
+func (l *luaGenerator) generateSplitDef(program *ast.Program, stmt ast.Statement) {
+	var name string
+	switch def := stmt.(type) {
+	case *ast.SkillDef:
+		name = def.Name.Value
+	case *ast.StateDef:
+		name = def.Name.Value
+	}
+	name = luaName(name)
+	path := l.unit.Name + "/" + name + ".lua"
+
+	mainBuf, mainLines := l.buf, l.lines
+	l.buf, l.lines = &strings.Builder{}, &lineMap{line: 1}
+
+	l.generateBanner()
+	l.generateHeader()
+	if l.generateFunctionNames(program) {
+		for _, fn := range functionDecls(program) {
+			l.generateNode(fn)
+		}
+	}
+	l.generateNode(stmt)
+	l.buf.WriteString("\nreturn " + name + "\n")
+
+	l.artifacts = append(l.artifacts, generator.Artifact{
+		Name:    path,
+		Kind:    generator.Code,
+		Content: []byte(l.buf.String()),
+	})
+	if l.sourceMap {
+		l.artifacts = append(l.artifacts, l.generateSourceMap(path, l.lines))
+	}
+
+	l.buf, l.lines = mainBuf, mainLines
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString(fmt.Sprintf("local %s = require(%q)\n", name, strings.ReplaceAll(l.unit.Name, "/", ".")+"."+name))
+}
+
 func (l *luaGenerator) generateExport() {
-	if len(l.skillMap) == 0 && len(l.stateMap) == 0 {
+	if len(l.skillMap) == 0 && len(l.stateMap) == 0 && len(l.enums) == 0 {
 		return
 	}
 
 	l.buf.WriteString("return {\n")
 	l.indent++
 
+	if len(l.enums) > 0 {
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString("enums = {\n")
+		l.indent++
+
+		for _, name := range l.enums {
+			l.buf.WriteString(l.indent_str())
+			l.buf.WriteString(fmt.Sprintf("%s = %s,\n", name, name))
+		}
+
+		l.indent--
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString("},\n")
+	}
+
 	if len(l.skillMap) > 0 {
 		l.buf.WriteString(l.indent_str())
 		l.buf.WriteString("skills = {\n")
 		l.indent++
 
-		for tid, name := range l.skillMap {
+		for _, tid := range sortedKeys(l.skillMap) {
 			l.buf.WriteString(l.indent_str())
-			l.buf.WriteString(fmt.Sprintf("[%s] = %s,\n", tid, name))
+			l.buf.WriteString(fmt.Sprintf("[%s] = %s,\n", tid, l.skillMap[tid]))
 		}
 
 		l.indent--
@@ -145,9 +356,9 @@ func (l *luaGenerator) generateExport() {
 		l.buf.WriteString("states = {\n")
 		l.indent++
 
-		for tid, name := range l.stateMap {
+		for _, tid := range sortedKeys(l.stateMap) {
 			l.buf.WriteString(l.indent_str())
-			l.buf.WriteString(fmt.Sprintf("[%s] = %s,\n", tid, name))
+			l.buf.WriteString(fmt.Sprintf("[%s] = %s,\n", tid, l.stateMap[tid]))
 		}
 
 		l.indent--
@@ -158,3 +369,17 @@ func (l *luaGenerator) generateExport() {
 	l.indent--
 	l.buf.WriteString("}")
 }
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { // numeric tids in numeric order
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,63 @@
+package lua
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// luaName turns a DSL identifier into a Lua name for locals, parameters
+// and definitions. Lua names are ASCII, so - becomes _ and any other
+// character, such as the Chinese in 伤害, is spelled _uXXXX.
+func luaName(name string) string {
+	name = strings.ReplaceAll(name, "-", "_")
+	if isASCII(name) {
+		return name
+	}
+	var sb strings.Builder
+	for _, r := range name {
+		if r < utf8.RuneSelf {
+			sb.WriteRune(r)
+		} else {
+			fmt.Fprintf(&sb, "_u%04X", r)
+		}
+	}
+	return sb.String()
+}
+
+// fieldName returns the key a DSL name has in a Lua table: the name with -
+// spelled _. Unlike luaName it keeps other characters, so hosts look
+// fields up by the names designers wrote.
+func fieldName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// generateKey writes a field name before = in a table constructor:
+// name, or ["name"] when Lua cannot spell it as a name.
+func (l *luaGenerator) generateKey(name string) {
+	key := fieldName(name)
+	if isASCII(key) {
+		l.buf.WriteString(key)
+		return
+	}
+	l.buf.WriteString("[" + quote(key) + "]")
+}
+
+// generateMember writes the access of field name: .name or ["name"].
+func (l *luaGenerator) generateMember(name string) {
+	key := fieldName(name)
+	if isASCII(key) {
+		l.buf.WriteString("." + key)
+		return
+	}
+	l.buf.WriteString("[" + quote(key) + "]")
+}
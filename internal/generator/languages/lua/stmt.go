@@ -5,6 +5,17 @@ import (
 )
 
 func (l *luaGenerator) generateVarStatement(stmt *ast.VarStatement) {
+	if fn, ok := stmt.Value.(*ast.FunctionDef); ok {
+		l.generateDoc(fn.Doc)
+		if stmt.Recursive() { // local function puts the name in scope in the body
+			l.buf.WriteString(l.indent_str())
+			l.buf.WriteString("local function ")
+			l.generateExpression(stmt.Name)
+			l.generateFunctionBody(fn)
+			l.buf.WriteString("\n")
+			return
+		}
+	}
 	l.buf.WriteString(l.indent_str())
 	l.buf.WriteString("local ")
 	l.generateExpression(stmt.Name)
@@ -13,10 +24,82 @@ func (l *luaGenerator) generateVarStatement(stmt *ast.VarStatement) {
 	l.buf.WriteString("\n")
 }
 
+// generateAssignStatement writes an assignment. Lua has no compound
+// assignment, so x += v becomes x = x + v; when the object or key of the
+// target has side effects, as in f().hp -= 1, it is evaluated once into
+// locals first.
+func (l *luaGenerator) generateAssignStatement(stmt *ast.AssignStatement) {
+	target := stmt.Target
+	op, operand := stmt.Update()
+
+	l.buf.WriteString(l.indent_str())
+	if op != "" && !isPure(target) {
+		l.buf.WriteString("do\n")
+		l.indent++
+		l.buf.WriteString(l.indent_str())
+		switch t := target.(type) {
+		case *ast.DotExpression:
+			l.buf.WriteString("local _obj = ")
+			l.generateExpression(t.Left)
+			target = &ast.DotExpression{BaseNode: t.BaseNode, Left: &ast.Identifier{Value: "_obj"}, Right: t.Right}
+		case *ast.IndexExpression:
+			l.buf.WriteString("local _obj, _key = ")
+			l.generateExpression(t.Left)
+			l.buf.WriteString(", ")
+			l.generateExpression(t.Index)
+			target = &ast.IndexExpression{BaseNode: t.BaseNode, Left: &ast.Identifier{Value: "_obj"}, Index: &ast.Identifier{Value: "_key"}}
+		}
+		l.buf.WriteString("\n")
+		l.buf.WriteString(l.indent_str())
+		defer func() {
+			l.indent--
+			l.buf.WriteString(l.indent_str())
+			l.buf.WriteString("end\n")
+		}()
+	}
+
+	if l.startsWithParen(target) {
+		l.buf.WriteString(";")
+	}
+	l.generateExpression(target)
+	l.buf.WriteString(" = ")
+	if op == "" {
+		l.generateExpression(stmt.Value)
+	} else {
+		l.generateInfixExpression(&ast.InfixExpression{BaseNode: stmt.BaseNode, Left: target, Operator: op, Right: operand})
+	}
+	l.buf.WriteString("\n")
+}
+
+// isPure reports whether evaluating exp twice is harmless, which is the
+// case when it only reads names and literals.
+func isPure(exp ast.Expression) bool {
+	switch e := exp.(type) {
+	case *ast.Identifier, *ast.Integer, *ast.Float, *ast.String, *ast.Boolean:
+		return true
+	case *ast.DotExpression:
+		return isPure(e.Left)
+	case *ast.IndexExpression:
+		return isPure(e.Left) && isPure(e.Index)
+	case *ast.PrefixExpression:
+		return isPure(e.Right)
+	case *ast.InfixExpression:
+		return isPure(e.Left) && isPure(e.Right)
+	case *ast.ConditionalExpression:
+		return isPure(e.Condition) && isPure(e.Consequence) && isPure(e.Alternative)
+	}
+	return false
+}
+
+// generateImportStatement requires a host module. Imports of DSL files
+// generate nothing: their declarations were copied into the program.
 func (l *luaGenerator) generateImportStatement(stmt *ast.ImportStatement) {
+	name, ok := ast.QualifiedName(stmt.Value)
+	if stmt.Linked || !ok {
+		return
+	}
 	l.buf.WriteString(l.indent_str())
-	l.buf.WriteString("require ")
-	l.generateExpression(stmt.Value)
+	l.buf.WriteString("require(" + quote(name) + ")\n")
 }
 
 func (l *luaGenerator) generateIfStatement(stmt *ast.IfStatement) {
@@ -56,6 +139,65 @@ func (l *luaGenerator) generateIfStatement(stmt *ast.IfStatement) {
 	l.buf.WriteString("end\n")
 }
 
+// generateMatchStatement lowers a match to an if chain comparing a local
+// holding the subject, evaluated once, with each pattern. Arms stay inline
+// rather than becoming functions in a dispatch table, so return, break and
+// continue in them keep applying to the enclosing function and loop.
+func (l *luaGenerator) generateMatchStatement(stmt *ast.MatchStatement) {
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("do\n")
+	l.indent++
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("local _match = ")
+	l.generateExpression(stmt.Subject)
+	l.buf.WriteString("\n")
+	subject := &ast.Identifier{BaseNode: stmt.BaseNode, Value: "_match"}
+
+	if len(stmt.Arms) == 1 && stmt.Arms[0].Patterns == nil {
+		for _, s := range stmt.Arms[0].Body.Statements {
+			l.generateNode(s)
+		}
+	} else if len(stmt.Arms) > 0 {
+		for i, arm := range stmt.Arms {
+			if arm.Patterns == nil && len(arm.Body.Statements) == 0 {
+				continue
+			}
+			l.buf.WriteString(l.indent_str())
+			switch {
+			case arm.Patterns == nil:
+				l.buf.WriteString("else\n")
+			default:
+				if i == 0 {
+					l.buf.WriteString("if ")
+				} else {
+					l.buf.WriteString("elseif ")
+				}
+				var cond ast.Expression
+				for _, pattern := range arm.Patterns {
+					var eq ast.Expression = &ast.InfixExpression{BaseNode: arm.BaseNode, Left: subject, Operator: "==", Right: pattern}
+					if cond != nil {
+						eq = &ast.InfixExpression{BaseNode: arm.BaseNode, Left: cond, Operator: "or", Right: eq}
+					}
+					cond = eq
+				}
+				l.generateExpression(cond)
+				l.buf.WriteString(" then\n")
+			}
+			l.indent++
+			for _, s := range arm.Body.Statements {
+				l.generateNode(s)
+			}
+			l.indent--
+		}
+		l.buf.WriteString(l.indent_str())
+		l.buf.WriteString("end\n")
+	}
+
+	l.indent--
+	l.buf.WriteString(l.indent_str())
+	l.buf.WriteString("end\n")
+}
+
 func (l *luaGenerator) generateReturnStatement(stmt *ast.ReturnStatement) {
 	l.buf.WriteString(l.indent_str())
 	l.buf.WriteString("return ")
@@ -9,11 +9,12 @@ func (l *luaGenerator) generateTableDef(table *ast.TableDef) {
 	l.indent++
 
 	for i, prop := range table.Properties {
+		l.generateDoc(prop.Doc)
 		l.buf.WriteString(l.indent_str())
 		if prop.Key != nil {
 			switch key := prop.Key.(type) {
 			case *ast.Identifier:
-				l.buf.WriteString(key.Value)
+				l.generateKey(key.Value)
 			case *ast.String:
 				l.buf.WriteString("[")
 				l.generateExpression(prop.Key)
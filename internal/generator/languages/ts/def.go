@@ -0,0 +1,80 @@
+package ts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+// definition is a skill or state about to become a factory function.
+type definition struct {
+	kind       string
+	name       string
+	doc        string
+	factory    string
+	shape      string // interface of its properties, such as FireballSkill
+	properties []*ast.PropertyDef
+}
+
+func newDefinition(kind, name, doc string, properties []*ast.PropertyDef) *definition {
+	return &definition{
+		kind:       kind,
+		name:       name,
+		doc:        doc,
+		factory:    "new" + generator.PascalCase(name),
+		shape:      generator.PascalCase(name) + generator.PascalCase(kind),
+		properties: properties,
+	}
+}
+
+func (t *tsGenerator) generateDefinition(def *definition) {
+	if def.doc != "" {
+		t.jsdoc(fmt.Sprintf("Creates %s %s.\n\n%s", def.kind, def.name, def.doc))
+	} else {
+		t.line("/** Creates %s %s. */", def.kind, def.name)
+	}
+	t.line("export function %s(host: Host): %s {", def.factory, def.shape)
+	t.indent++
+	t.line("return {")
+	t.indent++
+	t.pushScope()
+	for _, prop := range def.properties {
+		key, ok := prop.Key.(*ast.Identifier)
+		if !ok {
+			continue
+		}
+		t.jsdoc(prop.Doc)
+		fn, ok := prop.Value.(*ast.FunctionDef)
+		if !ok {
+			t.line("%s: %s,", propertyName(key.Value), t.expression(prop.Value))
+			continue
+		}
+		t.generateFunction(propertyName(key.Value), fn)
+		t.line("},")
+	}
+	t.popScope()
+	t.indent--
+	t.line("};")
+	t.indent--
+	t.line("}")
+}
+
+// generateFunction declares fn, a hook or a top-level function, as the
+// method or function head, up to the closing brace, which the caller
+// writes. It takes the host first, then the parameters of fn with the
+// TypeScript types of their annotations.
+func (t *tsGenerator) generateFunction(head string, fn *ast.FunctionDef) {
+	t.pushScope()
+	params := []string{"host: Host"}
+	for i, param := range fn.Parameters {
+		t.declare(param.Value)
+		params = append(params, tsLocal(param.Value)+": "+tsType(fn.ParamType(i)))
+	}
+	t.result = fn.ReturnType()
+	t.line("%s(%s): %s {", head, strings.Join(params, ", "), tsResult(t.result))
+	t.generateFunctionBody(fn.Body)
+	t.result = ""
+	t.popScope()
+}
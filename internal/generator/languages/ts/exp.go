@@ -0,0 +1,255 @@
+package ts
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// expression renders exp as a TypeScript expression. Operands are wrapped
+// in parentheses so the output never depends on JavaScript precedence.
+func (t *tsGenerator) expression(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		if t.isLocal(e.Value) || t.globals[e.Value] {
+			return tsLocal(e.Value)
+		}
+		if fn := t.funcs[e.Value]; fn != nil {
+			return t.functionValue(e.Value, fn)
+		}
+		if t.manifest.IsModule(e.Value) {
+			t.errorf(e, "ts: host module %s can only be called", e.Value)
+			return "undefined"
+		}
+		return "host.global(" + quote(e.Value) + ")"
+	case *ast.Integer:
+		return fmt.Sprintf("%d", e.Value)
+	case *ast.Float:
+		return formatFloat(e.Value)
+	case *ast.String:
+		return quote(e.Value)
+	case *ast.InterpolatedString:
+		parts := make([]string, len(e.Parts))
+		for i, part := range e.Parts {
+			parts[i] = t.expression(part)
+		}
+		return "dsl.concat(" + strings.Join(parts, ", ") + ")"
+	case *ast.Boolean:
+		return strconv.FormatBool(e.Value)
+	case *ast.TableDef:
+		return t.table(e)
+	case *ast.PrefixExpression:
+		switch e.Operator {
+		case "-":
+			return "(-" + t.operand(e.Right) + ")"
+		case "~":
+			return "(~" + t.operand(e.Right) + ")"
+		case "not":
+			return "!" + t.condition(e.Right)
+		}
+	case *ast.InfixExpression:
+		return t.infix(e)
+	case *ast.DotExpression:
+		if right, ok := e.Right.(*ast.Identifier); ok {
+			if e.Optional {
+				// Parenthesized so that, as in the other backends, ?. only
+				// guards its own member and not the rest of the chain.
+				return "(" + t.receiver(e.Left) + "?." + right.Value + ")"
+			}
+			return t.receiver(e.Left) + "." + right.Value
+		}
+	case *ast.IndexExpression:
+		return "dsl.index(" + t.expression(e.Left) + ", " + t.expression(e.Index) + ")"
+	case *ast.ConditionalExpression:
+		return "(" + t.condition(e.Condition) + " ? " + t.expression(e.Consequence) + " : " + t.expression(e.Alternative) + ")"
+	case *ast.FunctionCall:
+		return t.call(e)
+	case *ast.FunctionDef:
+		return t.function(e)
+	}
+
+	t.errorf(exp, "ts: unsupported expression %T", exp)
+	return "undefined"
+}
+
+// condition renders exp as a boolean expression with DSL truthiness.
+func (t *tsGenerator) condition(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Boolean:
+		return strconv.FormatBool(e.Value)
+	case *ast.PrefixExpression:
+		if e.Operator == "not" {
+			return "!" + t.condition(e.Right)
+		}
+	case *ast.InfixExpression:
+		switch e.Operator {
+		case "and":
+			return "(" + t.condition(e.Left) + " && " + t.condition(e.Right) + ")"
+		case "or":
+			return "(" + t.condition(e.Left) + " || " + t.condition(e.Right) + ")"
+		case "==", "!=", "<", ">", "<=", ">=":
+			return t.infix(e)
+		}
+	}
+	return "dsl.truthy(" + t.expression(exp) + ")"
+}
+
+// operators maps DSL operators to JavaScript ones. The bitwise operators
+// work on 32-bit integers in JavaScript, and >> is the logical >>> as in
+// Lua.
+var operators = map[string]string{
+	"+": "+", "-": "-", "*": "*", "/": "/", "^": "**",
+	"==": "===", "!=": "!==", "<": "<", "<=": "<=", ">": ">", ">=": ">=",
+	"&": "&", "|": "|", "~": "^", "<<": "<<", ">>": ">>>",
+}
+
+func (t *tsGenerator) infix(exp *ast.InfixExpression) string {
+	switch exp.Operator {
+	case "and", "or":
+		return "dsl." + exp.Operator + "(" + t.expression(exp.Left) + ", () => " + t.expression(exp.Right) + ")"
+	case "==", "!=":
+		return "(" + t.expression(exp.Left) + " " + operators[exp.Operator] + " " + t.expression(exp.Right) + ")"
+	case "%":
+		return "dsl.mod(" + t.operand(exp.Left) + ", " + t.operand(exp.Right) + ")"
+	case "//":
+		return "Math.floor(" + t.operand(exp.Left) + " / " + t.operand(exp.Right) + ")"
+	}
+
+	if op, ok := operators[exp.Operator]; ok {
+		return "(" + t.operand(exp.Left) + " " + op + " " + t.operand(exp.Right) + ")"
+	}
+
+	t.errorf(exp, "ts: unsupported operator %s", exp.Operator)
+	return "undefined"
+}
+
+// operand renders an arithmetic or ordering operand. Booleans are cast to
+// any, since TypeScript rejects them there while the DSL only fails at
+// runtime.
+func (t *tsGenerator) operand(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Integer:
+		if e.Value < 0 { // JavaScript rejects -2 ** 2
+			return "(" + t.expression(exp) + ")"
+		}
+	case *ast.Float:
+		if math.Signbit(e.Value) {
+			return "(" + t.expression(exp) + ")"
+		}
+	}
+	if valueType(exp) == "boolean" {
+		return "(" + t.expression(exp) + " as any)"
+	}
+	return t.expression(exp)
+}
+
+// table renders a table constructor. Purely positional tables become
+// arrays and tables keyed only by names become object literals; anything
+// else goes through dsl.table.
+func (t *tsGenerator) table(table *ast.TableDef) string {
+	var array, fields, pairs []string
+	for _, prop := range table.Properties {
+		value := t.expression(prop.Value)
+		switch key := prop.Key.(type) {
+		case nil:
+			array = append(array, value)
+		case *ast.Identifier:
+			fields = append(fields, propertyName(key.Value)+": "+value)
+			pairs = append(pairs, quote(key.Value), value)
+		default:
+			pairs = append(pairs, t.expression(prop.Key), value)
+		}
+	}
+
+	switch {
+	case len(pairs) == 0 && len(array) > 0:
+		return "[" + strings.Join(array, ", ") + "]"
+	case len(array) == 0 && len(fields)*2 == len(pairs):
+		if len(fields) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(fields, ", ") + " }"
+	}
+	return "dsl.table([" + strings.Join(array, ", ") + "], " + strings.Join(pairs, ", ") + ")"
+}
+
+func (t *tsGenerator) call(call *ast.FunctionCall) string {
+	args := make([]string, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		args[i] = t.expression(arg)
+	}
+
+	if module, name, ok := generator.HostCall(call, t.manifest); ok {
+		return "host." + module + "." + name + "(" + strings.Join(args, ", ") + ")"
+	}
+	if id, ok := call.Function.(*ast.Identifier); ok && !t.isLocal(id.Value) && t.funcs[id.Value] != nil {
+		return tsLocal(id.Value) + "(" + strings.Join(append([]string{"host"}, args...), ", ") + ")"
+	}
+
+	if dot, ok := call.Function.(*ast.DotExpression); ok {
+		// JavaScript passes the receiver as this, which is what method
+		// calls on host handles need; ?. skips the arguments too.
+		op := "."
+		if dot.Optional {
+			op = "?."
+		}
+		callee := t.receiver(dot.Left) + op + dot.Right.(*ast.Identifier).Value
+		if dot.Optional {
+			return "(" + callee + "(" + strings.Join(args, ", ") + "))"
+		}
+		return callee + "(" + strings.Join(args, ", ") + ")"
+	}
+	return t.receiver(call.Function) + "(" + strings.Join(args, ", ") + ")"
+}
+
+// receiver renders the object of a member access or call, parenthesizing
+// expressions JavaScript would not parse in that position.
+func (t *tsGenerator) receiver(exp ast.Expression) string {
+	switch exp.(type) {
+	case *ast.FunctionDef, *ast.TableDef, *ast.Integer, *ast.Float:
+		return "(" + t.expression(exp) + ")"
+	}
+	return t.expression(exp)
+}
+
+// functionValue renders the top-level function name as an arrow function,
+// for when it is passed around rather than called.
+func (t *tsGenerator) functionValue(name string, fn *ast.FunctionDef) string {
+	params := make([]string, len(fn.Parameters))
+	args := []string{"host"}
+	for i, param := range fn.Parameters {
+		params[i] = tsLocal(param.Value) + ": any"
+		args = append(args, tsLocal(param.Value))
+	}
+	return "((" + strings.Join(params, ", ") + "): any => " + tsLocal(name) + "(" + strings.Join(args, ", ") + "))"
+}
+
+// function renders a function value as an arrow function. JavaScript
+// closures already capture by reference, as Lua's do.
+func (t *tsGenerator) function(fn *ast.FunctionDef) string {
+	outer, outerInit, outerResult := t.buf, t.inInit, t.result
+	t.buf, t.inInit, t.result = &strings.Builder{}, false, fn.ReturnType()
+
+	t.pushScope()
+	params := make([]string, len(fn.Parameters))
+	for i, param := range fn.Parameters {
+		t.declare(param.Value)
+		params[i] = tsLocal(param.Value) + ": " + tsType(fn.ParamType(i))
+	}
+	t.buf.WriteString("(" + strings.Join(params, ", ") + "): " + tsResult(t.result) + " => {\n")
+	t.generateFunctionBody(fn.Body)
+	t.buf.WriteString(strings.Repeat("  ", t.indent) + "}")
+	t.popScope()
+
+	out := t.buf.String()
+	t.buf, t.inInit, t.result = outer, outerInit, outerResult
+	return out
+}
@@ -0,0 +1,247 @@
+package ts
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/generator"
+	"github.com/hsoul/skconf/internal/manifest"
+)
+
+const Language = "ts"
+
+// Options understood by the ts generator.
+const (
+	OptRuntime = "runtime" // module the runtime is imported from; when set, runtime.ts is not emitted
+)
+
+const defaultRuntime = "./runtime"
+
+func init() {
+	generator.Register(Language, NewTSGenerator)
+}
+
+// tsGenerator compiles a unit to a TypeScript module for web tools: a
+// factory per skill and state returning a plain object with literal
+// properties and hook methods, registries from tid to factory, and a
+// <unit>.types.d.ts describing the host interface and the data shape of
+// skills and states.
+type tsGenerator struct {
+	buf    *strings.Builder
+	indent int
+
+	unit     *generator.Unit
+	manifest *manifest.Manifest
+	diags    []diag.Diagnostic
+
+	runtime string
+	globals map[string]bool             // top-level vars, declared at module level
+	funcs   map[string]*ast.FunctionDef // top-level functions, exported functions of the module
+	scopes  []map[string]bool           // locals of the enclosing blocks
+	inInit  bool                        // generating init, which returns void
+	result  string                      // manifest type of the result of the current function
+	passes  int                         // pass counters of loops declared so far
+}
+
+func NewTSGenerator(opts generator.Options) (generator.CodeGenerator, error) {
+	if err := opts.Check(Language, OptRuntime); err != nil {
+		return nil, err
+	}
+	return &tsGenerator{runtime: opts.String(OptRuntime, "")}, nil
+}
+
+func (t *tsGenerator) Generate(unit *generator.Unit) ([]generator.Artifact, []diag.Diagnostic) {
+	t.buf = &strings.Builder{}
+	t.indent = 0
+	t.unit = unit
+	t.manifest = unit.Manifest
+	t.diags = nil
+	t.globals = map[string]bool{}
+	t.funcs = map[string]*ast.FunctionDef{}
+	t.passes = 0
+	t.scopes = nil
+
+	runtime := t.runtime
+	if runtime == "" {
+		runtime = defaultRuntime
+	}
+
+	skills, states := t.generateProgram(unit.Program, runtime)
+	code := t.buf.String()
+
+	t.buf = &strings.Builder{}
+	t.generateTypes(unit.Program, skills, states)
+
+	artifacts := []generator.Artifact{
+		{Name: unit.Name + ".ts", Kind: generator.Code, Content: []byte(code)},
+		{Name: unit.Name + ".types.d.ts", Kind: generator.TypeStubs, Content: []byte(t.buf.String())},
+	}
+	if t.runtime == "" {
+		artifacts = append(artifacts, generator.Artifact{
+			Name:    path.Join(path.Dir(unit.Name), "runtime.ts"),
+			Kind:    generator.Code,
+			Content: []byte(runtimeSource),
+		})
+	}
+	return artifacts, t.diags
+}
+
+func (t *tsGenerator) errorf(node ast.Node, format string, args ...any) {
+	t.diags = append(t.diags, diag.Errorf(t.unit.File, node.Pos(), format, args...))
+}
+
+func (t *tsGenerator) line(format string, args ...any) {
+	if format != "" {
+		t.buf.WriteString(strings.Repeat("  ", t.indent))
+		t.buf.WriteString(fmt.Sprintf(format, args...))
+	}
+	t.buf.WriteString("\n")
+}
+
+// jsdoc writes text as a /** */ comment; empty text writes nothing.
+func (t *tsGenerator) jsdoc(text string) {
+	if text == "" {
+		return
+	}
+	text = strings.ReplaceAll(text, "*/", "*\\/")
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		t.line("/** %s */", lines[0])
+		return
+	}
+	t.line("/**")
+	for _, line := range lines {
+		t.line("%s", strings.TrimRight(" * "+line, " "))
+	}
+	t.line(" */")
+}
+
+func (t *tsGenerator) generateProgram(program *ast.Program, runtime string) (skills, states []*definition) {
+	types := "./" + path.Base(t.unit.Name) + ".types"
+
+	t.line("// Code generated by skconf from %s. DO NOT EDIT.", t.unit.File)
+	t.line("")
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.SkillDef:
+			skills = append(skills, newDefinition("skill", s.Name.Value, s.Doc, s.MergedProperties()))
+		case *ast.StateDef:
+			states = append(states, newDefinition("state", s.Name.Value, s.Doc, s.MergedProperties()))
+		}
+	}
+	names := []string{"Host", "Skill", "State"}
+	for _, def := range slices.Concat(skills, states) {
+		names = append(names, def.shape)
+	}
+	t.line("import * as dsl from %s;", quote(runtime))
+	t.line("import type { %s } from %s;", strings.Join(names, ", "), quote(types))
+	t.line("")
+	t.line("export type { %s } from %s;", strings.Join(names, ", "), quote(types))
+	t.line("")
+
+	var topLevel []ast.Statement
+	var funcs []*ast.FunctionDef
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.SkillDef, *ast.StateDef:
+			// Collected above.
+		case *ast.VarStatement:
+			t.globals[s.Name.Value] = true
+			topLevel = append(topLevel, stmt)
+		case *ast.FunctionDef:
+			if name, ok := s.Name.(*ast.Identifier); ok {
+				t.funcs[name.Value] = s
+				funcs = append(funcs, s)
+				continue
+			}
+			topLevel = append(topLevel, stmt)
+		case *ast.ConstStatement:
+			// Folded into its uses before code generation.
+		case *ast.TemplateDef:
+			// Expanded into the skills and states that use it.
+		case *ast.EnumDef:
+			t.generateEnum(s)
+		default:
+			topLevel = append(topLevel, stmt)
+		}
+	}
+
+	for _, name := range sortedNames(t.globals) {
+		t.line("let %s: any;", tsLocal(name))
+	}
+	if len(t.globals) > 0 {
+		t.line("")
+	}
+
+	t.line("/** Runs the top-level statements of %s. */", t.unit.File)
+	t.line("export function init(host: Host): void {")
+	t.indent++
+	t.inInit = true
+	t.pushScope()
+	for _, stmt := range topLevel {
+		t.generateStatement(stmt)
+	}
+	t.popScope()
+	t.inInit = false
+	t.indent--
+	t.line("}")
+
+	for _, fn := range funcs {
+		t.line("")
+		t.jsdoc(fn.Doc)
+		t.generateFunction("export function "+tsLocal(fn.Name.(*ast.Identifier).Value), fn)
+		t.line("}")
+	}
+
+	for _, def := range skills {
+		t.line("")
+		t.generateDefinition(def)
+	}
+	for _, def := range states {
+		t.line("")
+		t.generateDefinition(def)
+	}
+
+	t.line("")
+	t.generateRegistry("skills", "Skill", skills)
+	t.line("")
+	t.generateRegistry("states", "State", states)
+	return skills, states
+}
+
+// generateEnum declares an enum as a readonly object of its members and a
+// union type of their values, which unlike a TypeScript enum needs no
+// transpilation. Uses of the members are folded to their values.
+func (t *tsGenerator) generateEnum(enum *ast.EnumDef) {
+	name := tsLocal(enum.Name.Value)
+	t.jsdoc(enum.Doc)
+	t.line("export const %s = {", name)
+	t.indent++
+	for _, member := range enum.Members {
+		t.jsdoc(member.Doc)
+		t.line("%s: %d,", propertyName(member.Name.Value), member.Value.(*ast.Integer).Value)
+	}
+	t.indent--
+	t.line("} as const;")
+	t.line("export type %s = (typeof %s)[keyof typeof %s];", name, name, name)
+	t.line("")
+}
+
+func (t *tsGenerator) generateRegistry(name, typ string, defs []*definition) {
+	t.line("/** Maps %s tids to factories. */", strings.ToLower(typ))
+	t.line("export const %s: Record<number, (host: Host) => %s> = {", name, typ)
+	t.indent++
+	for _, def := range defs {
+		tid := ast.FindPropertyByName("tid", def.properties)
+		if tid == "" {
+			continue
+		}
+		t.line("%s: %s,", tid, def.factory)
+	}
+	t.indent--
+	t.line("};")
+}
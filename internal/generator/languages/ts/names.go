@@ -0,0 +1,93 @@
+package ts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// reserved are JavaScript and TypeScript reserved words plus the names the
+// generated module declares itself.
+var reserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true, "do": true,
+	"else": true, "enum": true, "export": true, "extends": true, "false": true,
+	"finally": true, "for": true, "function": true, "if": true, "import": true,
+	"in": true, "instanceof": true, "new": true, "null": true, "return": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true,
+	"with": true, "yield": true, "let": true, "static": true, "implements": true,
+	"interface": true, "package": true, "private": true, "protected": true,
+	"public": true, "await": true, "arguments": true, "eval": true,
+	"undefined": true, "NaN": true, "Infinity": true, "type": true, "any": true,
+	"host": true, "init": true, "skills": true, "states": true,
+}
+
+// tsLocal turns a DSL identifier into a TypeScript identifier for locals,
+// module variables and parameters.
+func tsLocal(name string) string {
+	name = strings.ReplaceAll(name, "-", "_")
+	if reserved[name] || strings.HasPrefix(name, "dsl") {
+		return name + "_"
+	}
+	return name
+}
+
+// propertyName renders an object key, quoting it when it is not an
+// identifier.
+func propertyName(name string) string {
+	if isIdentifier(name) {
+		return name
+	}
+	return quote(name)
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || i > 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// quote renders s as a JavaScript string literal.
+func quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if unicode.IsPrint(r) {
+				sb.WriteRune(r)
+			} else {
+				sb.WriteString(fmt.Sprintf(`\u{%x}`, r))
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
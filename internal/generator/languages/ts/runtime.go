@@ -0,0 +1,106 @@
+package ts
+
+// runtimeSource is written next to the generated modules as runtime.ts. It
+// holds the few helpers where DSL semantics differ from JavaScript: only
+// nil and false are falsy, and/or return the deciding operand, and tables
+// iterate with 1-based positional keys.
+const runtimeSource = `// Code generated by skconf. DO NOT EDIT.
+
+/** A function value created by DSL code. */
+export type Func = (...args: any[]) => any;
+
+/** truthy reports whether v counts as true in the DSL: everything but nil and false. */
+export function truthy(v: unknown): boolean {
+  return v !== undefined && v !== null && v !== false;
+}
+
+/** and returns a when it is falsy and b() otherwise. */
+export function and(a: any, b: () => any): any {
+  return truthy(a) ? b() : a;
+}
+
+/** or returns a when it is truthy and b() otherwise. */
+export function or(a: any, b: () => any): any {
+  return truthy(a) ? a : b();
+}
+
+/**
+ * table builds a table that mixes positional values and keys. Positional
+ * values are stored under the keys 1..n and win over explicit keys.
+ */
+export function table(array: any[], ...pairs: any[]): { [key: string]: any } {
+  const t: { [key: string]: any } = {};
+  for (let i = 0; i + 1 < pairs.length; i += 2) {
+    t[String(pairs[i])] = pairs[i + 1];
+  }
+  array.forEach((v, i) => {
+    t[i + 1] = v;
+  });
+  return t;
+}
+
+/** mod implements %; as in Lua the result takes the sign of the divisor. */
+export function mod(a: number, b: number): number {
+  const m = a % b;
+  return m !== 0 && (m < 0) !== (b < 0) ? m + b : m;
+}
+
+/**
+ * tostring formats v as Lua's tostring does, except that JavaScript does
+ * not tell 3.0 from 3.
+ */
+export function tostring(v: unknown): string {
+  if (v === undefined || v === null) {
+    return "nil";
+  }
+  if (typeof v === "number") {
+    return Number.isNaN(v) ? "nan" : v === Infinity ? "inf" : v === -Infinity ? "-inf" : String(v);
+  }
+  if (typeof v === "object" || typeof v === "function") {
+    return typeof v === "object" ? "table" : "function";
+  }
+  return String(v);
+}
+
+/** concat joins the parts of an interpolated string. */
+export function concat(...parts: unknown[]): string {
+  return parts.map(tostring).join("");
+}
+
+/** index reads t[key]. Arrays are indexed from 1. */
+export function index(t: any, key: any): any {
+  if (Array.isArray(t) && typeof key === "number") {
+    return t[key - 1];
+  }
+  return t[key];
+}
+
+/** setIndex assigns t[key] = value. Arrays are indexed from 1. */
+export function setIndex(t: any, key: any, value: any): void {
+  if (Array.isArray(t) && typeof key === "number") {
+    t[key - 1] = value;
+    return;
+  }
+  t[key] = value;
+}
+
+/** updateIndex implements t[key] op= operand, evaluating t and key once. */
+export function updateIndex(t: any, key: any, op: "+" | "-" | "*" | "/", operand: any): void {
+  const v = index(t, key);
+  setIndex(t, key, op === "+" ? v + operand : op === "-" ? v - operand : op === "*" ? v * operand : v / operand);
+}
+
+/** pairs returns the entries of a table. Arrays have the keys 1..n. */
+export function pairs(v: any): [any, any][] {
+  if (v === undefined || v === null) {
+    return [];
+  }
+  if (Array.isArray(v)) {
+    return v.map((e, i): [any, any] => [i + 1, e]);
+  }
+  return Object.entries(v).map(([k, e]): [any, any] => {
+    const n = Number(k);
+    return [Number.isInteger(n) && String(n) === k ? n : k, e];
+  });
+}
+`
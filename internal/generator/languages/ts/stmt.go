@@ -0,0 +1,354 @@
+package ts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+)
+
+func (t *tsGenerator) pushScope() {
+	t.scopes = append(t.scopes, map[string]bool{})
+}
+
+func (t *tsGenerator) popScope() {
+	t.scopes = t.scopes[:len(t.scopes)-1]
+}
+
+func (t *tsGenerator) declare(name string) {
+	t.scopes[len(t.scopes)-1][name] = true
+}
+
+func (t *tsGenerator) isLocal(name string) bool {
+	for i := len(t.scopes) - 1; i >= 0; i-- {
+		if t.scopes[i][name] {
+			return true
+		}
+	}
+	return false
+}
+
+// generateBlockBody writes the statements of body one level deeper, in
+// the scope the caller opened.
+func (t *tsGenerator) generateBlockBody(body *ast.CodeBlock) {
+	t.indent++
+	if body != nil {
+		for _, stmt := range body.Statements {
+			t.generateStatement(stmt)
+		}
+	}
+	t.indent--
+}
+
+// generateFunctionBody writes the statements of a function whose result has
+// the manifest type t.result. A function returning a number, a boolean or a
+// string must end in a return, so one returning the zero value is added.
+func (t *tsGenerator) generateFunctionBody(body *ast.CodeBlock) {
+	t.generateBlockBody(body)
+	zero := tsZero(t.result)
+	if zero == "" || body != nil && generator.BlockTerminates(body) {
+		return
+	}
+	t.indent++
+	t.line("return %s;", zero)
+	t.indent--
+}
+
+func (t *tsGenerator) generateBlock(body *ast.CodeBlock) {
+	t.pushScope()
+	t.generateBlockBody(body)
+	t.popScope()
+}
+
+// budget declares the pass counter of loop if it counts its passes against
+// the loop budget, and returns its name; otherwise it returns "".
+func (t *tsGenerator) budget(loop ast.Statement) string {
+	if !t.unit.Budgeted(loop) {
+		return ""
+	}
+	t.passes++
+	counter := fmt.Sprintf("dslPasses%d", t.passes)
+	t.line("let %s = 0;", counter)
+	return counter
+}
+
+// generateLoopBlock writes the body of loop, which first counts the pass
+// if counter is not empty.
+func (t *tsGenerator) generateLoopBlock(loop ast.Statement, counter string, body *ast.CodeBlock) {
+	if counter != "" {
+		t.indent++
+		t.line("if (++%s > %d) throw new Error(%s);", counter, t.unit.LoopBudget, quote(t.unit.BudgetError(loop)))
+		t.indent--
+	}
+	t.generateBlock(body)
+}
+
+func (t *tsGenerator) generateStatement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VarStatement:
+		t.generateVarStatement(s)
+	case *ast.ExprStmt, *ast.AssignStatement:
+		if simple, ok := t.simpleStatement(s); ok {
+			t.line("%s;", simple)
+		}
+	case *ast.IfStatement:
+		t.generateIfStatement(s)
+	case *ast.MatchStatement:
+		t.generateMatchStatement(s)
+	case *ast.ForStatement:
+		t.generateForStatement(s)
+	case *ast.WhileStatement:
+		counter := t.budget(s)
+		t.line("while (%s) {", t.condition(s.Condition))
+		t.generateLoopBlock(s, counter, s.Body)
+		t.line("}")
+	case *ast.RepeatStatement:
+		counter := t.budget(s)
+		t.line("do {")
+		t.generateLoopBlock(s, counter, s.Body)
+		t.line("} while (%s);", generator.Not(t.condition(s.Condition)))
+	case *ast.ReturnStatement:
+		t.generateReturnStatement(s)
+	case *ast.BreakStatement:
+		t.line("break;")
+	case *ast.ContinueStatement:
+		t.line("continue;")
+	case *ast.FunctionDef: // unnamed; named ones are module functions
+		t.line("void (%s);", t.expression(s))
+	default:
+		t.errorf(stmt, "ts: unsupported statement %T", stmt)
+	}
+}
+
+func (t *tsGenerator) generateVarStatement(stmt *ast.VarStatement) {
+	name := tsLocal(stmt.Name.Value)
+	global := t.inInit && len(t.scopes) == 1 && t.globals[stmt.Name.Value]
+	if stmt.Recursive() && !global && !t.scopes[len(t.scopes)-1][stmt.Name.Value] {
+		t.declare(stmt.Name.Value) // the function refers to itself
+		t.line("let %s: any;", name)
+	}
+	value := t.expression(stmt.Value)
+
+	if global {
+		t.line("%s = %s;", name, value)
+		return
+	}
+	if t.scopes[len(t.scopes)-1][stmt.Name.Value] {
+		t.line("%s = %s;", name, value)
+		return
+	}
+
+	t.declare(stmt.Name.Value)
+	t.line("let %s: any = %s;", name, value)
+}
+
+// simpleStatement renders an expression statement or an assignment as one
+// JavaScript expression, so it also fits the update clause of a for loop.
+func (t *tsGenerator) simpleStatement(stmt ast.Statement) (string, bool) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		switch e := s.Expression.(type) {
+		case *ast.FunctionCall:
+			return t.expression(e), true
+		case *ast.FunctionDef:
+			return "void (" + t.expression(e) + ")", true
+		}
+		return "void " + t.expression(s.Expression), true
+	case *ast.AssignStatement:
+		return t.assignStatement(s)
+	}
+	t.errorf(stmt, "ts: unsupported statement %T", stmt)
+	return "", false
+}
+
+// assignStatement renders an assignment. Names and members use the native
+// operators; elements go through dsl.setIndex and dsl.updateIndex, which
+// know that arrays are indexed from 1.
+func (t *tsGenerator) assignStatement(stmt *ast.AssignStatement) (string, bool) {
+	op, operand := stmt.Update()
+
+	switch e := stmt.Target.(type) {
+	case *ast.Identifier:
+		if !t.isLocal(e.Value) && !t.globals[e.Value] {
+			t.errorf(e, "ts: cannot assign to undeclared name %s", e.Value)
+			return "", false
+		}
+	case *ast.DotExpression:
+	case *ast.IndexExpression:
+		left, index := t.expression(e.Left), t.expression(e.Index)
+		if op == "" {
+			return "dsl.setIndex(" + left + ", " + index + ", " + t.expression(stmt.Value) + ")", true
+		}
+		return "dsl.updateIndex(" + left + ", " + index + ", " + quote(op) + ", " + t.expression(operand) + ")", true
+	default:
+		t.errorf(stmt.Target, "ts: cannot assign to %s", stmt.Target)
+		return "", false
+	}
+
+	target := t.expression(stmt.Target)
+	if stmt.Value == nil {
+		return target + stmt.Operator, true
+	}
+	return target + " " + stmt.Operator + " " + t.expression(stmt.Value), true
+}
+
+func (t *tsGenerator) generateIfStatement(stmt *ast.IfStatement) {
+	t.line("if (%s) {", t.condition(stmt.Condition))
+	t.generateBlock(stmt.Consequence)
+	for _, alt := range stmt.Alternatives {
+		if alt.Condition != nil {
+			t.line("} else if (%s) {", t.condition(alt.Condition))
+		} else {
+			t.line("} else {")
+		}
+		t.generateBlock(alt.Consequence)
+	}
+	t.line("}")
+}
+
+// generateMatchStatement writes a match as a switch over the subject. Since
+// break in a switch leaves the switch, a match with an arm breaking out of
+// the loop around it is written as an if chain instead.
+func (t *tsGenerator) generateMatchStatement(stmt *ast.MatchStatement) {
+	if generator.MatchBreaks(stmt) {
+		t.line("{")
+		t.indent++
+		if len(stmt.Arms) > 0 && stmt.Arms[0].Patterns == nil {
+			t.line("void (%s);", t.expression(stmt.Subject))
+			t.indent--
+			t.generateBlock(stmt.Arms[0].Body)
+			t.line("}")
+			return
+		}
+		t.line("const dslMatch = %s;", t.expression(stmt.Subject))
+		for i, arm := range stmt.Arms {
+			switch {
+			case arm.Patterns == nil:
+				t.line("} else {")
+			default:
+				conds := make([]string, len(arm.Patterns))
+				for j, pattern := range arm.Patterns {
+					conds[j] = "dslMatch === " + t.expression(pattern)
+				}
+				if i == 0 {
+					t.line("if (%s) {", strings.Join(conds, " || "))
+				} else {
+					t.line("} else if (%s) {", strings.Join(conds, " || "))
+				}
+			}
+			t.generateBlock(arm.Body)
+		}
+		t.line("}")
+		t.indent--
+		t.line("}")
+		return
+	}
+
+	t.line("switch (%s) {", t.expression(stmt.Subject))
+	t.indent++
+	for _, arm := range stmt.Arms {
+		labels := []string{"default:"}
+		if arm.Patterns != nil {
+			labels = labels[:0]
+			for _, pattern := range arm.Patterns {
+				labels = append(labels, "case "+t.expression(pattern)+":")
+			}
+		}
+		for _, label := range labels[:len(labels)-1] {
+			t.line("%s", label)
+		}
+		t.line("%s {", labels[len(labels)-1])
+		t.pushScope()
+		t.generateBlockBody(arm.Body)
+		if !generator.LeavesCase(arm.Body) {
+			t.indent++
+			t.line("break;")
+			t.indent--
+		}
+		t.popScope()
+		t.line("}")
+	}
+	t.indent--
+	t.line("}")
+}
+
+func (t *tsGenerator) generateForStatement(stmt *ast.ForStatement) {
+	if stmt.IsRangeForm {
+		rangeValue := t.expression(stmt.RangeValue)
+		t.pushScope()
+		key := ""
+		if stmt.Key != nil {
+			key = tsLocal(stmt.Key.Value)
+			t.declare(stmt.Key.Value)
+		}
+		t.declare(stmt.Value.Value)
+		t.line("for (let [%s, %s] of dsl.pairs(%s)) {", key, tsLocal(stmt.Value.Value), rangeValue)
+		t.generateBlock(stmt.Body)
+		t.popScope()
+		t.line("}")
+		return
+	}
+
+	if stmt.Init == nil && stmt.Post == nil {
+		cond := "true"
+		if stmt.Condition != nil {
+			cond = t.condition(stmt.Condition)
+		}
+		counter := t.budget(stmt)
+		t.line("while (%s) {", cond)
+		t.generateLoopBlock(stmt, counter, stmt.Body)
+		t.line("}")
+		return
+	}
+
+	// A var initializer becomes the loop's own let; anything else runs in
+	// a block before the loop, as does a var a function in the body
+	// captures, which all iterations share.
+	init, isVar := stmt.Init.(*ast.VarStatement)
+	isVar = isVar && !stmt.Captured()
+	if !isVar && stmt.Init != nil {
+		t.line("{")
+		t.indent++
+	}
+	t.pushScope()
+	header := ""
+	if isVar {
+		value := t.expression(init.Value)
+		t.declare(init.Name.Value)
+		header = "let " + tsLocal(init.Name.Value) + ": any = " + value
+	} else if stmt.Init != nil {
+		t.generateStatement(stmt.Init)
+	}
+	cond, post := "", ""
+	if stmt.Condition != nil {
+		cond = t.condition(stmt.Condition)
+	}
+	if stmt.Post != nil {
+		post, _ = t.simpleStatement(stmt.Post)
+	}
+	counter := t.budget(stmt)
+	t.line("for (%s; %s; %s) {", header, cond, post)
+	t.generateLoopBlock(stmt, counter, stmt.Body)
+	t.line("}")
+	t.popScope()
+	if !isVar && stmt.Init != nil {
+		t.indent--
+		t.line("}")
+	}
+}
+
+func (t *tsGenerator) generateReturnStatement(stmt *ast.ReturnStatement) {
+	if t.inInit && stmt.ReturnValue != nil {
+		t.errorf(stmt, "ts: top-level return cannot have a value")
+	}
+	if t.inInit || stmt.ReturnValue == nil && tsZero(t.result) == "" {
+		t.line("return;")
+		return
+	}
+	if stmt.ReturnValue == nil {
+		t.line("return %s;", tsZero(t.result))
+		return
+	}
+	t.line("return %s;", t.expression(stmt.ReturnValue))
+}
@@ -0,0 +1,243 @@
+package ts
+
+import (
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/generator"
+	"github.com/hsoul/skconf/internal/manifest"
+)
+
+// generateTypes writes <unit>.types.d.ts: the host interfaces and the
+// shapes of the skills and states, see generateShapes.
+func (t *tsGenerator) generateTypes(program *ast.Program, skills, states []*definition) {
+	t.line("// Code generated by skconf from %s. DO NOT EDIT.", t.unit.File)
+	t.line("")
+	t.line("/** A table: positional tables are arrays, other tables objects. */")
+	t.line("export type Table = unknown[] | { [key: string]: unknown };")
+	t.line("")
+	t.line("/** A hook property, called with the host followed by the DSL arguments. */")
+	t.line("export type Hook = (host: Host, ...args: any[]) => any;")
+	t.line("")
+
+	modules := generator.HostAPI(program, t.manifest)
+	t.line("/** Gives generated code access to the game. */")
+	t.line("export interface Host {")
+	t.indent++
+	for _, mod := range modules {
+		t.line("readonly %s: %s;", mod.Name, moduleType(mod.Name))
+	}
+	t.line("/** Returns a name the DSL uses without declaring it, such as EM or print. */")
+	t.line("global(name: string): any;")
+	t.indent--
+	t.line("}")
+
+	for _, mod := range modules {
+		t.line("")
+		t.line("export interface %s {", moduleType(mod.Name))
+		t.indent++
+		for _, fn := range mod.Functions {
+			t.line("%s%s;", fn.Name, tsSignature(fn.Signature))
+		}
+		t.indent--
+		t.line("}")
+	}
+
+	t.line("")
+	t.generateShapes("Skill", "skills", skills)
+	t.line("")
+	t.generateShapes("State", "states", states)
+}
+
+// generateShapes declares an interface per definition with the types of
+// its properties, extending the interface typ of the properties that every
+// definition of the kind has, typed with the union of their types.
+func (t *tsGenerator) generateShapes(typ, kind string, defs []*definition) {
+	var keys []string
+	types := map[string][]string{}
+	count := map[string]int{}
+	shapes := make([][][2]string, len(defs))
+	for i, def := range defs {
+		seen := map[string]bool{}
+		for _, prop := range def.properties {
+			key, ok := prop.Key.(*ast.Identifier)
+			if !ok || seen[key.Value] {
+				continue
+			}
+			seen[key.Value] = true
+			if count[key.Value] == 0 {
+				keys = append(keys, key.Value)
+			}
+			count[key.Value]++
+
+			vt := "Hook"
+			if _, ok := prop.Value.(*ast.FunctionDef); !ok {
+				vt = valueType(prop.Value)
+			}
+			types[key.Value] = addType(types[key.Value], vt)
+			shapes[i] = append(shapes[i], [2]string{key.Value, vt})
+		}
+	}
+
+	t.line("/** Properties every one of the %s in %s has. */", kind, t.unit.File)
+	t.line("export interface %s {", typ)
+	t.indent++
+	for _, key := range keys {
+		if count[key] == len(defs) {
+			t.line("%s: %s;", propertyName(key), union(types[key]))
+		}
+	}
+	t.indent--
+	t.line("}")
+
+	for i, def := range defs {
+		t.line("")
+		t.line("/** Properties of %s %s. */", def.kind, def.name)
+		t.line("export interface %s extends %s {", def.shape, typ)
+		t.indent++
+		for _, prop := range shapes[i] {
+			t.line("%s: %s;", propertyName(prop[0]), prop[1])
+		}
+		t.indent--
+		t.line("}")
+	}
+}
+
+// valueType infers the TypeScript type of a property value. It mirrors
+// table: positional tables are arrays and name-keyed tables objects.
+func valueType(exp ast.Expression) string {
+	switch e := exp.(type) {
+	case *ast.Integer, *ast.Float:
+		return "number"
+	case *ast.String, *ast.InterpolatedString:
+		return "string"
+	case *ast.Boolean:
+		return "boolean"
+	case *ast.FunctionDef:
+		return "(...args: any[]) => any"
+	case *ast.PrefixExpression:
+		switch e.Operator {
+		case "not":
+			return "boolean"
+		case "~":
+			return "number"
+		}
+	case *ast.InfixExpression:
+		switch e.Operator {
+		case "==", "!=", "<", ">", "<=", ">=":
+			return "boolean"
+		case "%", "^", "//", "&", "|", "~", "<<", ">>":
+			return "number"
+		}
+	case *ast.ConditionalExpression:
+		return union(addType([]string{valueType(e.Consequence)}, valueType(e.Alternative)))
+	case *ast.TableDef:
+		return tableType(e)
+	}
+	return "unknown"
+}
+
+func tableType(table *ast.TableDef) string {
+	var elems, fields []string
+	for _, prop := range table.Properties {
+		switch key := prop.Key.(type) {
+		case nil:
+			elems = addType(elems, valueType(prop.Value))
+		case *ast.Identifier:
+			fields = append(fields, propertyName(key.Value)+": "+valueType(prop.Value))
+		default:
+			return "Table"
+		}
+	}
+
+	switch {
+	case len(elems) > 0 && len(fields) == 0:
+		elem := union(elems)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case len(elems) == 0 && len(fields) > 0:
+		return "{ " + strings.Join(fields, "; ") + " }"
+	}
+	return "Table"
+}
+
+// union joins types; unknown absorbs every other type.
+func union(types []string) string {
+	for _, t := range types {
+		if t == "unknown" {
+			return t
+		}
+	}
+	return strings.Join(types, " | ")
+}
+
+func addType(types []string, typ string) []string {
+	for _, t := range types {
+		if t == typ {
+			return types
+		}
+	}
+	return append(types, typ)
+}
+
+func moduleType(name string) string {
+	return generator.PascalCase(name) + "Module"
+}
+
+func tsSignature(sig *manifest.Signature) string {
+	if sig == nil {
+		return "(...args: any[]): any"
+	}
+	params := make([]string, len(sig.Params))
+	for i, param := range sig.Params {
+		if sig.Variadic && i == len(sig.Params)-1 {
+			params[i] = "..." + tsLocal(param.Name) + ": " + tsType(param.Type) + "[]"
+			continue
+		}
+		params[i] = tsLocal(param.Name) + ": " + tsType(param.Type)
+	}
+	return "(" + strings.Join(params, ", ") + "): " + tsType(sig.Returns)
+}
+
+// tsResult renders the result type of a function returning the manifest
+// type typ. A function returning a table may end without a value.
+func tsResult(typ string) string {
+	if t := tsType(typ); t != "Table" {
+		return t
+	}
+	return "Table | undefined"
+}
+
+// tsZero renders the value a function returning the manifest type typ
+// returns when it ends without a value, or "" if it can return nothing.
+func tsZero(typ string) string {
+	switch tsType(typ) {
+	case "number":
+		return "0"
+	case "boolean":
+		return "false"
+	case "string":
+		return `""`
+	}
+	return ""
+}
+
+// tsType maps a manifest type to a TypeScript type. Host types such as
+// Unit are opaque to the generated code and map to any; quantities are
+// numbers whatever their base unit.
+func tsType(typ string) string {
+	switch typ {
+	case "int", "float", "duration", "distance", "percent":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "string":
+		return "string"
+	case "table":
+		return "Table"
+	default:
+		return "any"
+	}
+}
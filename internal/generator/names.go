@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/constant"
+)
+
+// PascalCase turns snake_case or kebab-case DSL names into the exported
+// type and member names of typed backends: ack_s becomes AckS.
+func PascalCase(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' || r == '-' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	out := sb.String()
+	if out == "" || !unicode.IsLetter([]rune(out)[0]) {
+		out = "D" + out
+	}
+	return out
+}
+
+// Terminates reports whether stmt always ends in a return. Backends for
+// languages that require a final return use it to decide whether to add one.
+func Terminates(stmt ast.Statement) bool {
+	switch s := stmt.(type) {
+	case *ast.ReturnStatement:
+		return true
+	case *ast.IfStatement:
+		if !BlockTerminates(s.Consequence) {
+			return false
+		}
+		for _, alt := range s.Alternatives {
+			if !BlockTerminates(alt.Consequence) {
+				return false
+			}
+		}
+		n := len(s.Alternatives)
+		return n > 0 && s.Alternatives[n-1].Condition == nil
+	case *ast.MatchStatement:
+		if s.Default() == nil {
+			return false
+		}
+		for _, arm := range s.Arms {
+			if !BlockTerminates(arm.Body) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// MatchBreaks reports whether an arm of s breaks out of a loop around s.
+// In languages where break inside a switch leaves the switch, backends
+// compile such a match to an if chain instead.
+func MatchBreaks(s *ast.MatchStatement) bool {
+	breaks := false
+	for _, arm := range s.Arms {
+		ast.Inspect(arm.Body, func(n ast.Node) bool {
+			switch n.(type) {
+			case *ast.BreakStatement:
+				breaks = true
+			case *ast.ForStatement, *ast.WhileStatement, *ast.RepeatStatement, *ast.FunctionDef:
+				return false // a break in there leaves that loop
+			}
+			return !breaks
+		})
+	}
+	return breaks
+}
+
+// Not negates cond, a boolean expression of Go, C# or TypeScript, adding
+// parentheses unless cond is already enclosed in a pair.
+func Not(cond string) string {
+	if enclosed(cond) {
+		return "!" + cond
+	}
+	return "!(" + cond + ")"
+}
+
+// enclosed reports whether the first parenthesis of exp closes at its end.
+func enclosed(exp string) bool {
+	if !strings.HasPrefix(exp, "(") {
+		return false
+	}
+	depth := 0
+	var quote byte
+	for i := 0; i < len(exp); i++ {
+		switch ch := exp[i]; {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+			if depth == 0 {
+				return i == len(exp)-1
+			}
+		}
+	}
+	return false
+}
+
+// CaseLabel returns a pattern as the case of a switch over a key-normalized
+// subject compares it: the runtimes turn an integral float into an integer,
+// as Lua does for table keys, so the pattern 2.0 becomes 2.
+func CaseLabel(pattern ast.Expression) ast.Expression {
+	if f, ok := pattern.(*ast.Float); ok && f.Value == math.Trunc(f.Value) && math.Abs(f.Value) < 1<<63 {
+		return constant.Literal(int64(f.Value), f.Pos())
+	}
+	return pattern
+}
+
+// LeavesCase reports whether block ends in a statement that leaves the
+// case of a switch, so that no break is needed after it.
+func LeavesCase(block *ast.CodeBlock) bool {
+	if n := len(block.Statements); n > 0 {
+		if _, ok := block.Statements[n-1].(*ast.ContinueStatement); ok {
+			return true
+		}
+	}
+	return BlockTerminates(block)
+}
+
+func BlockTerminates(block *ast.CodeBlock) bool {
+	if block == nil || len(block.Statements) == 0 {
+		return false
+	}
+	return Terminates(block.Statements[len(block.Statements)-1])
+}
@@ -1,23 +1,41 @@
 package lexer
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
 
+// Position is a place in the source; a token is at its first character.
+// Line and Column start at 1. Column counts characters (runes), so Chinese
+// text does not throw it off. UTF16Column is the LSP "character" of the
+// position: it counts UTF-16 code units and starts at 0.
 type Position struct {
-	Line   int // 当前行号
-	Column int // 当前列号
+	Line        int // 当前行号，从 1 开始
+	Column      int // 当前列号（按字符计），从 1 开始
+	UTF16Column int // 当前列号（按 UTF-16 码元计），从 0 开始
 }
 
 type Lexer struct {
 	input        string
-	position     int      // 当前字符的位置
-	readPosition int      // 当前读取位置（在当前字符之后）
-	ch           byte     // 当前正在查看的字符
-	pos          Position // 当前解析位置
+	position     int       // 当前字符的位置
+	readPosition int       // 当前读取位置（在当前字符之后）
+	ch           rune      // 当前正在查看的字符
+	pos          Position  // 当前解析位置
+	prev         TokenType // 上一个记号的类型
+	templates    []int     // 每个未闭合的 ${ 内的 { 嵌套深度 / brace depth inside every open ${
+	trace        io.Writer
 }
 
+// New returns a lexer for UTF-8 input. A leading byte order mark is
+// skipped and CRLF line endings read as LF.
 func New(input string) *Lexer {
 	l := &Lexer{
-		input: input,
+		input: strings.TrimPrefix(input, "\uFEFF"),
 		pos: Position{
 			Line:   1,
 			Column: 0,
@@ -27,35 +45,76 @@ func New(input string) *Lexer {
 	return l
 }
 
+// SetTrace makes the lexer write every token it produces to w. A nil writer
+// disables tracing.
+func (l *Lexer) SetTrace(w io.Writer) {
+	l.trace = w
+}
+
+func (l *Lexer) Trace() io.Writer {
+	return l.trace
+}
+
+func (l *Lexer) traceToken(tok Token) {
+	if l.trace != nil {
+		fmt.Fprintln(l.trace, tok)
+	}
+}
+
 func (l *Lexer) readChar() {
+	prev := l.ch
+	width := 1
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
 	} else {
-		l.ch = l.input[l.readPosition]
+		l.ch, width = utf8.DecodeRuneInString(l.input[l.readPosition:])
+		if l.ch == '\r' && strings.HasPrefix(l.input[l.readPosition+1:], "\n") {
+			l.ch, width = '\n', 2 // CRLF 视为一个换行
+		}
 	}
 
 	l.position = l.readPosition
-	l.readPosition++
+	l.readPosition += width
 
 	if l.ch == '\n' {
 		l.pos.Line++
 		l.pos.Column = 0
+		l.pos.UTF16Column = 0
+	} else if l.pos.Column == 0 {
+		l.pos.Column, l.pos.UTF16Column = 1, 0
 	} else {
 		l.pos.Column++
+		l.pos.UTF16Column += utf16.RuneLen(prev) // the character starts after the units of the one before
 	}
 }
 
-func (l *Lexer) peekChar() byte {
+func (l *Lexer) peekChar() rune {
 	if l.readPosition >= len(l.input) {
 		return 0
 	}
-	return l.input[l.readPosition]
+	r, _ := utf8.DecodeRuneInString(l.input[l.readPosition:])
+	return r
+}
+
+// invalidUTF8 reports whether the current character is a byte that is not
+// valid UTF-8.
+func (l *Lexer) invalidUTF8() bool {
+	return l.ch == utf8.RuneError && l.readPosition-l.position == 1
 }
 
 func (l *Lexer) NextToken() Token {
+	tok := l.nextToken()
+	l.prev = tok.Type
+	return tok
+}
+
+func (l *Lexer) nextToken() Token {
 	var tok Token
 
+	start := l.position
 	l.skipWhitespace()
+	adjacent := l.position == start
+	pos := l.pos // tokens are at their first character
 
 	switch l.ch {
 	case '=':
@@ -63,23 +122,81 @@ func (l *Lexer) NextToken() Token {
 			ch := l.ch
 			l.readChar()
 			tok = Token{Type: EQ, Literal: string(ch) + string(l.ch)}
+		} else if l.peekChar() == '>' {
+			l.readChar()
+			tok = Token{Type: FATARROW, Literal: "=>"}
 		} else {
 			tok = Token{Type: ASSIGN, Literal: string(l.ch)}
 		}
 	case '+':
-		tok = Token{Type: PLUS, Literal: string(l.ch)}
+		switch l.peekChar() {
+		case '+':
+			l.readChar()
+			tok = Token{Type: INCREMENT, Literal: "++"}
+		case '=':
+			l.readChar()
+			tok = Token{Type: PLUSASSIGN, Literal: "+="}
+		default:
+			tok = Token{Type: PLUS, Literal: string(l.ch)}
+		}
 	case '-':
-		if l.peekChar() == '-' { // 注释
-			l.readChar() // 跳过第二个'-'
-			return l.readComment()
+		switch l.peekChar() {
+		case '-':
+			if adjacent && l.isDecrement() {
+				l.readChar()
+				tok = Token{Type: DECREMENT, Literal: "--"}
+				break
+			}
+			l.readChar() // 注释，跳过第二个'-'
+			tok = l.readComment()
+			tok.Pos = pos
+			return tok
+		case '=':
+			l.readChar()
+			tok = Token{Type: MINUSASSIGN, Literal: "-="}
+		case '>':
+			l.readChar()
+			tok = Token{Type: ARROW, Literal: "->"}
+		default:
+			tok = Token{Type: MINUS, Literal: string(l.ch)}
 		}
-		tok = Token{Type: MINUS, Literal: string(l.ch)}
 	case '*':
-		tok = Token{Type: MULTIPLY, Literal: string(l.ch)}
+		switch l.peekChar() {
+		case '*':
+			l.readChar()
+			tok = Token{Type: POWER, Literal: "**"}
+		case '=':
+			l.readChar()
+			tok = Token{Type: MULTIPLYASSIGN, Literal: "*="}
+		default:
+			tok = Token{Type: MULTIPLY, Literal: string(l.ch)}
+		}
 	case '/':
-		tok = Token{Type: DIVIDE, Literal: string(l.ch)}
+		switch l.peekChar() {
+		case '/':
+			l.readChar()
+			tok = Token{Type: INTDIV, Literal: "//"}
+		case '=':
+			l.readChar()
+			tok = Token{Type: DIVIDEASSIGN, Literal: "/="}
+		default:
+			tok = Token{Type: DIVIDE, Literal: string(l.ch)}
+		}
+	case '%':
+		tok = Token{Type: MODULO, Literal: string(l.ch)}
+	case '^':
+		tok = Token{Type: POWER, Literal: string(l.ch)}
+	case '&':
+		tok = Token{Type: BITAND, Literal: string(l.ch)}
+	case '|':
+		tok = Token{Type: BITOR, Literal: string(l.ch)}
+	case '~':
+		tok = Token{Type: BITXOR, Literal: string(l.ch)}
 	case '<':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '<' {
+			l.readChar()
+			tok = Token{Type: SHL, Literal: "<<"}
+		} else if l.peekChar() == '=' {
 			ch := l.ch
 			l.readChar()
 			tok = Token{Type: LTE, Literal: string(ch) + string(l.ch)}
@@ -87,7 +204,10 @@ func (l *Lexer) NextToken() Token {
 			tok = Token{Type: LT, Literal: string(l.ch)}
 		}
 	case '>':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '>' {
+			l.readChar()
+			tok = Token{Type: SHR, Literal: ">>"}
+		} else if l.peekChar() == '=' {
 			ch := l.ch
 			l.readChar()
 			tok = Token{Type: GTE, Literal: string(ch) + string(l.ch)}
@@ -100,13 +220,24 @@ func (l *Lexer) NextToken() Token {
 			l.readChar()
 			tok = Token{Type: NEQ, Literal: string(ch) + string(l.ch)}
 		} else {
-			tok = Token{Type: ILLEGAL, Literal: string(l.ch)}
+			tok = Token{Type: ILLEGAL, Literal: unexpected(l.ch)}
 		}
 	case '"':
-		tok.Type = STRING
-		tok.Literal = l.readString()
+		tok = l.readString(false)
 	case '.':
+		if isDigit(l.peekChar()) {
+			tok = l.readNumber()
+			l.traceToken(tok)
+			return tok
+		}
 		tok = Token{Type: DOT, Literal: string(l.ch)}
+	case '?':
+		if l.peekChar() == '.' {
+			l.readChar()
+			tok = Token{Type: OPTDOT, Literal: "?."}
+		} else {
+			tok = Token{Type: QUESTION, Literal: string(l.ch)}
+		}
 	case ',':
 		tok = Token{Type: COMMA, Literal: string(l.ch)}
 	case '(':
@@ -114,8 +245,20 @@ func (l *Lexer) NextToken() Token {
 	case ')':
 		tok = Token{Type: RPAREN, Literal: string(l.ch)}
 	case '{':
+		if n := len(l.templates); n > 0 {
+			l.templates[n-1]++
+		}
 		tok = Token{Type: LBRACE, Literal: string(l.ch)}
 	case '}':
+		n := len(l.templates)
+		if n > 0 && l.templates[n-1] == 0 { // closes ${, the string goes on
+			l.templates = l.templates[:n-1]
+			tok = l.readString(true)
+			break
+		}
+		if n > 0 {
+			l.templates[n-1]--
+		}
 		tok = Token{Type: RBRACE, Literal: string(l.ch)}
 	case '[':
 		tok = Token{Type: LBRACKET, Literal: string(l.ch)}
@@ -123,102 +266,393 @@ func (l *Lexer) NextToken() Token {
 		tok = Token{Type: RBRACKET, Literal: string(l.ch)}
 	case ';':
 		tok = Token{Type: SEMICOLON, Literal: string(l.ch)}
+	case ':':
+		tok = Token{Type: COLON, Literal: string(l.ch)}
+	case '_':
+		tok = Token{Type: UNDERSCORE, Literal: string(l.ch)}
 	case 0:
 		tok = Token{Type: EOF, Literal: ""}
 	default:
-		if isLetter(l.ch) {
+		if l.invalidUTF8() {
+			tok = Token{Type: ILLEGAL, Literal: "invalid UTF-8 encoding"}
+		} else if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Type = LookupIdent(tok.Literal)
-			tok.Pos = l.pos
-			fmt.Println(tok)
+			tok.Pos = pos
+			l.traceToken(tok)
 			return tok
 		} else if isDigit(l.ch) {
 			tok = l.readNumber()
-			fmt.Println(tok)
+			l.traceToken(tok)
 			return tok
 		} else {
-			tok = Token{Type: ILLEGAL, Literal: string(l.ch)}
+			tok = Token{Type: ILLEGAL, Literal: unexpected(l.ch)}
 		}
 	}
 
 	l.readChar()
-	tok.Pos = l.pos
-	fmt.Println(tok)
+	tok.Pos = pos
+	l.traceToken(tok)
 	return tok
 }
 
-func (l *Lexer) readString() string {
-	position := l.position + 1
+// isDecrement reports whether the "--" at the current position is the
+// decrement of the operand just read, as in i-- or t[k]--, rather than the
+// start of a comment. It has to touch a name or "]" and end the statement:
+// only blanks, then the end of the line, ";", "}" or a comment may follow,
+// so x-- note is x followed by a comment.
+func (l *Lexer) isDecrement() bool {
+	if l.prev != IDENTIFIER && l.prev != RBRACKET {
+		return false
+	}
+	rest := strings.TrimLeft(l.input[l.readPosition+1:], " \t")
+	if rest == "" || strings.HasPrefix(rest, "--") {
+		return true
+	}
+	switch rest[0] {
+	case '\r', '\n', ';', '}':
+		return true
+	}
+	return false
+}
+
+// readString reads a string literal from its opening quote or, with cont
+// set, the rest of one from the } that ends an interpolation. The token
+// holds the unescaped text up to the closing quote (STRING, or STRINGTAIL
+// after an interpolation) or up to the next ${ (STRINGHEAD, or STRINGMID
+// after an interpolation). A bad escape or a missing closing quote gives
+// an ILLEGAL token whose literal describes the problem.
+func (l *Lexer) readString(cont bool) Token {
+	var sb strings.Builder
+	var err string
 	for {
 		l.readChar()
-		if l.ch == '"' || l.ch == 0 {
-			break
+		switch l.ch {
+		case 0:
+			return Token{Type: ILLEGAL, Literal: "unterminated string"}
+		case '"':
+			if err != "" {
+				return Token{Type: ILLEGAL, Literal: err}
+			}
+			if cont {
+				return Token{Type: STRINGTAIL, Literal: sb.String()}
+			}
+			return Token{Type: STRING, Literal: sb.String()}
+		case '\\':
+			if msg := l.readEscape(&sb); msg != "" && err == "" {
+				err = msg
+			}
+		case '$':
+			if l.peekChar() != '{' {
+				sb.WriteRune(l.ch)
+				continue
+			}
+			l.readChar()
+			if err != "" {
+				return Token{Type: ILLEGAL, Literal: err}
+			}
+			l.templates = append(l.templates, 0)
+			if cont {
+				return Token{Type: STRINGMID, Literal: sb.String()}
+			}
+			return Token{Type: STRINGHEAD, Literal: sb.String()}
+		default:
+			if l.invalidUTF8() && err == "" {
+				err = "invalid UTF-8 encoding in string"
+			}
+			sb.WriteRune(l.ch)
 		}
 	}
-	return l.input[position:l.position]
 }
 
+// readEscape reads the escape sequence starting at the current backslash
+// and writes the character it stands for to sb. It returns a description
+// of the problem when the sequence is not one of \n \t \r \" \\ \$ or
+// \u{hex}.
+func (l *Lexer) readEscape(sb *strings.Builder) string {
+	switch l.peekChar() {
+	case 'n':
+		sb.WriteByte('\n')
+	case 't':
+		sb.WriteByte('\t')
+	case 'r':
+		sb.WriteByte('\r')
+	case '"', '\\', '$':
+		sb.WriteRune(l.peekChar())
+	case 'u':
+		l.readChar()
+		if l.peekChar() != '{' {
+			return `\u must be followed by {hex digits}`
+		}
+		l.readChar()
+		start := l.readPosition
+		for l.peekChar() != '}' && l.peekChar() != '"' && l.peekChar() != 0 {
+			l.readChar()
+		}
+		digits := l.input[start:l.readPosition]
+		code, err := strconv.ParseUint(digits, 16, 32)
+		if l.peekChar() != '}' || err != nil || len(digits) > 6 || !utf8.ValidRune(rune(code)) {
+			return fmt.Sprintf("invalid escape \\u{%s}", digits)
+		}
+		sb.WriteRune(rune(code))
+	case 0:
+		return "unterminated string"
+	default:
+		return fmt.Sprintf("invalid escape \\%c", l.peekChar())
+	}
+	l.readChar()
+	return ""
+}
+
+// readNumber reads a numeric literal: decimal digits with an optional
+// fraction and exponent, a fraction alone as in .5, or 0x hex digits. A
+// single '_' may separate two digits. The literal keeps its source
+// spelling; the parser converts it and checks the range.
 func (l *Lexer) readNumber() Token {
-	startPosition := l.position
-	isFloat := false
+	start, startPos := l.position, l.pos
+	typ := INTEGER
 
-	for isDigit(l.ch) {
+	hex := l.ch == '0' && (l.peekChar() == 'x' || l.peekChar() == 'X')
+	if hex {
 		l.readChar()
+		l.readChar() // 跳过 0x
+		if err := l.readDigits(isHexDigit); err != "" {
+			return l.invalidNumber(start, startPos, err)
+		}
+	} else {
+		if l.ch != '.' {
+			if err := l.readDigits(isDigit); err != "" {
+				return l.invalidNumber(start, startPos, err)
+			}
+		}
+		if l.ch == '.' && isDigit(l.peekChar()) {
+			typ = FLOAT
+			l.readChar() // consume the dot
+			if err := l.readDigits(isDigit); err != "" {
+				return l.invalidNumber(start, startPos, err)
+			}
+		}
+		if l.ch == 'e' || l.ch == 'E' {
+			typ = FLOAT
+			l.readChar()
+			if l.ch == '+' || l.ch == '-' {
+				l.readChar()
+			}
+			if err := l.readDigits(isDigit); err != "" {
+				return l.invalidNumber(start, startPos, "exponent "+err)
+			}
+		}
 	}
 
-	if l.ch == '.' && isDigit(l.peekChar()) {
-		isFloat = true
-		l.readChar() // consume the dot
-		for isDigit(l.ch) {
+	// A unit right after a decimal literal makes it a quantity: 1.5s,
+	// 300ms, 20%. The parser checks the unit name.
+	if !hex {
+		if isUnitChar(l.ch) {
+			typ = QUANTITY
+			for isUnitChar(l.ch) {
+				l.readChar()
+			}
+		} else if l.ch == '%' && l.isPercent() {
+			typ = QUANTITY
 			l.readChar()
 		}
 	}
 
-	if isFloat {
-		return Token{
-			Type:    FLOAT,
-			Literal: l.input[startPosition:l.position],
-			Pos:     l.pos,
+	if isIdentChar(l.ch) {
+		return l.invalidNumber(start, startPos, fmt.Sprintf("unexpected %q", l.ch))
+	}
+
+	return Token{
+		Type:    typ,
+		Literal: l.input[start:l.position],
+		Pos:     startPos,
+	}
+}
+
+// isPercent reports whether the '%' at the current position, right after a
+// number, is a percent sign rather than the modulo operator: 20% is a
+// percentage unless an operand follows on the same line, as in 10%3, 10% x
+// or 10%-3. "and" and "or" after it are operators, not operands.
+func (l *Lexer) isPercent() bool {
+	rest := l.input[l.readPosition:]
+	if strings.HasPrefix(rest, "-") {
+		return false
+	}
+	rest = strings.TrimLeft(rest, " \t")
+	ch, _ := utf8.DecodeRuneInString(rest)
+	switch {
+	case ch == '(' || ch == '"' || ch == '_' || isDigit(ch):
+		return false
+	case ch == '.':
+		return len(rest) < 2 || !isDigit(rune(rest[1]))
+	case isLetter(ch):
+		word := rest
+		if i := strings.IndexFunc(rest, func(r rune) bool { return !isIdentChar(r) }); i >= 0 {
+			word = rest[:i]
+		}
+		return word == "and" || word == "or"
+	}
+	return true
+}
+
+// readDigits reads a run of digits in which a single '_' may separate two
+// digits. It returns what is wrong with the run, if anything.
+func (l *Lexer) readDigits(valid func(rune) bool) string {
+	if !valid(l.ch) {
+		return "has no digits"
+	}
+	for valid(l.ch) || l.ch == '_' {
+		if l.ch == '_' && !valid(l.peekChar()) {
+			return "'_' must separate digits"
 		}
+		l.readChar()
 	}
+	return ""
+}
 
+// invalidNumber reports a malformed numeric literal at the character that
+// breaks it, then skips the rest of the literal.
+func (l *Lexer) invalidNumber(start int, startPos Position, reason string) Token {
+	pos := advance(startPos, l.position-start)
+	for isIdentChar(l.ch) || l.ch == '.' && isDigit(l.peekChar()) {
+		l.readChar()
+	}
 	return Token{
-		Type:    INTEGER,
-		Literal: l.input[startPosition:l.position],
-		Pos:     l.pos,
+		Type:    ILLEGAL,
+		Literal: fmt.Sprintf("invalid numeric literal %q: %s", l.input[start:l.position], reason),
+		Pos:     pos,
 	}
 }
 
 func (l *Lexer) readIdentifier() string {
 	position := l.position
-	for isLetter(l.ch) || isDigit(l.ch) || l.ch == '_' || l.ch == '-' {
+	for isIdentChar(l.ch) || l.ch == '-' && isIdentChar(l.peekChar()) {
 		l.readChar()
 	}
 	return l.input[position:l.position]
 }
 
+// readComment reads a comment whose second '-' is the current character.
+// "--[[" opens a block comment closed by "]]" (Lua's "--[==[ ... ]==]"
+// levels work too), and "---" starts a doc comment for the definition that
+// follows.
 func (l *Lexer) readComment() Token {
+	rest := l.input[l.readPosition:]
+	if level := blockLevel(rest); level >= 0 {
+		return l.readBlockComment(level)
+	}
+
+	typ := COMMENT
+	if strings.HasPrefix(rest, "-") && !strings.HasPrefix(rest, "--") {
+		typ = DOCCOMMENT
+		l.readChar()
+	}
+
 	position := l.position + 1
 	for l.ch != '\n' && l.ch != 0 {
 		l.readChar()
 	}
-	return Token{
-		Type:    COMMENT,
-		Literal: l.input[position:l.position],
-		Pos:     l.pos,
+	literal := l.input[position:l.position]
+	if typ == DOCCOMMENT {
+		literal = strings.TrimRight(strings.TrimPrefix(literal, " "), " \t")
+	}
+	return Token{Type: typ, Literal: literal}
+}
+
+// readBlockComment reads a block comment whose opening brackets follow the
+// current character. The literal is the text between the brackets without
+// the newline that directly follows the opening, as in Lua.
+func (l *Lexer) readBlockComment(level int) Token {
+	for i := 0; i < level+2; i++ {
+		l.readChar() // 跳过 [=*[
+	}
+
+	start := l.readPosition
+	closing := "]" + strings.Repeat("=", level) + "]"
+	end := strings.Index(l.input[start:], closing)
+	if end < 0 {
+		for l.ch != 0 {
+			l.readChar()
+		}
+		return Token{Type: ILLEGAL, Literal: "unterminated block comment"}
+	}
+	end += start
+
+	for l.position < end+len(closing) {
+		l.readChar()
+	}
+	text := l.input[start:end]
+	if strings.HasPrefix(text, "\r\n") {
+		text = text[2:]
+	} else {
+		text = strings.TrimPrefix(text, "\n")
+	}
+	return Token{Type: COMMENT, Literal: text}
+}
+
+// blockLevel returns the number of '=' in the "[=*[" that opens a block
+// comment at the start of s, or -1 if s does not open one.
+func blockLevel(s string) int {
+	if !strings.HasPrefix(s, "[") {
+		return -1
+	}
+	level := 1
+	for level < len(s) && s[level] == '=' {
+		level++
 	}
+	if level < len(s) && s[level] == '[' {
+		return level - 1
+	}
+	return -1
 }
 
+// skipWhitespace also skips the ideographic space U+3000, which Chinese
+// input methods type in full-width mode.
 func (l *Lexer) skipWhitespace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' || l.ch == '\u3000' {
 		l.readChar()
 	}
 }
 
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
+// unexpected describes a character that starts no token. Full-width
+// punctuation typed with a Chinese input method gets a hint.
+func unexpected(ch rune) string {
+	if 0xFF01 <= ch && ch <= 0xFF5E {
+		return fmt.Sprintf("unexpected full-width character %q, did you mean %q?", ch, ch-0xFF01+'!')
+	}
+	return fmt.Sprintf("unexpected character %q", ch)
+}
+
+// isLetter reports whether ch can start an identifier: any Unicode letter,
+// so 伤害 is a name like damage.
+func isLetter(ch rune) bool {
+	if ch < utf8.RuneSelf {
+		return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
+	}
+	return unicode.IsLetter(ch)
 }
 
-func isDigit(ch byte) bool {
+func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
+
+// advance moves pos n ASCII characters to the right on the same line.
+func advance(pos Position, n int) Position {
+	pos.Column += n
+	pos.UTF16Column += n
+	return pos
+}
+
+// isUnitChar reports whether ch can be part of the unit of a quantity.
+// Units are ASCII letters.
+func isUnitChar(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
+}
+
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isIdentChar(ch rune) bool {
+	return isLetter(ch) || isDigit(ch) || ch == '_' || ch >= utf8.RuneSelf && (unicode.IsDigit(ch) || unicode.IsMark(ch))
+}
@@ -0,0 +1,116 @@
+package lexer
+
+import (
+	"slices"
+	"testing"
+)
+
+// tokens returns the tokens of input up to EOF, without comments.
+func tokens(input string) []Token {
+	l := New(input)
+	var toks []Token
+	for {
+		tok := l.NextToken()
+		if tok.Type == EOF {
+			return toks
+		}
+		if tok.Type != COMMENT {
+			toks = append(toks, tok)
+		}
+	}
+}
+
+func TestPositions(t *testing.T) {
+	type want struct {
+		literal          string
+		line, col, col16 int
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []want
+	}{
+		{
+			name:  "ascii",
+			input: "var x = 10\n  x += 1.5s -- note\nf(\"s\")",
+			want: []want{
+				{"var", 1, 1, 0}, {"x", 1, 5, 4}, {"=", 1, 7, 6}, {"10", 1, 9, 8},
+				{"x", 2, 3, 2}, {"+=", 2, 5, 4}, {"1.5s", 2, 8, 7},
+				{"f", 3, 1, 0}, {"(", 3, 2, 1}, {"s", 3, 3, 2}, {")", 3, 6, 5},
+			},
+		},
+		{
+			name:  "cjk",
+			input: "var 伤害 = 10\n伤害 = \"火焰\" + 𝒙",
+			want: []want{
+				{"var", 1, 1, 0}, {"伤害", 1, 5, 4}, {"=", 1, 8, 7}, {"10", 1, 10, 9},
+				{"伤害", 2, 1, 0}, {"=", 2, 4, 3}, {"火焰", 2, 6, 5}, {"+", 2, 11, 10}, {"𝒙", 2, 13, 12},
+			},
+		},
+		{
+			name:  "after a wide character",
+			input: "𝒙 = 1",
+			want:  []want{{"𝒙", 1, 1, 0}, {"=", 1, 3, 3}, {"1", 1, 5, 5}},
+		},
+		{
+			name:  "ideographic space",
+			input: "x\u3000=\u30001",
+			want:  []want{{"x", 1, 1, 0}, {"=", 1, 3, 2}, {"1", 1, 5, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks := tokens(tt.input)
+			if len(toks) != len(tt.want) {
+				t.Fatalf("got %d tokens %v, want %d", len(toks), toks, len(tt.want))
+			}
+			for i, w := range tt.want {
+				tok := toks[i]
+				if tok.Literal != w.literal || tok.Pos.Line != w.line || tok.Pos.Column != w.col || tok.Pos.UTF16Column != w.col16 {
+					t.Errorf("token %d: got %q at %d:%d (UTF-16 %d), want %q at %d:%d (UTF-16 %d)",
+						i, tok.Literal, tok.Pos.Line, tok.Pos.Column, tok.Pos.UTF16Column, w.literal, w.line, w.col, w.col16)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidNumberPosition(t *testing.T) {
+	toks := tokens("x = 12_a")
+	tok := toks[len(toks)-1]
+	if tok.Type != ILLEGAL || tok.Pos.Line != 1 || tok.Pos.Column != 7 {
+		t.Errorf("got %v at %d:%d, want ILLEGAL at 1:7", tok.Type, tok.Pos.Line, tok.Pos.Column)
+	}
+}
+
+func TestDecrementAndPercent(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []TokenType
+	}{
+		{"x--", []TokenType{IDENTIFIER, DECREMENT}},
+		{"x-- comment", []TokenType{IDENTIFIER}},
+		{"x --y", []TokenType{IDENTIFIER}},
+		{"x-- -- comment", []TokenType{IDENTIFIER, DECREMENT}},
+		{"x--;", []TokenType{IDENTIFIER, DECREMENT, SEMICOLON}},
+		{"t[k]--\ny", []TokenType{IDENTIFIER, LBRACKET, IDENTIFIER, RBRACKET, DECREMENT, IDENTIFIER}},
+		{"10%", []TokenType{QUANTITY}},
+		{"10%3", []TokenType{INTEGER, MODULO, INTEGER}},
+		{"10% x", []TokenType{INTEGER, MODULO, IDENTIFIER}},
+		{"10%-x", []TokenType{INTEGER, MODULO, MINUS, IDENTIFIER}},
+		{"10% and y", []TokenType{QUANTITY, AND, IDENTIFIER}},
+		{"(10%)", []TokenType{LPAREN, QUANTITY, RPAREN}},
+		{"x%3", []TokenType{IDENTIFIER, MODULO, INTEGER}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got []TokenType
+			for _, tok := range tokens(tt.input) {
+				got = append(got, tok.Type)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,9 +15,14 @@ const (
 	IDENTIFIER // 标识符
 	INTEGER    // 整数
 	FLOAT      // 浮点数
+	QUANTITY   // 带单位的数 / number with a unit: 1.5s, 20%
 	STRING     // 字符串
+	STRINGHEAD // "text${
+	STRINGMID  // }text${
+	STRINGTAIL // }text"
 	BOOLEAN    // true/false
 	COMMENT    // 注释
+	DOCCOMMENT // --- 文档注释 / doc comment
 
 	// 运算符
 	ASSIGN    // =
@@ -25,6 +30,14 @@ const (
 	MINUS     // -
 	MULTIPLY  // *
 	DIVIDE    // /
+	MODULO    // %
+	POWER     // ^ or **
+	INTDIV    // //
+	BITAND    // &
+	BITOR     // |
+	BITXOR    // ~, also bitwise not as a prefix
+	SHL       // <<
+	SHR       // >>
 	EQ        // ==
 	NEQ       // !=
 	LT        // <
@@ -35,18 +48,29 @@ const (
 	AND       // and
 	OR        // or
 	DOT       // .
+	OPTDOT    // ?.
+	QUESTION  // ?
 	INCREMENT // ++
 	DECREMENT // --
 
+	PLUSASSIGN     // +=
+	MINUSASSIGN    // -=
+	MULTIPLYASSIGN // *=
+	DIVIDEASSIGN   // /=
+
 	// 分隔符
-	COMMA     // ,
-	LPAREN    // (
-	RPAREN    // )
-	LBRACE    // {
-	RBRACE    // }
-	LBRACKET  // [
-	RBRACKET  // ]
-	SEMICOLON // ;
+	COMMA      // ,
+	LPAREN     // (
+	RPAREN     // )
+	LBRACE     // {
+	RBRACE     // }
+	LBRACKET   // [
+	RBRACKET   // ]
+	SEMICOLON  // ;
+	COLON      // :
+	ARROW      // ->
+	FATARROW   // =>
+	UNDERSCORE // _
 
 	// 关键字
 	SKILL    // skill
@@ -63,6 +87,15 @@ const (
 	BREAK    // break
 	CONTINUE // continue
 	RANGE    // range
+	CONST    // const
+	ENUM     // enum
+	EXTENDS  // extends
+	TEMPLATE // template
+	USE      // use
+	MATCH    // match
+	WHILE    // while
+	REPEAT   // repeat
+	UNTIL    // until
 )
 
 func (t TokenType) String() string {
@@ -77,12 +110,22 @@ func (t TokenType) String() string {
 		return "INTEGER"
 	case FLOAT:
 		return "FLOAT"
+	case QUANTITY:
+		return "QUANTITY"
 	case STRING:
 		return "STRING"
+	case STRINGHEAD:
+		return "STRINGHEAD"
+	case STRINGMID:
+		return "STRINGMID"
+	case STRINGTAIL:
+		return "STRINGTAIL"
 	case BOOLEAN:
 		return "BOOLEAN"
 	case COMMENT:
 		return "COMMENT"
+	case DOCCOMMENT:
+		return "DOCCOMMENT"
 	case ASSIGN:
 		return "ASSIGN"
 	case PLUS:
@@ -93,6 +136,22 @@ func (t TokenType) String() string {
 		return "MULTIPLY"
 	case DIVIDE:
 		return "DIVIDE"
+	case MODULO:
+		return "MODULO"
+	case POWER:
+		return "POWER"
+	case INTDIV:
+		return "INTDIV"
+	case BITAND:
+		return "BITAND"
+	case BITOR:
+		return "BITOR"
+	case BITXOR:
+		return "BITXOR"
+	case SHL:
+		return "SHL"
+	case SHR:
+		return "SHR"
 	case EQ:
 		return "EQ"
 	case NEQ:
@@ -113,6 +172,10 @@ func (t TokenType) String() string {
 		return "OR"
 	case DOT:
 		return "DOT"
+	case OPTDOT:
+		return "OPTDOT"
+	case QUESTION:
+		return "QUESTION"
 	case COMMA:
 		return "COMMA"
 	case LPAREN:
@@ -129,6 +192,14 @@ func (t TokenType) String() string {
 		return "RBRACKET"
 	case SEMICOLON:
 		return "SEMICOLON"
+	case COLON:
+		return "COLON"
+	case ARROW:
+		return "ARROW"
+	case FATARROW:
+		return "FATARROW"
+	case UNDERSCORE:
+		return "UNDERSCORE"
 	case SKILL:
 		return "SKILL"
 	case STATE:
@@ -155,12 +226,38 @@ func (t TokenType) String() string {
 		return "BREAK"
 	case CONTINUE:
 		return "CONTINUE"
+	case CONST:
+		return "CONST"
+	case ENUM:
+		return "ENUM"
+	case EXTENDS:
+		return "EXTENDS"
+	case TEMPLATE:
+		return "TEMPLATE"
+	case USE:
+		return "USE"
+	case MATCH:
+		return "MATCH"
+	case WHILE:
+		return "WHILE"
+	case REPEAT:
+		return "REPEAT"
+	case UNTIL:
+		return "UNTIL"
 	case RANGE:
 		return "RANGE"
 	case INCREMENT:
 		return "INCREMENT"
 	case DECREMENT:
 		return "DECREMENT"
+	case PLUSASSIGN:
+		return "PLUSASSIGN"
+	case MINUSASSIGN:
+		return "MINUSASSIGN"
+	case MULTIPLYASSIGN:
+		return "MULTIPLYASSIGN"
+	case DIVIDEASSIGN:
+		return "DIVIDEASSIGN"
 	default:
 		return "UNKNOWN"
 	}
@@ -176,6 +273,22 @@ func (t TokenType) TokenLiteral() string {
 		return "*"
 	case DIVIDE:
 		return "/"
+	case MODULO:
+		return "%"
+	case POWER:
+		return "^"
+	case INTDIV:
+		return "//"
+	case BITAND:
+		return "&"
+	case BITOR:
+		return "|"
+	case BITXOR:
+		return "~"
+	case SHL:
+		return "<<"
+	case SHR:
+		return ">>"
 	case ASSIGN:
 		return "="
 	case EQ:
@@ -196,6 +309,22 @@ func (t TokenType) TokenLiteral() string {
 		return "and"
 	case DOT:
 		return "."
+	case OPTDOT:
+		return "?."
+	case QUESTION:
+		return "?"
+	case INCREMENT:
+		return "++"
+	case DECREMENT:
+		return "--"
+	case PLUSASSIGN:
+		return "+="
+	case MINUSASSIGN:
+		return "-="
+	case MULTIPLYASSIGN:
+		return "*="
+	case DIVIDEASSIGN:
+		return "/="
 	case COMMA:
 		return ","
 	case LPAREN:
@@ -210,6 +339,16 @@ func (t TokenType) TokenLiteral() string {
 		return "["
 	case RBRACKET:
 		return "]"
+	case COLON:
+		return ":"
+	case ARROW:
+		return "->"
+	case FATARROW:
+		return "=>"
+	case UNDERSCORE:
+		return "_"
+	case STRINGTAIL:
+		return "}"
 	default:
 		return t.String()
 	}
@@ -233,6 +372,15 @@ var keywords = map[string]TokenType{
 	"break":    BREAK,
 	"continue": CONTINUE,
 	"range":    RANGE,
+	"const":    CONST,
+	"enum":     ENUM,
+	"extends":  EXTENDS,
+	"template": TEMPLATE,
+	"use":      USE,
+	"match":    MATCH,
+	"while":    WHILE,
+	"repeat":   REPEAT,
+	"until":    UNTIL,
 }
 
 type Token struct {
@@ -0,0 +1,283 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode"
+
+	"github.com/hsoul/skconf/internal/units"
+)
+
+// Manifest describes the host API the DSL compiles against: the modules
+// whose functions skill code may call (UE, UF, ...) and their signatures,
+// and the enums of the host.
+//
+//	{
+//	  "modules": {
+//	    "UE": {
+//	      "functions": {
+//	        "AA": {"params": [{"name": "min", "type": "int"}, {"name": "max", "type": "int"}], "returns": "int"}
+//	      }
+//	    }
+//	  },
+//	  "hooks": {
+//	    "OnHit": {"params": [{"name": "target", "type": "Unit"}, {"name": "ratio", "type": "float"}], "returns": "bool"}
+//	  },
+//	  "enums": {
+//	    "EM": {"Test": 1, "Buff": 2}
+//	  },
+//	  "units": {
+//	    "duration": {"base": "frame", "fps": 30},
+//	    "distance": {"base": "cm"},
+//	    "percent": {"base": "ratio"}
+//	  }
+//	}
+//
+// Types are "int", "float", "bool", "string", "table", "any", a dimension
+// ("duration", "distance" or "percent") or the name of a host type such as
+// "Unit"; an empty type means "any".
+//
+// Hooks are the signatures the host calls the function-valued properties
+// of skills and states with, by property name. The parameters and result
+// of such a hook are checked against them, and take their types when the
+// DSL does not annotate them.
+//
+// Enums are host enums, such as the skill types a skill's type property
+// takes; EM.Test names a member and compiles to its value.
+//
+// Units selects the base unit the runtime stores every dimension in, and so
+// what quantities such as 1.5s or 20% compile to. Durations are in "s",
+// "ms" or "frame" (which needs "fps"), distances in "m", "cm" or "mm" and
+// percentages in "ratio", "percent" or "permille"; "fixed" makes values
+// fixed-point integers scaled by it. Seconds, meters and ratios, as
+// floats, are the defaults.
+type Manifest struct {
+	Modules map[string]*Module          `json:"modules"`
+	Hooks   map[string]*Signature       `json:"hooks"`
+	Enums   map[string]map[string]int64 `json:"enums"`
+	Units   *Units                      `json:"units"`
+}
+
+type Units struct {
+	Duration *UnitBase `json:"duration"`
+	Distance *UnitBase `json:"distance"`
+	Percent  *UnitBase `json:"percent"`
+}
+
+type UnitBase struct {
+	Base  string `json:"base"`
+	FPS   int    `json:"fps"`   // frames per second, for the "frame" base
+	Fixed int64  `json:"fixed"` // if set, values are integers scaled by it
+}
+
+type Module struct {
+	Functions map[string]*Signature `json:"functions"`
+}
+
+type Signature struct {
+	Params   []Param `json:"params"`
+	Returns  string  `json:"returns"`
+	Variadic bool    `json:"variadic"` // the last parameter accepts any number of arguments
+}
+
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// DefaultModules are the host modules assumed when no manifest is given.
+var DefaultModules = []string{"UE", "UF"}
+
+func Parse(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	for modName, mod := range m.Modules {
+		if mod == nil {
+			return nil, fmt.Errorf("manifest: module %s is empty", modName)
+		}
+		for fnName, sig := range mod.Functions {
+			if sig == nil {
+				return nil, fmt.Errorf("manifest: %s.%s has no signature", modName, fnName)
+			}
+			if sig.Variadic && len(sig.Params) == 0 {
+				return nil, fmt.Errorf("manifest: %s.%s is variadic without parameters", modName, fnName)
+			}
+		}
+	}
+	for name, sig := range m.Hooks {
+		if sig == nil {
+			return nil, fmt.Errorf("manifest: hook %s has no signature", name)
+		}
+		if sig.Variadic {
+			return nil, fmt.Errorf("manifest: hook %s cannot be variadic", name)
+		}
+	}
+	for name := range m.Enums {
+		if _, ok := m.Modules[name]; ok {
+			return nil, fmt.Errorf("manifest: %s is both a module and an enum", name)
+		}
+	}
+	for _, dim := range units.Dimensions {
+		if cfg := m.unitBase(dim); cfg != nil {
+			if _, err := units.NewBase(dim, cfg.Base, cfg.FPS, cfg.Fixed); err != nil {
+				return nil, fmt.Errorf("manifest: %w", err)
+			}
+		}
+	}
+	return m, nil
+}
+
+// unitBase returns the configuration of dim, or nil.
+func (m *Manifest) unitBase(dim units.Dimension) *UnitBase {
+	if m == nil || m.Units == nil {
+		return nil
+	}
+	switch dim {
+	case units.Duration:
+		return m.Units.Duration
+	case units.Distance:
+		return m.Units.Distance
+	case units.Percent:
+		return m.Units.Percent
+	}
+	return nil
+}
+
+// Base returns the base unit the runtime stores dim in, the default one
+// for a nil manifest or a dimension it does not configure.
+func (m *Manifest) Base(dim units.Dimension) units.Base {
+	if cfg := m.unitBase(dim); cfg != nil {
+		if base, err := units.NewBase(dim, cfg.Base, cfg.FPS, cfg.Fixed); err == nil {
+			return base
+		}
+	}
+	return units.DefaultBase(dim)
+}
+
+// ValueType resolves a dimension type such as "duration" to the "int" or
+// "float" its base unit is stored as; other types are returned unchanged.
+func (m *Manifest) ValueType(typ string) string {
+	if !units.IsDimension(typ) {
+		return typ
+	}
+	if m.Base(units.Dimension(typ)).IsInt() {
+		return "int"
+	}
+	return "float"
+}
+
+// ModuleNames returns the host module names in sorted order, or
+// DefaultModules for a nil manifest.
+func (m *Manifest) ModuleNames() []string {
+	if m == nil {
+		return DefaultModules
+	}
+	names := make([]string, 0, len(m.Modules))
+	for name := range m.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *Manifest) IsModule(name string) bool {
+	if m == nil {
+		for _, mod := range DefaultModules {
+			if mod == name {
+				return true
+			}
+		}
+		return false
+	}
+	_, ok := m.Modules[name]
+	return ok
+}
+
+// Function looks up module.name. It reports false for a nil manifest.
+func (m *Manifest) Function(module, name string) (*Signature, bool) {
+	if m == nil {
+		return nil, false
+	}
+	mod, ok := m.Modules[module]
+	if !ok {
+		return nil, false
+	}
+	sig, ok := mod.Functions[name]
+	return sig, ok
+}
+
+// FunctionNames returns the function names of module in sorted order.
+func (m *Manifest) FunctionNames(module string) []string {
+	if m == nil || m.Modules[module] == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m.Modules[module].Functions))
+	for name := range m.Modules[module].Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enum returns the members of the host enum name. It reports false for a
+// nil manifest.
+func (m *Manifest) Enum(name string) (map[string]int64, bool) {
+	if m == nil {
+		return nil, false
+	}
+	members, ok := m.Enums[name]
+	return members, ok
+}
+
+// Hook returns the signature of the hook name. It reports false for a nil
+// manifest.
+func (m *Manifest) Hook(name string) (*Signature, bool) {
+	if m == nil {
+		return nil, false
+	}
+	sig, ok := m.Hooks[name]
+	return sig, ok
+}
+
+// builtinTypes are the types every manifest knows.
+var builtinTypes = map[string]bool{"int": true, "float": true, "bool": true, "string": true, "table": true, "any": true}
+
+// IsType reports whether typ names a type: a builtin one, a dimension, or a
+// host type the manifest uses in a signature. Without a manifest, host
+// types are unknown and any name starting with an upper-case letter is
+// taken for one.
+func (m *Manifest) IsType(typ string) bool {
+	if builtinTypes[typ] || units.IsDimension(typ) {
+		return true
+	}
+	if m == nil {
+		return typ != "" && unicode.IsUpper([]rune(typ)[0])
+	}
+	uses := func(sig *Signature) bool {
+		if sig.Returns == typ {
+			return true
+		}
+		for _, param := range sig.Params {
+			if param.Type == typ {
+				return true
+			}
+		}
+		return false
+	}
+	for _, mod := range m.Modules {
+		for _, sig := range mod.Functions {
+			if uses(sig) {
+				return true
+			}
+		}
+	}
+	for _, sig := range m.Hooks {
+		if uses(sig) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package sema
+
+import (
+	"slices"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+)
+
+// variable is a variable as the capture analysis sees it.
+type variable struct {
+	decl *ast.Identifier
+	fn   *ast.FunctionDef // that declares it; nil at the top level of the file
+	loop bool             // the variable of a for loop with an init clause
+}
+
+// captures fills in the Captures of every function of a program: the
+// variables of enclosing functions, or of the file, that it refers to.
+// Functions capture variables rather than their values, as Lua closures do:
+// every target shares a captured variable between the function and the
+// code around it.
+type captures struct {
+	checker *checker
+	scopes  []map[string]*variable
+	fns     []*ast.FunctionDef // being walked, innermost last
+}
+
+// captureVariables runs the capture analysis on program. A function that
+// captures the variable of a for loop with an init clause is reported: all
+// iterations share that variable, so the function sees its last value.
+func (c *checker) captureVariables(program *ast.Program) {
+	a := &captures{checker: c}
+	a.push()
+	ast.Inspect(program, a.visit)
+	a.pop()
+}
+
+func (a *captures) push() {
+	a.scopes = append(a.scopes, map[string]*variable{})
+}
+
+func (a *captures) pop() {
+	a.scopes = a.scopes[:len(a.scopes)-1]
+}
+
+func (a *captures) declare(id *ast.Identifier, loop bool) {
+	var fn *ast.FunctionDef
+	if len(a.fns) > 0 {
+		fn = a.fns[len(a.fns)-1]
+	}
+	a.scopes[len(a.scopes)-1][id.Value] = &variable{decl: id, fn: fn, loop: loop}
+}
+
+// resolve records a reference to a variable in every function between the
+// one that declares it and the reference.
+func (a *captures) resolve(id *ast.Identifier) {
+	var v *variable
+	for i := len(a.scopes) - 1; i >= 0 && v == nil; i-- {
+		v = a.scopes[i][id.Value]
+	}
+	if v == nil {
+		return
+	}
+	for i := len(a.fns) - 1; i >= 0 && a.fns[i] != v.fn; i-- {
+		fn := a.fns[i]
+		if slices.Contains(fn.Captures, v.decl) {
+			continue
+		}
+		fn.Captures = append(fn.Captures, v.decl)
+		if v.loop && i == len(a.fns)-1 {
+			a.checker.diags = append(a.checker.diags, diag.Warningf(a.checker.file, id.Pos(),
+				"function captures loop variable %s, which all iterations share; copy it with var inside the loop to capture each value", id.Value))
+		}
+	}
+}
+
+// definition walks the properties of a skill or state and those of the
+// templates it uses. Inherited properties are walked with the parent, or
+// in the file they are imported from.
+func (a *captures) definition(uses []*ast.UseDecl, props []*ast.PropertyDef) {
+	for _, use := range uses {
+		for _, prop := range use.Expanded {
+			ast.Inspect(prop, a.visit)
+		}
+	}
+	for _, prop := range props {
+		ast.Inspect(prop, a.visit)
+	}
+}
+
+func (a *captures) function(fn *ast.FunctionDef) {
+	a.fns = append(a.fns, fn)
+	a.push()
+	for _, param := range fn.Parameters {
+		a.declare(param, false)
+	}
+	ast.Inspect(fn.Body, a.visit)
+	a.pop()
+	a.fns = a.fns[:len(a.fns)-1]
+}
+
+// visit is the ast.Inspect callback of the walk, which handles the nodes
+// that declare names itself.
+func (a *captures) visit(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.Identifier:
+		a.resolve(n)
+	case *ast.TemplateDef, *ast.ConstStatement, *ast.EnumDef, *ast.ImportStatement:
+		return false // expanded or folded into their uses
+	case *ast.SkillDef:
+		a.definition(n.Uses, n.Properties)
+		return false
+	case *ast.StateDef:
+		a.definition(n.Uses, n.Properties)
+		return false
+	case *ast.PropertyDef:
+		if _, isName := n.Key.(*ast.Identifier); !isName { // a field name, not a reference
+			ast.Inspect(n.Key, a.visit)
+		}
+		ast.Inspect(n.Value, a.visit)
+		return false
+	case *ast.DotExpression:
+		ast.Inspect(n.Left, a.visit) // Right is a member name
+		return false
+	case *ast.CodeBlock:
+		a.push()
+		for _, stmt := range n.Statements {
+			ast.Inspect(stmt, a.visit)
+		}
+		a.pop()
+		return false
+	case *ast.FunctionDef:
+		if !a.checker.imported[n] { // analysed in its own file
+			a.function(n)
+		}
+		return false
+	case *ast.VarStatement:
+		// A function can call itself through the variable it is assigned to.
+		if _, ok := n.Value.(*ast.FunctionDef); ok {
+			a.declare(n.Name, false)
+			ast.Inspect(n.Value, a.visit)
+			return false
+		}
+		ast.Inspect(n.Value, a.visit)
+		a.declare(n.Name, false)
+		return false
+	case *ast.ForStatement:
+		a.push()
+		if init, ok := n.Init.(*ast.VarStatement); ok {
+			ast.Inspect(init.Value, a.visit)
+			a.declare(init.Name, true)
+		} else {
+			ast.Inspect(n.Init, a.visit)
+		}
+		ast.Inspect(n.Condition, a.visit)
+		ast.Inspect(n.Post, a.visit)
+		ast.Inspect(n.RangeValue, a.visit)
+		if n.Key != nil {
+			a.declare(n.Key, false)
+		}
+		if n.Value != nil {
+			a.declare(n.Value, false)
+		}
+		ast.Inspect(n.Body, a.visit)
+		a.pop()
+		return false
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package sema
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+)
+
+// definition is a skill or a state, which extends definitions of the same
+// kind and uses templates.
+type definition struct {
+	kind     string // "skill" or "state"
+	name     string
+	parent   ast.Expression
+	uses     []*ast.UseDecl
+	own      []*ast.PropertyDef
+	merged   []*ast.PropertyDef // nil until resolved
+	setMerge func([]*ast.PropertyDef)
+	visiting bool
+}
+
+type defKey struct {
+	kind, name string
+}
+
+func newDefinition(stmt ast.Statement) *definition {
+	switch s := stmt.(type) {
+	case *ast.SkillDef:
+		return &definition{kind: "skill", name: s.Name.Value, parent: s.Parent, uses: s.Uses, own: s.Properties,
+			setMerge: func(props []*ast.PropertyDef) { s.Merged = props }}
+	case *ast.StateDef:
+		return &definition{kind: "state", name: s.Name.Value, parent: s.Parent, uses: s.Uses, own: s.Properties,
+			setMerge: func(props []*ast.PropertyDef) { s.Merged = props }}
+	}
+	return nil
+}
+
+// resolveExtends merges the properties of every skill and state of program
+// that extends another, declared before or after it in the same file or in
+// an imported one, or uses templates.
+func (c *checker) resolveExtends(program *ast.Program) {
+	var defs []*definition
+	for _, stmt := range program.Statements {
+		if d := newDefinition(stmt); d != nil {
+			key := defKey{d.kind, d.name}
+			if c.defs[key] == nil {
+				c.defs[key] = d
+			}
+			defs = append(defs, d)
+		}
+	}
+	for _, d := range defs {
+		c.merge(d, nil)
+	}
+}
+
+// merge returns the merged properties of d: those of its parent, then
+// those of each template it uses, then its own, each overriding the ones
+// before. chain holds the definitions being merged, to report a cycle.
+func (c *checker) merge(d *definition, chain []*definition) []*ast.PropertyDef {
+	if d.merged != nil {
+		return d.merged
+	}
+	if d.parent == nil && len(d.uses) == 0 {
+		d.merged = d.own
+		return d.merged
+	}
+	if d.visiting {
+		names := []string{}
+		for _, link := range chain[slices.Index(chain, d):] {
+			names = append(names, link.name)
+		}
+		last := chain[len(chain)-1]
+		c.errorf(last.parent, "inheritance cycle: %s -> %s", strings.Join(names, " -> "), d.name)
+		return d.own
+	}
+
+	var merged []*ast.PropertyDef
+	if d.parent != nil {
+		d.visiting = true
+		if parent, from, path := c.parentOf(d); parent != nil {
+			merged = c.merge(parent, append(chain, d))
+			if from != nil {
+				merged = c.importProperties(merged, from, path)
+			}
+			c.checkTid(d, merged)
+		}
+		d.visiting = false
+	}
+	for _, use := range d.uses {
+		merged = override(merged, use.Expanded)
+	}
+	merged = override(merged, d.own)
+
+	d.merged = merged
+	d.setMerge(merged)
+	return merged
+}
+
+// parentOf resolves the definition d extends, with the exports and the
+// path of the file it is imported from, if any, reporting it if there is
+// none.
+func (c *checker) parentOf(d *definition) (parent *definition, from *exports, path string) {
+	ref, _ := ast.QualifiedName(d.parent)
+	find := func(kind string) (*definition, *exports, string) {
+		if parent := c.defs[defKey{kind, ref}]; parent != nil {
+			return parent, nil, ""
+		}
+		for path, exports := range c.imports {
+			if name, found := strings.CutPrefix(ref, path+"."); found {
+				if parent := exports.defs[defKey{kind, name}]; parent != nil {
+					return parent, exports, path
+				}
+			}
+		}
+		return nil, nil, ""
+	}
+
+	if parent, from, path := find(d.kind); parent != nil {
+		return parent, from, path
+	}
+	other := "state"
+	if d.kind == "state" {
+		other = "skill"
+	}
+	if parent, _, _ := find(other); parent != nil {
+		c.errorf(d.parent, "%s %s cannot extend %s, which is a %s", d.kind, d.name, ref, other)
+	} else {
+		c.errorf(d.parent, "%s %s extends unknown %s %s", d.kind, d.name, d.kind, ref)
+	}
+	return nil, nil, ""
+}
+
+// importProperties copies the properties inherited from a definition of the
+// file imported as path, so that the functions of that file they call are
+// linked into this one.
+func (c *checker) importProperties(props []*ast.PropertyDef, from *exports, path string) []*ast.PropertyDef {
+	copies := make([]*ast.PropertyDef, len(props))
+	for i, prop := range props {
+		copies[i] = ast.Clone(prop)
+		c.importCode(copies[i], from, path)
+	}
+	return copies
+}
+
+// checkTid reports a tid d inherits: it must declare one of its own, or
+// two definitions would register under it.
+func (c *checker) checkTid(d *definition, inherited []*ast.PropertyDef) {
+	for _, prop := range d.own {
+		if propertyName(prop) == "tid" {
+			return
+		}
+	}
+	for _, prop := range inherited {
+		if propertyName(prop) == "tid" {
+			ref, _ := ast.QualifiedName(d.parent)
+			c.errorf(d.parent, "%s %s inherits tid %s from %s; give it a tid of its own", d.kind, d.name, prop.Value, ref)
+		}
+	}
+}
+
+// override merges props into base: a property replaces the one of the same
+// name in place, and the others follow in their order.
+func override(base, props []*ast.PropertyDef) []*ast.PropertyDef {
+	byName := map[string]*ast.PropertyDef{}
+	for _, prop := range props {
+		if name := propertyName(prop); name != "" {
+			byName[name] = prop
+		}
+	}
+
+	merged := make([]*ast.PropertyDef, 0, len(base)+len(props))
+	placed := map[*ast.PropertyDef]bool{}
+	for _, prop := range base {
+		if mine := byName[propertyName(prop)]; mine != nil && !placed[mine] {
+			mine.Overrides = prop
+			merged = append(merged, mine)
+			placed[mine] = true
+			continue
+		}
+		merged = append(merged, prop)
+	}
+	for _, prop := range props {
+		if !placed[prop] {
+			merged = append(merged, prop)
+		}
+	}
+	return merged
+}
+
+func propertyName(prop *ast.PropertyDef) string {
+	switch k := prop.Key.(type) {
+	case *ast.Identifier:
+		return k.Value
+	case *ast.String:
+		return k.Value
+	}
+	return ""
+}
@@ -0,0 +1,205 @@
+package sema
+
+import (
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/constant"
+)
+
+// constValue is a const or an enum member. value is the literal it folds
+// to, nil until evalConsts has folded it or if it is not constant.
+type constValue struct {
+	value ast.Expression
+	dim   dimension
+}
+
+// collectConsts registers the consts and enums of program before anything
+// is checked, so that no variable anywhere in the file can hide one.
+func (c *checker) collectConsts(program *ast.Program) {
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.ConstStatement:
+			if c.consts[s.Name.Value] != nil || c.enums[s.Name.Value] != nil {
+				c.errorf(s.Name, "const %s redeclared", s.Name.Value)
+				continue
+			}
+			c.consts[s.Name.Value] = &constValue{}
+		case *ast.EnumDef:
+			if c.consts[s.Name.Value] != nil || c.enums[s.Name.Value] != nil {
+				c.errorf(s.Name, "enum %s redeclared", s.Name.Value)
+				continue
+			}
+			members := map[string]*constValue{}
+			for _, member := range s.Members {
+				if members[member.Name.Value] != nil {
+					c.errorf(member.Name, "enum member %s.%s redeclared", s.Name.Value, member.Name.Value)
+					continue
+				}
+				members[member.Name.Value] = &constValue{dim: number}
+			}
+			c.enums[s.Name.Value] = members
+		}
+	}
+}
+
+// evalConsts folds the value of every const and enum member in source
+// order, so that each may use the ones declared before it.
+func (c *checker) evalConsts(program *ast.Program) {
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.ConstStatement:
+			ast.Rewrite(s, c.fold)
+			if _, ok := constant.Of(s.Value); !ok {
+				c.errorf(s.Name, "const %s is not a constant expression", s.Name.Value)
+				continue
+			}
+			if k := c.consts[s.Name.Value]; k != nil && k.value == nil {
+				k.value = s.Value
+			}
+		case *ast.EnumDef:
+			c.evalEnum(s)
+		}
+	}
+}
+
+// evalEnum folds the values of the members of s, filling in the ones
+// left out. A value may use the members before it without qualification,
+// as in Holy = Fire * 10.
+func (c *checker) evalEnum(s *ast.EnumDef) {
+	members := c.enums[s.Name.Value]
+	c.members = members
+	defer func() { c.members = nil }()
+	next := int64(0)
+	for _, member := range s.Members {
+		if member.Value == nil {
+			member.Value = constant.Literal(next, member.Pos())
+		}
+		ast.Rewrite(member, c.fold)
+		v, ok := constant.Of(member.Value)
+		value, isInt := v.(int64)
+		if !ok || !isInt {
+			c.errorf(member.Name, "value of %s.%s is not a constant integer", s.Name.Value, member.Name.Value)
+			continue
+		}
+		next = value + 1
+		if k := members[member.Name.Value]; k != nil && k.value == nil {
+			k.value = member.Value
+		}
+	}
+}
+
+// importedConst resolves exp if it names a const of an imported file, as
+// in common.GCD. ref is the dotted name and ok reports whether its prefix
+// is an import path; k is nil if that file has no such const.
+func (c *checker) importedConst(exp ast.Expression) (k *constValue, ref string, ok bool) {
+	ref, isName := ast.QualifiedName(exp)
+	if !isName {
+		return nil, "", false
+	}
+	for path, exports := range c.imports {
+		if name, found := strings.CutPrefix(ref, path+"."); found && !strings.Contains(name, ".") {
+			return exports.consts[name], ref, true
+		}
+	}
+	return nil, ref, false
+}
+
+// enumMember resolves exp if it names a member of an enum of this file,
+// an imported file or the manifest, as in Element.Fire. enum is the dotted
+// name of the enum, empty if exp names none; k is nil if the enum has no
+// such member.
+func (c *checker) enumMember(exp ast.Expression) (k *constValue, enum, member string) {
+	ref, isName := ast.QualifiedName(exp)
+	dot := strings.LastIndex(ref, ".")
+	if !isName || dot < 0 {
+		return nil, "", ""
+	}
+	enum, member = ref[:dot], ref[dot+1:]
+
+	members := c.enumMembers(enum)
+	if members == nil {
+		return nil, "", ""
+	}
+	return members[member], enum, member
+}
+
+// enumMembers returns the members of the enum of this file, an imported
+// file or the manifest with the dotted name enum, or nil if there is none.
+func (c *checker) enumMembers(enum string) map[string]*constValue {
+	if members := c.enums[enum]; members != nil {
+		return members
+	}
+	for path, exports := range c.imports {
+		if name, found := strings.CutPrefix(enum, path+"."); found && exports.enums[name] != nil {
+			return exports.enums[name]
+		}
+	}
+	return c.hostEnums[enum]
+}
+
+// fold replaces a use of a const by its value and evaluates an operator
+// whose operands are constants. It is applied children first, so constant
+// subexpressions are already literals. Operations that would fail at
+// runtime, such as 1 // 0, are left alone.
+func (c *checker) fold(exp ast.Expression) ast.Expression {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		if k := c.consts[e.Value]; k != nil && k.value != nil {
+			return use(k, e)
+		}
+		if k := c.members[e.Value]; k != nil && k.value != nil {
+			return use(k, e)
+		}
+	case *ast.DotExpression:
+		if k, enum, _ := c.enumMember(e); k != nil && k.value != nil {
+			lit := use(k, e)
+			if i, ok := lit.(*ast.Integer); ok {
+				i.Enum = enum
+			}
+			return lit
+		}
+		if k, _, _ := c.importedConst(e); k != nil && k.value != nil {
+			return use(k, e)
+		}
+	case *ast.PrefixExpression:
+		if right, ok := constant.Of(e.Right); ok {
+			if v, ok := constant.Unary(e.Operator, right); ok {
+				return constant.Literal(v, e.Pos())
+			}
+		}
+	case *ast.InfixExpression:
+		left, leftOK := constant.Of(e.Left)
+		if leftOK && (e.Operator == "and" || e.Operator == "or") {
+			// Lua's and/or yield one of their operands.
+			if constant.Truthy(left) == (e.Operator == "and") {
+				return e.Right
+			}
+			return e.Left
+		}
+		if right, ok := constant.Of(e.Right); ok && leftOK {
+			if v, ok := constant.Binary(e.Operator, left, right); ok {
+				return constant.Literal(v, e.Pos())
+			}
+		}
+	case *ast.ConditionalExpression:
+		if cond, ok := constant.Of(e.Condition); ok {
+			if constant.Truthy(cond) {
+				return e.Consequence
+			}
+			return e.Alternative
+		}
+	}
+	return exp
+}
+
+// use returns a copy of the value of k placed at the use site. A const
+// whose value is an enum member stays one.
+func use(k *constValue, site ast.Node) ast.Expression {
+	v, _ := constant.Of(k.value)
+	lit := constant.Literal(v, site.Pos())
+	if i, ok := k.value.(*ast.Integer); ok {
+		lit.(*ast.Integer).Enum = i.Enum
+	}
+	return lit
+}
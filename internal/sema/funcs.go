@@ -0,0 +1,118 @@
+package sema
+
+import (
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/lexer"
+)
+
+// collectFunctions registers the functions declared at the top level of
+// program, which the whole file can call, before or after the declaration.
+func (c *checker) collectFunctions(program *ast.Program) {
+	for _, stmt := range program.Statements {
+		fn, ok := stmt.(*ast.FunctionDef)
+		if !ok {
+			continue
+		}
+		name, ok := fn.Name.(*ast.Identifier)
+		if !ok {
+			continue
+		}
+		if c.funcs[name.Value] != nil {
+			c.errorf(name, "function %s redeclared", name.Value)
+			continue
+		}
+		c.funcs[name.Value] = fn
+	}
+	for _, stmt := range program.Statements {
+		if s, ok := stmt.(*ast.VarStatement); ok && c.funcs[s.Name.Value] != nil {
+			c.errorf(s.Name, "%s is a function and cannot be redeclared", s.Name.Value)
+		}
+	}
+}
+
+// fileFunction returns the function of this file name refers to, unless a
+// local hides it.
+func (c *checker) fileFunction(name string) *ast.FunctionDef {
+	for i := len(c.scopes) - 1; i > 0; i-- {
+		if _, ok := c.scopes[i][name]; ok {
+			return nil
+		}
+	}
+	return c.funcs[name]
+}
+
+// callFunction checks a call of fn, a function of this file, whose
+// arguments are of dimensions args. Code that runs as the file loads,
+// outside of any function, can only call the functions declared before it.
+func (c *checker) callFunction(call *ast.FunctionCall, name string, fn *ast.FunctionDef, args []dimension) {
+	if len(call.Arguments) != len(fn.Parameters) {
+		c.errorf(call.Function, "%s takes %d arguments, got %d", name, len(fn.Parameters), len(call.Arguments))
+	}
+	for i, arg := range call.Arguments[:min(len(args), len(fn.Parameters))] {
+		if got := mismatch(arg, args[i], fn.ParamType(i)); got != "" {
+			c.errorf(arg, "%s: argument %d (%s) must be %s, got %s", name, i+1, fn.Parameters[i].Value, fn.ParamType(i), got)
+		}
+	}
+	if len(c.fns) == 0 && !c.imported[fn] && before(call.Function.Pos(), fn.Pos()) {
+		c.errorf(call.Function, "%s is called before its declaration", name)
+	}
+}
+
+// linkFunctions makes the functions of imported files that program refers
+// to, as in lib.common.clamp(x), functions of program: imported files
+// generate no code of their own. Each gets a copy named after its import
+// path, lib_common_clamp, declared before the statements of program.
+func (c *checker) linkFunctions(program *ast.Program) {
+	ast.Rewrite(program, c.link)
+	program.Statements = append(c.linked, program.Statements...)
+}
+
+// link is the ast.Rewrite callback of linkFunctions.
+func (c *checker) link(exp ast.Expression) ast.Expression {
+	dot, ok := exp.(*ast.DotExpression)
+	if !ok {
+		return exp
+	}
+	ref, _ := ast.QualifiedName(dot)
+	for path, exports := range c.imports {
+		if name, found := strings.CutPrefix(ref, path+"."); found && exports.funcs[name] != nil {
+			return &ast.Identifier{BaseNode: dot.BaseNode, Value: c.importFunction(path, name, exports)}
+		}
+	}
+	return exp
+}
+
+// importFunction copies the function name of the file imported as path
+// into this one, unless it already has, and returns the name of the copy.
+func (c *checker) importFunction(path, name string, from *exports) string {
+	local := strings.ReplaceAll(path, ".", "_") + "_" + name
+	if fn := c.funcs[local]; fn != nil && c.imported[fn] {
+		return local
+	}
+
+	fn := ast.Clone(from.funcs[name])
+	fn.Name = &ast.Identifier{BaseNode: fn.BaseNode, Value: local}
+	if c.funcs[local] != nil {
+		c.errorf(c.funcs[local].Name, "function %s is also the name of %s.%s in this file", local, path, name)
+	}
+	c.funcs[local] = fn
+	c.imported[fn] = true
+	c.importCode(fn, from, path)
+	c.linked = append(c.linked, fn)
+	return local
+}
+
+// importCode adapts code copied from the file imported as path, such as an
+// inherited property, to this file: the declarations of that file it
+// refers to are qualified with path, and its functions then linked.
+func (c *checker) importCode(node ast.Node, from *exports, path string) {
+	c.newHygiene(from, path).apply(node)
+	ast.Rewrite(node, c.link)
+}
+
+// before reports whether a comes before b in the same file.
+func before(a, b lexer.Position) bool {
+	return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+}
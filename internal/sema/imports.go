@@ -0,0 +1,57 @@
+package sema
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+)
+
+// importOrder sorts files so that every file comes after the files it
+// imports, keeping the given order otherwise. Imports that name no file,
+// such as host modules, are ignored; an import cycle is an error.
+func importOrder(files []File) ([]File, []diag.Diagnostic) {
+	byPath := map[string]int{}
+	for i, f := range files {
+		byPath[modulePath(f.Name)] = i
+	}
+
+	var order []File
+	var diags []diag.Diagnostic
+	state := make([]int, len(files)) // 0 new, 1 visiting, 2 done
+	var visit func(i int, chain []string)
+	visit = func(i int, chain []string) {
+		state[i] = 1
+		chain = append(chain, modulePath(files[i].Name))
+		for _, imp := range files[i].Program.Imports {
+			path, ok := ast.QualifiedName(imp.Value)
+			j, isFile := byPath[path]
+			if !ok || !isFile {
+				continue
+			}
+			switch state[j] {
+			case 0:
+				visit(j, chain)
+			case 1:
+				cycle := chain[slices.Index(chain, path):]
+				diags = append(diags, diag.Errorf(files[i].Name, imp.Pos(), "import cycle: %s -> %s", strings.Join(cycle, " -> "), path))
+			}
+		}
+		state[i] = 2
+		order = append(order, files[i])
+	}
+	for i := range files {
+		if state[i] == 0 {
+			visit(i, nil)
+		}
+	}
+	return order, diags
+}
+
+// modulePath returns the import path of a source: lib/common.dsl is
+// imported as lib.common.
+func modulePath(name string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(name, path.Ext(name)), "/", ".")
+}
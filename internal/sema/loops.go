@@ -0,0 +1,98 @@
+package sema
+
+import (
+	"cmp"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/constant"
+)
+
+// boundLoops sets Bounded on the for loops of program that end after a
+// number of passes known when they start, so that the loop budget leaves
+// them alone: range loops, and loops counting a variable from a constant
+// towards a constant limit in constant steps, such as
+// for var i = 1; i <= 10; i += 2, whose body never assigns the variable.
+// While and repeat loops, and every other for loop, count their passes
+// against the budget.
+func (c *checker) boundLoops(program *ast.Program) {
+	ast.Inspect(program, func(node ast.Node) bool {
+		if loop, ok := node.(*ast.ForStatement); ok {
+			loop.Bounded = loop.IsRangeForm || counts(loop)
+		}
+		return true
+	})
+}
+
+func counts(loop *ast.ForStatement) bool {
+	init, ok := loop.Init.(*ast.VarStatement)
+	if !ok || !isNumber(init.Value) {
+		return false
+	}
+	cond, ok := loop.Condition.(*ast.InfixExpression)
+	if !ok || !ast.IsSameIdentifier(cond.Left, init.Name) || !isNumber(cond.Right) {
+		return false
+	}
+	post, ok := loop.Post.(*ast.AssignStatement)
+	if !ok || !ast.IsSameIdentifier(post.Target, init.Name) {
+		return false
+	}
+
+	op, step := post.Update()
+	if op == "" { // i = i + 1
+		update, ok := step.(*ast.InfixExpression)
+		if !ok || !ast.IsSameIdentifier(update.Left, init.Name) {
+			return false
+		}
+		op, step = update.Operator, update.Right
+	}
+	direction, ok := sign(step)
+	switch {
+	case !ok:
+		return false
+	case op == "-":
+		direction = -direction
+	case op != "+":
+		return false
+	}
+	switch cond.Operator {
+	case "<", "<=":
+		ok = direction > 0
+	case ">", ">=":
+		ok = direction < 0
+	default:
+		ok = false
+	}
+	return ok && !assigns(loop.Body, init.Name.Value)
+}
+
+// sign returns the sign of exp if it is a number constant.
+func sign(exp ast.Expression) (int, bool) {
+	v, _ := constant.Of(exp)
+	switch v := v.(type) {
+	case int64:
+		return cmp.Compare(v, 0), true
+	case float64:
+		return cmp.Compare(v, 0), true
+	}
+	return 0, false
+}
+
+func isNumber(exp ast.Expression) bool {
+	_, ok := sign(exp)
+	return ok
+}
+
+// assigns reports whether code in body, including the functions it
+// declares, assigns to a variable called name.
+func assigns(body *ast.CodeBlock, name string) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		if s, ok := node.(*ast.AssignStatement); ok {
+			if id, ok := s.Target.(*ast.Identifier); ok && id.Value == name {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
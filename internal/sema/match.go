@@ -0,0 +1,235 @@
+package sema
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/constant"
+	"github.com/hsoul/skconf/internal/lexer"
+)
+
+// match checks a match statement. A bare name among its patterns, such as
+// Fire, names a const or else a member of the enum the match is over,
+// which the other patterns name or which is the only enum with all the
+// bare names. A match over an enum without a default arm must have an arm
+// for every member.
+func (c *checker) match(s *ast.MatchStatement) {
+	dim := c.expression(s.Subject)
+	enum, ok := c.matchEnum(s)
+	if !ok {
+		c.unresolved[s] = true
+	}
+
+	covered := map[string]bool{}
+	for _, arm := range s.Arms {
+		for i, pattern := range arm.Patterns {
+			if id, ok := pattern.(*ast.Identifier); ok && enum != "" && c.consts[id.Value] == nil {
+				pattern = &ast.DotExpression{BaseNode: id.BaseNode, Left: qualifiedName(enum, id.BaseNode), Right: id}
+				arm.Patterns[i] = pattern
+			}
+			name := patternName(pattern)
+			c.patterns[pattern.Pos()] = name
+			if got := c.expression(pattern); dim != unknown && got != unknown && got != dim {
+				c.errorf(pattern, "pattern %s is a %s, but the match is over a %s", name, got, dim)
+			}
+			if k, of, member := c.enumMember(pattern); k != nil && of == enum {
+				covered[member] = true
+			}
+		}
+		c.block(arm.Body)
+	}
+
+	if enum == "" || !ok || s.Default() != nil {
+		return
+	}
+	var missing []string
+	for member := range c.enumMembers(enum) {
+		if !covered[member] {
+			missing = append(missing, member)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		c.errorf(s, "match over %s is missing %s; add arms for them or a _ arm", enum, strings.Join(missing, ", "))
+	}
+}
+
+// matchEnum returns the dotted name of the enum the patterns of s are
+// members of, or "" if they are not all members of one enum. ok is false
+// if it reported a pattern that names no member.
+func (c *checker) matchEnum(s *ast.MatchStatement) (enum string, ok bool) {
+	var named []string
+	var bare []*ast.Identifier
+	for _, arm := range s.Arms {
+		for _, pattern := range arm.Patterns {
+			if id, ok := pattern.(*ast.Identifier); ok {
+				if c.consts[id.Value] == nil {
+					bare = append(bare, id)
+				}
+			} else if _, enum, _ := c.enumMember(pattern); enum != "" && !slices.Contains(named, enum) {
+				named = append(named, enum)
+			}
+		}
+	}
+
+	switch {
+	case len(named) > 1:
+		for _, id := range bare {
+			c.errorf(id, "%s is ambiguous in a match over members of %s; qualify it", id.Value, strings.Join(named, " and "))
+		}
+		return "", len(bare) == 0
+	case len(named) == 1:
+		members := c.enumMembers(named[0])
+		ok = true
+		for _, id := range bare {
+			if members[id.Value] == nil {
+				c.errorf(id, "%s has no member %s", named[0], id.Value)
+				ok = false
+			}
+		}
+		return named[0], ok
+	case len(bare) == 0:
+		return "", true
+	}
+
+	var candidates []string
+	for _, enum := range c.enumNames() {
+		members := c.enumMembers(enum)
+		if !slices.ContainsFunc(bare, func(id *ast.Identifier) bool { return members[id.Value] == nil }) {
+			candidates = append(candidates, enum)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		c.errorf(bare[0], "no enum has all of the members %s", bareNames(bare))
+		return "", false
+	case 1:
+		return candidates[0], true
+	}
+	if names := bareNames(bare); strings.Contains(names, ",") {
+		c.errorf(bare[0], "%s could be members of %s; qualify one of them", names, strings.Join(candidates, " or "))
+	} else {
+		c.errorf(bare[0], "%s could be a member of %s; qualify it", names, strings.Join(candidates, " or "))
+	}
+	return "", false
+}
+
+func bareNames(ids []*ast.Identifier) string {
+	var names []string
+	for _, id := range ids {
+		if !slices.Contains(names, id.Value) {
+			names = append(names, id.Value)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// enumNames returns the dotted names of the enums a file can use, sorted:
+// its own, those of the files it imports and those of the manifest.
+func (c *checker) enumNames() []string {
+	var names []string
+	for name := range c.enums {
+		names = append(names, name)
+	}
+	for path, exports := range c.imports {
+		for name := range exports.enums {
+			names = append(names, path+"."+name)
+		}
+	}
+	for name := range c.hostEnums {
+		if c.enums[name] == nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// patternName returns the source text of a pattern for messages.
+func patternName(exp ast.Expression) string {
+	if name, ok := ast.QualifiedName(exp); ok {
+		return name
+	}
+	if s, ok := exp.(*ast.String); ok {
+		return strconv.Quote(s.Value)
+	}
+	return exp.String()
+}
+
+// checkMatches checks, once constants are folded, that the patterns of
+// every match of program are constants and that no two of them are equal;
+// the backends compile a match to a switch, which allows neither. Code
+// inherited from another file was checked there.
+func (c *checker) checkMatches(program *ast.Program) {
+	var visit func(node ast.Node) bool
+	visit = func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.TemplateDef:
+			return false // checked where it is used
+		case *ast.FunctionDef:
+			return !c.imported[n]
+		case *ast.SkillDef:
+			c.definitionMatches(n.Uses, n.Properties, visit)
+			return false
+		case *ast.StateDef:
+			c.definitionMatches(n.Uses, n.Properties, visit)
+			return false
+		case *ast.MatchStatement:
+			if !c.unresolved[n] {
+				c.checkPatterns(n)
+			}
+		}
+		return true
+	}
+	ast.Inspect(program, visit)
+}
+
+func (c *checker) definitionMatches(uses []*ast.UseDecl, props []*ast.PropertyDef, visit func(ast.Node) bool) {
+	for _, use := range uses {
+		for _, prop := range use.Expanded {
+			ast.Inspect(prop, visit)
+		}
+	}
+	for _, prop := range props {
+		ast.Inspect(prop, visit)
+	}
+}
+
+func (c *checker) checkPatterns(s *ast.MatchStatement) {
+	seen := map[any]lexer.Position{}
+	for _, arm := range s.Arms {
+		for _, pattern := range arm.Patterns {
+			name := c.patterns[pattern.Pos()]
+			if name == "" {
+				name = patternName(pattern)
+			}
+			v, ok := constant.Of(pattern)
+			if !ok {
+				c.errorf(pattern, "pattern %s is not a constant", name)
+				continue
+			}
+			key := patternKey(v)
+			if prev, dup := seen[key]; dup {
+				if other := c.patterns[prev]; other != "" && other != name {
+					c.errorf(pattern, "pattern %s has the same value as %s", name, other)
+				} else {
+					c.errorf(pattern, "duplicate pattern %s", name)
+				}
+				continue
+			}
+			seen[key] = pattern.Pos()
+		}
+	}
+}
+
+// patternKey returns the value a pattern is compared by. As in Lua, a
+// float with an integral value equals the integer.
+func patternKey(v any) any {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+		return int64(f)
+	}
+	return v
+}
@@ -0,0 +1,623 @@
+// Package sema checks a parsed program before code generation and rewrites
+// it for the generators. For each file, in this order, it
+//
+//   - resolves consts, enum members and named functions, including those
+//     imported from other files or declared by the manifest;
+//   - expands templates and merges inherited properties;
+//   - checks every statement: names, assignments, the arguments of calls
+//     and the types of parameters and results, and the dimension of
+//     quantities such as 1.5s, 5m and 20%, so that a duration is never
+//     added to a distance;
+//   - records the variables each function captures;
+//   - lowers quantities to plain numbers in the base units of the runtime;
+//   - substitutes consts for their uses and folds constant expressions;
+//   - checks that matches are unambiguous and, over enums, exhaustive;
+//   - marks the for loops known to be bounded, which the loop budget of
+//     the generators leaves alone.
+package sema
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/constant"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/generator"
+	"github.com/hsoul/skconf/internal/lexer"
+	"github.com/hsoul/skconf/internal/manifest"
+	"github.com/hsoul/skconf/internal/units"
+)
+
+// dimension is what a value measures as far as the checker knows: a
+// units.Dimension, number for plain numbers, or unknown.
+type dimension string
+
+const (
+	unknown dimension = ""
+	number  dimension = "number"
+)
+
+func (d dimension) String() string {
+	switch d {
+	case number:
+		return "plain number"
+	case dimension(units.Percent):
+		return "percentage"
+	}
+	return string(d)
+}
+
+func (d dimension) isUnit() bool {
+	return d != unknown && d != number
+}
+
+// File is a parsed source to check. Name is its path, such as
+// lib/common.dsl, which other files import as lib.common.
+type File struct {
+	Name    string
+	Program *ast.Program
+}
+
+type checker struct {
+	file     string
+	manifest *manifest.Manifest
+	diags    []diag.Diagnostic
+	scopes   []map[string]dimension
+
+	consts    map[string]*constValue            // consts of this file
+	enums     map[string]map[string]*constValue // enums of this file by name, then member
+	imports   map[string]*exports               // declarations of imported files by import path
+	hostEnums map[string]map[string]*constValue // enums of the manifest
+	members   map[string]*constValue            // of the enum being evaluated, usable unqualified
+	defs      map[defKey]*definition            // skills and states of this file
+	templates map[string]*template              // templates of this file
+	funcs     map[string]*ast.FunctionDef       // functions of this file, including imported ones
+
+	origins    map[ast.Node]*expansion // nodes copied from a template
+	expansions int
+	imported   map[*ast.FunctionDef]bool    // functions copied from imported files
+	linked     []ast.Statement              // the same, in the order they were copied
+	fns        []*ast.FunctionDef           // being checked, innermost last
+	patterns   map[lexer.Position]string    // source text of match patterns, for messages once folded
+	unresolved map[*ast.MatchStatement]bool // matches with a pattern naming no enum member
+}
+
+// exports are the declarations a file provides to the files importing it.
+type exports struct {
+	consts    map[string]*constValue
+	enums     map[string]map[string]*constValue
+	defs      map[defKey]*definition
+	templates map[string]*template
+	funcs     map[string]*ast.FunctionDef
+}
+
+// Check checks files, each after the files it imports, and rewrites their
+// programs for code generation; the package doc lists what it does.
+func Check(files []File, m *manifest.Manifest) []diag.Diagnostic {
+	order, diags := importOrder(files)
+	if len(diags) > 0 {
+		return diags
+	}
+
+	hostEnums := map[string]map[string]*constValue{}
+	if m != nil {
+		for name, members := range m.Enums {
+			hostEnums[name] = map[string]*constValue{}
+			for member, value := range members {
+				hostEnums[name][member] = &constValue{dim: number, value: constant.Literal(value, lexer.Position{})}
+			}
+		}
+	}
+
+	provided := map[string]*exports{}
+	for _, f := range order {
+		c := &checker{
+			file:       f.Name,
+			manifest:   m,
+			consts:     map[string]*constValue{},
+			enums:      map[string]map[string]*constValue{},
+			imports:    map[string]*exports{},
+			hostEnums:  hostEnums,
+			defs:       map[defKey]*definition{},
+			templates:  map[string]*template{},
+			funcs:      map[string]*ast.FunctionDef{},
+			origins:    map[ast.Node]*expansion{},
+			imported:   map[*ast.FunctionDef]bool{},
+			patterns:   map[lexer.Position]string{},
+			unresolved: map[*ast.MatchStatement]bool{},
+		}
+		for i, imp := range f.Program.Imports {
+			if path, ok := ast.QualifiedName(imp.Value); ok && provided[path] != nil {
+				c.imports[path] = provided[path]
+				f.Program.Imports[i].Linked = true
+			}
+		}
+		c.check(f.Program)
+		provided[modulePath(f.Name)] = &exports{consts: c.consts, enums: c.enums, defs: c.defs, templates: c.templates, funcs: c.funcs}
+		diags = append(diags, c.diags...)
+	}
+	return diags
+}
+
+func (c *checker) check(program *ast.Program) {
+	c.collectConsts(program)
+	c.collectFunctions(program)
+	c.expandTemplates(program)
+	c.resolveExtends(program)
+	c.linkFunctions(program)
+	c.pushScope()
+	for _, stmt := range program.Statements {
+		if fn, ok := stmt.(*ast.FunctionDef); ok && c.imported[fn] {
+			continue // checked in its own file
+		}
+		c.statement(stmt)
+	}
+	c.popScope()
+	c.captureVariables(program)
+
+	ast.Rewrite(program, func(exp ast.Expression) ast.Expression {
+		if lit, ok := exp.(*ast.UnitLiteral); ok {
+			return c.lower(lit)
+		}
+		return exp
+	})
+
+	c.evalConsts(program)
+	ast.Rewrite(program, c.fold)
+	c.checkMatches(program)
+	c.boundLoops(program)
+}
+
+// errorf reports an error at node. An error in code copied from a template
+// is reported in the template, with a note at the use; the same error in
+// several uses is reported once, with a note for each.
+func (c *checker) errorf(node ast.Node, format string, args ...any) {
+	d := diag.Errorf(c.file, node.Pos(), format, args...)
+	if x := c.origins[node]; x != nil {
+		name, _ := ast.QualifiedName(x.use.Template)
+		d.File = x.template.file
+		d.Notes = []diag.Note{{File: c.file, Pos: x.use.Pos(), Message: fmt.Sprintf("in the use of template %s here", name)}}
+	}
+	for i, prev := range c.diags {
+		if prev.File == d.File && prev.Pos == d.Pos && prev.Message == d.Message {
+			for _, note := range d.Notes {
+				if !slices.Contains(prev.Notes, note) {
+					c.diags[i].Notes = append(c.diags[i].Notes, note)
+				}
+			}
+			return
+		}
+	}
+	c.diags = append(c.diags, d)
+}
+
+func (c *checker) pushScope() {
+	c.scopes = append(c.scopes, map[string]dimension{})
+}
+
+func (c *checker) popScope() {
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+func (c *checker) declare(name string, dim dimension) {
+	c.scopes[len(c.scopes)-1][name] = dim
+}
+
+// define declares a variable, which must not hide a const or an enum.
+func (c *checker) define(id *ast.Identifier, dim dimension) {
+	if c.consts[id.Value] != nil {
+		c.errorf(id, "%s is a const and cannot be redeclared", id.Value)
+	} else if c.enums[id.Value] != nil {
+		c.errorf(id, "%s is an enum and cannot be redeclared", id.Value)
+	}
+	c.declare(id.Value, dim)
+}
+
+func (c *checker) lookup(name string) dimension {
+	if k := c.consts[name]; k != nil {
+		return k.dim
+	}
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if dim, ok := c.scopes[i][name]; ok {
+			return dim
+		}
+	}
+	return unknown
+}
+
+func (c *checker) block(block *ast.CodeBlock) {
+	if block == nil {
+		return
+	}
+	c.pushScope()
+	for _, stmt := range block.Statements {
+		c.statement(stmt)
+	}
+	c.popScope()
+}
+
+func (c *checker) statement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.SkillDef:
+		c.uses(s.Uses)
+		c.hooks(s.Properties)
+		c.properties(s.Properties)
+	case *ast.StateDef:
+		c.uses(s.Uses)
+		c.hooks(s.Properties)
+		c.properties(s.Properties)
+	case *ast.TemplateDef:
+		c.pushScope()
+		for _, param := range s.Parameters {
+			c.define(param, unknown)
+		}
+		c.properties(s.Properties)
+		c.popScope()
+	case *ast.VarStatement:
+		dim := unknown
+		if s.Value != nil {
+			dim = c.expression(s.Value)
+		}
+		c.define(s.Name, dim)
+	case *ast.ConstStatement:
+		if k := c.consts[s.Name.Value]; k != nil {
+			k.dim = c.expression(s.Value)
+		}
+	case *ast.EnumDef:
+		for _, member := range s.Members {
+			if member.Value == nil {
+				continue
+			}
+			if dim := c.expression(member.Value); dim.isUnit() {
+				c.errorf(member.Value, "value of %s.%s is a %s, not a plain number", s.Name.Value, member.Name.Value, dim)
+			}
+		}
+	case *ast.AssignStatement:
+		c.assign(s)
+	case *ast.ExprStmt:
+		switch s.Expression.(type) {
+		case *ast.FunctionCall:
+		case *ast.FunctionDef:
+			c.diags = append(c.diags, diag.Warningf(c.file, s.Pos(), "unnamed function is never called; declare it as func name(...) to call it"))
+		default:
+			// Lua rejects such a statement, and a bare name here is often
+			// what is left of x-- followed by text, which is a comment.
+			c.errorf(s, "this expression is not a statement; only a call can stand alone")
+		}
+		c.expression(s.Expression)
+	case *ast.FunctionDef:
+		if name, ok := s.Name.(*ast.Identifier); ok {
+			c.define(name, unknown)
+		}
+		c.function(s)
+	case *ast.IfStatement:
+		c.expression(s.Condition)
+		c.block(s.Consequence)
+		for _, alt := range s.Alternatives {
+			if alt.Condition != nil {
+				c.expression(alt.Condition)
+			}
+			c.block(alt.Consequence)
+		}
+	case *ast.ReturnStatement:
+		if s.ReturnValue != nil {
+			dim := c.expression(s.ReturnValue)
+			if len(c.fns) > 0 {
+				typ := c.fns[len(c.fns)-1].ReturnType()
+				if got := mismatch(s.ReturnValue, dim, typ); got != "" {
+					c.errorf(s.ReturnValue, "cannot return %s from a function returning %s", got, typ)
+				}
+			}
+		}
+	case *ast.MatchStatement:
+		c.match(s)
+	case *ast.ForStatement:
+		c.pushScope()
+		if s.Init != nil {
+			c.statement(s.Init)
+		}
+		if s.Condition != nil {
+			c.expression(s.Condition)
+		}
+		if s.Post != nil {
+			c.statement(s.Post)
+		}
+		if s.RangeValue != nil {
+			c.expression(s.RangeValue)
+		}
+		if s.Key != nil {
+			c.define(s.Key, unknown)
+		}
+		if s.Value != nil {
+			c.define(s.Value, unknown)
+		}
+		c.block(s.Body)
+		c.popScope()
+	case *ast.WhileStatement:
+		c.expression(s.Condition)
+		c.block(s.Body)
+	case *ast.RepeatStatement:
+		c.block(s.Body)
+		c.expression(s.Condition)
+	}
+}
+
+func (c *checker) properties(props []*ast.PropertyDef) {
+	for _, prop := range props {
+		if prop.Key != nil {
+			if _, ok := prop.Key.(*ast.Identifier); !ok {
+				c.expression(prop.Key)
+			}
+		}
+		c.expression(prop.Value)
+	}
+}
+
+// uses checks the arguments of template uses and the properties they
+// expand to, in which the dimensions of the arguments are known.
+func (c *checker) uses(uses []*ast.UseDecl) {
+	for _, use := range uses {
+		for _, arg := range use.Arguments {
+			c.expression(arg)
+		}
+		c.hooks(use.Expanded)
+		c.properties(use.Expanded)
+	}
+}
+
+func (c *checker) function(fn *ast.FunctionDef) {
+	c.annotations(fn)
+	c.fns = append(c.fns, fn)
+	defer func() { c.fns = c.fns[:len(c.fns)-1] }()
+	c.pushScope()
+	for i, param := range fn.Parameters {
+		c.define(param, typeDimension(fn.ParamType(i)))
+	}
+	c.block(fn.Body)
+	c.popScope()
+}
+
+// assign checks an assignment against the dimension of its target. Only
+// variables have a known dimension; members and elements are unknown.
+func (c *checker) assign(s *ast.AssignStatement) {
+	target := unknown
+	if id, ok := s.Target.(*ast.Identifier); ok {
+		if c.consts[id.Value] != nil {
+			c.errorf(s, "cannot assign to const %s", id.Value)
+		} else if c.fileFunction(id.Value) != nil {
+			c.errorf(s, "cannot assign to function %s", id.Value)
+		}
+		target = c.lookup(id.Value)
+	} else {
+		if k, enum, member := c.enumMember(s.Target); k != nil {
+			c.errorf(s, "cannot assign to enum member %s.%s", enum, member)
+		} else if k, ref, _ := c.importedConst(s.Target); k != nil {
+			c.errorf(s, "cannot assign to const %s", ref)
+		}
+		c.expression(s.Target)
+	}
+
+	op, operand := s.Update()
+	value := c.expression(operand)
+	if op != "" {
+		c.binary(s, op, target, value)
+		return
+	}
+	if target != unknown && value != unknown && target != value {
+		c.errorf(s, "cannot assign a %s to %s, a %s", value, s.Target, target)
+	}
+}
+
+// expression checks exp and returns its dimension.
+func (c *checker) expression(exp ast.Expression) dimension {
+	switch e := exp.(type) {
+	case *ast.UnitLiteral:
+		u, _ := units.Lookup(e.Unit)
+		return dimension(u.Dimension)
+	case *ast.Integer, *ast.Float:
+		return number
+	case *ast.Identifier:
+		return c.lookup(e.Value)
+	case *ast.InterpolatedString:
+		for _, part := range e.Parts {
+			c.expression(part)
+		}
+	case *ast.TableDef:
+		c.properties(e.Properties)
+	case *ast.FunctionDef:
+		c.function(e)
+	case *ast.PrefixExpression:
+		right := c.expression(e.Right)
+		switch e.Operator {
+		case "-":
+			return right
+		case "~":
+			if right.isUnit() {
+				c.errorf(e, "operator ~ needs a plain number, got a %s", right)
+			}
+			return right
+		}
+	case *ast.InfixExpression:
+		left := c.expression(e.Left)
+		right := c.expression(e.Right)
+		return c.binary(e, e.Operator, left, right)
+	case *ast.DotExpression:
+		if k, enum, member := c.enumMember(e); enum != "" {
+			if k == nil {
+				c.errorf(e, "%s has no member %s", enum, member)
+				return unknown
+			}
+			return number
+		}
+		if k, ref, ok := c.importedConst(e); ok {
+			if k == nil {
+				c.errorf(e, "%s is not a const of an imported file", ref)
+				return unknown
+			}
+			return k.dim
+		}
+		c.expression(e.Left)
+	case *ast.IndexExpression:
+		c.expression(e.Left)
+		c.expression(e.Index)
+	case *ast.ConditionalExpression:
+		c.expression(e.Condition)
+		then := c.expression(e.Consequence)
+		otherwise := c.expression(e.Alternative)
+		if then != unknown && otherwise != unknown && then != otherwise {
+			c.errorf(e, "branches of ?: are a %s and a %s", then, otherwise)
+		}
+		if then != otherwise {
+			return unknown
+		}
+		return then
+	case *ast.FunctionCall:
+		return c.call(e)
+	}
+	return unknown
+}
+
+// binary returns the dimension of left op right. Quantities of the same
+// dimension add, subtract and compare; a quantity scales by a plain number
+// or a percentage, and dividing two of the same dimension gives a plain
+// number.
+func (c *checker) binary(node ast.Node, op string, left, right dimension) dimension {
+	switch op {
+	case "and", "or":
+		return unknown
+	case "+", "-", "%":
+		if left != unknown && right != unknown && left != right {
+			c.errorf(node, "cannot use %s on a %s and a %s", op, left, right)
+		}
+		if left != right {
+			return unknown
+		}
+		return left
+	case "==", "!=", "<", ">", "<=", ">=":
+		if left != unknown && right != unknown && left != right {
+			c.errorf(node, "cannot compare a %s with a %s", left, right)
+		}
+		return unknown
+	case "*":
+		switch {
+		case left == unknown || right == unknown:
+			return unknown
+		case right == number || right == dimension(units.Percent):
+			return left
+		case left == number || left == dimension(units.Percent):
+			return right
+		}
+		c.errorf(node, "cannot multiply a %s by a %s", left, right)
+		return unknown
+	case "/", "//":
+		switch {
+		case left == unknown || right == unknown:
+			return unknown
+		case left == right:
+			return number
+		case right == number || right == dimension(units.Percent):
+			return left
+		}
+		c.errorf(node, "cannot divide a %s by a %s", left, right)
+		return unknown
+	}
+
+	// ^ and the bitwise operators.
+	for _, dim := range []dimension{left, right} {
+		if dim.isUnit() {
+			c.errorf(node, "operator %s needs plain numbers, got a %s", op, dim)
+			return unknown
+		}
+	}
+	if left == number && right == number {
+		return number
+	}
+	return unknown
+}
+
+// call checks a call of a host function against its manifest signature:
+// the function must be declared, take that many arguments and arguments of
+// the dimensions it declares. It returns the dimension of the result.
+func (c *checker) call(call *ast.FunctionCall) dimension {
+	c.expression(call.Function)
+	args := make([]dimension, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		args[i] = c.expression(arg)
+	}
+
+	if id, ok := call.Function.(*ast.Identifier); ok {
+		if fn := c.fileFunction(id.Value); fn != nil {
+			c.callFunction(call, id.Value, fn, args)
+			return typeDimension(fn.ReturnType())
+		}
+	}
+
+	module, name, ok := generator.HostCall(call, c.manifest)
+	if !ok {
+		return unknown
+	}
+	if c.manifest == nil {
+		return unknown // the default modules, whose functions are not declared
+	}
+	sig, ok := c.manifest.Function(module, name)
+	if !ok {
+		c.errorf(call.Function, "%s.%s is not in the API manifest", module, name)
+		return unknown
+	}
+	if !sig.Variadic && len(args) != len(sig.Params) || sig.Variadic && len(args) < len(sig.Params)-1 {
+		c.errorf(call.Function, "%s.%s takes %d arguments, got %d", module, name, len(sig.Params), len(args))
+		return unknown
+	}
+	for i, arg := range args {
+		var param manifest.Param
+		switch {
+		case i < len(sig.Params):
+			param = sig.Params[i]
+		case sig.Variadic:
+			param = sig.Params[len(sig.Params)-1]
+		default:
+			continue
+		}
+		if want := dimension(param.Type); units.IsDimension(param.Type) && arg != unknown && arg != want {
+			c.errorf(call.Arguments[i], "%s.%s: argument %d (%s) must be a %s, got a %s", module, name, i+1, param.Name, want, arg)
+		}
+	}
+	if units.IsDimension(sig.Returns) {
+		return dimension(sig.Returns)
+	}
+	return unknown
+}
+
+// lower converts a quantity to its base unit. The conversion starts from
+// the literal as written, so 0.1s is exactly 100ms.
+func (c *checker) lower(lit *ast.UnitLiteral) ast.Expression {
+	u, _ := units.Lookup(lit.Unit)
+	base := c.manifest.Base(u.Dimension)
+
+	written := strings.ReplaceAll(strings.TrimSuffix(lit.Token.Literal, lit.Unit), "_", "")
+	value, ok := new(big.Rat).SetString(written)
+	if !ok {
+		value = new(big.Rat)
+		value.SetFloat64(lit.Value)
+	}
+
+	converted, err := base.Convert(value, u)
+	if err != nil {
+		c.errorf(lit, "%s %v", lit.Token.Literal, err)
+		return &ast.Float{BaseNode: lit.BaseNode, Value: lit.Value}
+	}
+
+	tok := lit.Token
+	switch v := converted.(type) {
+	case int64:
+		tok.Type, tok.Literal = lexer.INTEGER, fmt.Sprint(v)
+		return &ast.Integer{BaseNode: ast.BaseNode{Token: tok}, Value: v}
+	default:
+		f := v.(float64)
+		tok.Type, tok.Literal = lexer.FLOAT, fmt.Sprint(f)
+		return &ast.Float{BaseNode: ast.BaseNode{Token: tok}, Value: f}
+	}
+}
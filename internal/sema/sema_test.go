@@ -0,0 +1,155 @@
+package sema
+
+import (
+	"testing"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/lexer"
+	"github.com/hsoul/skconf/internal/syntax"
+)
+
+// check parses src as s.dsl and checks it on its own.
+func check(t *testing.T, src string) (*ast.Program, []diag.Diagnostic) {
+	t.Helper()
+	p := syntax.New(lexer.New(src), "s.dsl")
+	program := p.ParseProgram()
+	if diags := p.Diagnostics(); len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	return program, Check([]File{{Name: "s.dsl", Program: program}}, nil)
+}
+
+type want struct {
+	line    int // 0 if src is expected to check cleanly
+	message string
+}
+
+// expect checks that diags is the one diagnostic w, or none if w is zero.
+func expect(t *testing.T, diags []diag.Diagnostic, w want) {
+	t.Helper()
+	if w.line == 0 {
+		if len(diags) > 0 {
+			t.Errorf("got %v, want no diagnostics", diags)
+		}
+		return
+	}
+	if len(diags) != 1 || diags[0].Pos.Line != w.line || diags[0].Message != w.message {
+		t.Errorf("got %v, want %q at line %d", diags, w.message, w.line)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name, arms string
+		want       want
+	}{
+		{"every member", "E.A => {}\nE.B => {}\nC => {}", want{}},
+		{"default arm", "E.A => {}\n_ => {}", want{}},
+		{"missing members", "E.A => {}", want{4, "match over E is missing B, C; add arms for them or a _ arm"}},
+		{"bare names", "A | B => {}\nC => {}", want{}},
+		{"bare name of another enum", "D => {}\n_ => {}", want{}},
+		{"ambiguous", "A => {}\n_ => {}", want{5, "A could be a member of E or F; qualify it"}},
+		{"resolved by a qualified pattern", "A => {}\nF.D => {}", want{}},
+		{"duplicate", "A => {}\nE.A => {}\n_ => {}", want{6, "duplicate pattern E.A"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "enum E { A, B, C }\nenum F { A, D }\nfunc f(x) {\n  match x {\n" + tt.arms + "\n  }\n}\n"
+			_, diags := check(t, src)
+			expect(t, diags, tt.want)
+		})
+	}
+}
+
+func TestInheritanceCycle(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      want
+	}{
+		{"chain", "skill a extends b { tid = 1, }\nskill b { tid = 2, }\n", want{}},
+		{"self", "skill a extends a { tid = 1, }\n", want{1, "inheritance cycle: a -> a"}},
+		{"loop", "skill a extends b { tid = 1, }\nskill b extends c { tid = 2, }\nskill c extends a { tid = 3, }\n",
+			want{3, "inheritance cycle: a -> b -> c -> a"}},
+		{"skills and states apart", "skill a extends a2 { tid = 1, }\nskill a2 { tid = 2, }\nstate a2 extends a { tid = 3, }\nstate a { tid = 4, }\n", want{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, diags := check(t, tt.src)
+			expect(t, diags, tt.want)
+		})
+	}
+}
+
+// A local of a template that an argument names is renamed, so the
+// argument keeps referring to the variable of the use site.
+func TestTemplateHygiene(t *testing.T) {
+	src := "template dot(dmg) {\n  tick = func(t) {\n    var hits = 1\n    UF.Damage(t, dmg + hits)\n  },\n}\n" +
+		"var hits = 5\nskill s {\n  tid = 1,\n  use dot(hits),\n}\n"
+	program, diags := check(t, src)
+	expect(t, diags, want{})
+
+	skill := program.Statements[2].(*ast.SkillDef)
+	body := skill.MergedProperties()[0].Value.(*ast.FunctionDef).Body.Statements
+	local := body[0].(*ast.VarStatement).Name.Value
+	if local == "hits" {
+		t.Fatal("the local of the template is not renamed")
+	}
+	call := body[1].(*ast.ExprStmt).Expression.(*ast.FunctionCall)
+	if got, want := ast.Format(call.Arguments[1]), "hits + "+local; got != want {
+		t.Errorf("argument is %s, want %s", got, want)
+	}
+}
+
+func TestTemplateParameterAssignment(t *testing.T) {
+	src := "template dot(dmg) {\n  tick = func(t) {\n    dmg = 2\n  },\n}\nskill s {\n  tid = 1,\n  use dot(3),\n}\n"
+	_, diags := check(t, src)
+	expect(t, diags, want{3, "cannot assign to dmg, a parameter of the template"})
+}
+
+func TestLoopCapture(t *testing.T) {
+	tests := []struct {
+		name, body string
+		want       want
+	}{
+		{"captured", "UF.Later(func() { UF.Log(i) })", want{3, "function captures loop variable i, which all iterations share; copy it with var inside the loop to capture each value"}},
+		{"copied", "var j = i\n    UF.Later(func() { UF.Log(j) })", want{}},
+		{"not captured", "UF.Log(i)", want{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "func f() {\n  for var i = 1; i <= 3; i = i + 1 {\n    " + tt.body + "\n  }\n}\n"
+			_, diags := check(t, src)
+			expect(t, diags, tt.want)
+		})
+	}
+}
+
+func TestBoundLoops(t *testing.T) {
+	tests := []struct {
+		name, loop string
+		bounded    bool
+	}{
+		{"range", "for k, v = range t {", true},
+		{"counting up", "for var i = 1; i <= 10; i = i + 1 {", true},
+		{"counting down", "for var i = 10; i > 0; i -= 2 {", true},
+		{"const limit", "for var i = 0; i < N; i += 1 {", true},
+		{"wrong direction", "for var i = 0; i < 10; i -= 1 {", false},
+		{"zero step", "for var i = 0; i < 10; i += 0 {", false},
+		{"host limit", "for var i = 0; i < UE.Count(); i += 1 {", false},
+		{"assigned in the body", "for var i = 0; i < 10; i += 1 {\n    i = 0", false},
+		{"fractional step", "for var i = 0; i < 1; i += 0.25 {", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "const N = 5\nfunc f(t) {\n  " + tt.loop + "\n  }\n}\n"
+			program, diags := check(t, src)
+			expect(t, diags, want{})
+			fn := program.Statements[1].(*ast.FunctionDef)
+			loop := fn.Body.Statements[0].(*ast.ForStatement)
+			if loop.Bounded != tt.bounded {
+				t.Errorf("Bounded = %v, want %v", loop.Bounded, tt.bounded)
+			}
+		})
+	}
+}
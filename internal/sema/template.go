@@ -0,0 +1,275 @@
+package sema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+)
+
+// template is a template declared in file. def is a copy made before the
+// file is checked, so that uses in other files see quantities such as 1s
+// rather than their lowered values.
+type template struct {
+	def  *ast.TemplateDef
+	file string
+}
+
+// expansion is a copy of the properties of a template made for a use, so
+// that errors in it are reported in the template and at the use.
+type expansion struct {
+	use      *ast.UseDecl
+	template *template
+}
+
+// expandTemplates collects the templates of program and fills in the
+// properties of every use in its skills and states.
+func (c *checker) expandTemplates(program *ast.Program) {
+	for _, stmt := range program.Statements {
+		if s, ok := stmt.(*ast.TemplateDef); ok {
+			if c.templates[s.Name.Value] != nil {
+				c.errorf(s.Name, "template %s redeclared", s.Name.Value)
+				continue
+			}
+			c.templates[s.Name.Value] = &template{def: ast.Clone(s), file: c.file}
+		}
+	}
+
+	for _, stmt := range program.Statements {
+		var uses []*ast.UseDecl
+		switch s := stmt.(type) {
+		case *ast.SkillDef:
+			uses = s.Uses
+		case *ast.StateDef:
+			uses = s.Uses
+		}
+		for _, use := range uses {
+			c.expand(use)
+		}
+	}
+}
+
+// expand copies the properties of the template use names into
+// use.Expanded, with the arguments of use in place of the parameters.
+// Locals of the template that would hide a name an argument refers to are
+// renamed, so that the argument still means what it does at the use, and
+// the declarations of the file of an imported template are qualified with
+// its import path, so that they still mean what they do in the template.
+func (c *checker) expand(use *ast.UseDecl) {
+	ref, _ := ast.QualifiedName(use.Template)
+	t, from, path := c.templateOf(ref)
+	if t == nil {
+		c.errorf(use.Template, "unknown template %s", ref)
+		return
+	}
+	if len(use.Arguments) != len(t.def.Parameters) {
+		d := diag.Errorf(c.file, use.Template.Pos(), "template %s takes %d arguments, got %d", ref, len(t.def.Parameters), len(use.Arguments))
+		d.Notes = append(d.Notes, diag.Note{File: t.file, Pos: t.def.Name.Pos(), Message: fmt.Sprintf("template %s is declared here", ref)})
+		c.diags = append(c.diags, d)
+		return
+	}
+
+	c.expansions++
+	x := &expansion{use: use, template: t}
+	h := c.newHygiene(from, path)
+	h.suffix = fmt.Sprintf("__%d", c.expansions)
+	for i, param := range t.def.Parameters {
+		h.params[param.Value] = use.Arguments[i]
+	}
+	for _, arg := range use.Arguments {
+		ast.Inspect(arg, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Identifier); ok {
+				h.capture[id.Value] = true
+			}
+			return true
+		})
+	}
+
+	use.Expanded = make([]*ast.PropertyDef, len(t.def.Properties))
+	for i, prop := range t.def.Properties {
+		prop = ast.Clone(prop)
+		ast.Inspect(prop, func(n ast.Node) bool {
+			c.origins[n] = x
+			return true
+		})
+		h.apply(prop)
+		use.Expanded[i] = prop
+	}
+}
+
+// templateOf returns the template named ref, declared in this file or in an
+// imported one, with the exports and the path of the file it is imported
+// from.
+func (c *checker) templateOf(ref string) (t *template, from *exports, path string) {
+	if t := c.templates[ref]; t != nil {
+		return t, nil, ""
+	}
+	for path, exports := range c.imports {
+		if name, found := strings.CutPrefix(ref, path+"."); found {
+			if t := exports.templates[name]; t != nil {
+				return t, exports, path
+			}
+		}
+	}
+	return nil, nil, ""
+}
+
+// hygiene resolves the names in code copied from a template or an imported
+// file: it marks the references to template parameters in refs and those
+// to consts, enums and functions of an imported file in qualify, and
+// renames the locals the arguments would otherwise see instead of their
+// own names.
+type hygiene struct {
+	checker *checker
+	params  map[string]ast.Expression // argument by parameter name
+	capture map[string]bool           // names the arguments refer to
+	suffix  string
+	scopes  []map[string]string // name of every local in the copy, by name in the template
+	refs    map[*ast.Identifier]bool
+
+	from    *exports // of the file the code is imported from, if any
+	path    string   // import path of that file, such as lib.common
+	qualify map[*ast.Identifier]bool
+}
+
+func (c *checker) newHygiene(from *exports, path string) *hygiene {
+	return &hygiene{
+		checker: c,
+		params:  map[string]ast.Expression{},
+		capture: map[string]bool{},
+		refs:    map[*ast.Identifier]bool{},
+		from:    from,
+		path:    path,
+		qualify: map[*ast.Identifier]bool{},
+	}
+}
+
+// apply resolves the names in node, then substitutes the arguments for the
+// parameters and qualifies the references to the imported file.
+func (h *hygiene) apply(node ast.Node) {
+	h.push()
+	ast.Inspect(node, h.visit)
+	h.pop()
+	ast.Rewrite(node, func(exp ast.Expression) ast.Expression {
+		id, ok := exp.(*ast.Identifier)
+		switch {
+		case !ok:
+		case h.refs[id]:
+			return ast.Clone(h.params[id.Value])
+		case h.qualify[id]:
+			return &ast.DotExpression{BaseNode: id.BaseNode, Left: qualifiedName(h.path, id.BaseNode), Right: id}
+		}
+		return exp
+	})
+}
+
+// qualifiedName builds the expression of a dotted name such as lib.common.
+func qualifiedName(name string, base ast.BaseNode) ast.Expression {
+	parts := strings.Split(name, ".")
+	var exp ast.Expression = &ast.Identifier{BaseNode: base, Value: parts[0]}
+	for _, part := range parts[1:] {
+		exp = &ast.DotExpression{BaseNode: base, Left: exp, Right: &ast.Identifier{BaseNode: base, Value: part}}
+	}
+	return exp
+}
+
+func (h *hygiene) push() {
+	h.scopes = append(h.scopes, map[string]string{})
+}
+
+func (h *hygiene) pop() {
+	h.scopes = h.scopes[:len(h.scopes)-1]
+}
+
+func (h *hygiene) declare(id *ast.Identifier) {
+	name := id.Value
+	if h.capture[name] {
+		name += h.suffix
+	}
+	h.scopes[len(h.scopes)-1][id.Value] = name
+	id.Value = name
+}
+
+// resolve renames a reference to a local, or marks a reference to a
+// parameter or to a declaration of the imported file.
+func (h *hygiene) resolve(id *ast.Identifier) {
+	for i := len(h.scopes) - 1; i >= 0; i-- {
+		if name, ok := h.scopes[i][id.Value]; ok {
+			id.Value = name
+			return
+		}
+	}
+	if _, ok := h.params[id.Value]; ok {
+		h.refs[id] = true
+	} else if h.from != nil && (h.from.consts[id.Value] != nil || h.from.enums[id.Value] != nil || h.from.funcs[id.Value] != nil) {
+		h.qualify[id] = true
+	}
+}
+
+// visit is the ast.Inspect callback of the walk, which handles the nodes
+// that declare names itself.
+func (h *hygiene) visit(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.Identifier:
+		h.resolve(n)
+	case *ast.PropertyDef:
+		if _, isName := n.Key.(*ast.Identifier); !isName { // a field name, not a reference
+			ast.Inspect(n.Key, h.visit)
+		}
+		ast.Inspect(n.Value, h.visit)
+		return false
+	case *ast.DotExpression:
+		ast.Inspect(n.Left, h.visit) // Right is a member name
+		return false
+	case *ast.AssignStatement:
+		if id, ok := n.Target.(*ast.Identifier); ok {
+			h.resolve(id)
+			if h.refs[id] {
+				h.checker.errorf(n, "cannot assign to %s, a parameter of the template", id.Value)
+			}
+			ast.Inspect(n.Value, h.visit)
+			return false
+		}
+	case *ast.CodeBlock:
+		h.push()
+		for _, stmt := range n.Statements {
+			if fn, ok := stmt.(*ast.FunctionDef); ok {
+				if name, ok := fn.Name.(*ast.Identifier); ok {
+					h.declare(name)
+				}
+			}
+			ast.Inspect(stmt, h.visit)
+		}
+		h.pop()
+		return false
+	case *ast.FunctionDef:
+		h.push()
+		for _, param := range n.Parameters {
+			h.declare(param)
+		}
+		ast.Inspect(n.Body, h.visit)
+		h.pop()
+		return false
+	case *ast.VarStatement:
+		ast.Inspect(n.Value, h.visit)
+		h.declare(n.Name)
+		return false
+	case *ast.ForStatement:
+		h.push()
+		ast.Inspect(n.Init, h.visit)
+		ast.Inspect(n.Condition, h.visit)
+		ast.Inspect(n.Post, h.visit)
+		ast.Inspect(n.RangeValue, h.visit)
+		if n.Key != nil {
+			h.declare(n.Key)
+		}
+		if n.Value != nil {
+			h.declare(n.Value)
+		}
+		ast.Inspect(n.Body, h.visit)
+		h.pop()
+		return false
+	}
+	return true
+}
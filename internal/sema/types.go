@@ -0,0 +1,116 @@
+package sema
+
+import (
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/units"
+)
+
+// typeDimension returns the dimension of values of the manifest type typ:
+// its own for a dimension, number for int and float, unknown otherwise.
+func typeDimension(typ string) dimension {
+	switch {
+	case units.IsDimension(typ):
+		return dimension(typ)
+	case typ == "int" || typ == "float":
+		return number
+	}
+	return unknown
+}
+
+// literalType returns the manifest type of a literal, or "" for anything
+// else, whose type is only known at runtime.
+func literalType(exp ast.Expression) string {
+	switch exp.(type) {
+	case *ast.Integer:
+		return "int"
+	case *ast.Float:
+		return "float"
+	case *ast.String, *ast.InterpolatedString:
+		return "string"
+	case *ast.Boolean:
+		return "bool"
+	case *ast.TableDef:
+		return "table"
+	}
+	return ""
+}
+
+// mismatch describes what exp, of dimension dim, is if it cannot be a value
+// of type typ, and returns "" if it can or is not known well enough to
+// tell.
+func mismatch(exp ast.Expression, dim dimension, typ string) string {
+	if typ == "" || typ == "any" {
+		return ""
+	}
+	if want := typeDimension(typ); want != unknown && dim != unknown {
+		if dim != want {
+			return dim.String()
+		}
+		return ""
+	}
+	switch lit := literalType(exp); {
+	case lit == "" || lit == typ:
+		return ""
+	case lit == "int" && typ == "float":
+		return ""
+	default:
+		return lit
+	}
+}
+
+// annotations checks that the types annotated on fn exist.
+func (c *checker) annotations(fn *ast.FunctionDef) {
+	check := func(typ *ast.Identifier) {
+		if typ != nil && !c.manifest.IsType(typ.Value) {
+			c.errorf(typ, "unknown type %s", typ.Value)
+		}
+	}
+	for _, typ := range fn.Types {
+		check(typ)
+	}
+	check(fn.Returns)
+}
+
+// hooks checks the function-valued properties of a skill or state that
+// the manifest declares a hook signature for, and gives their parameters
+// and result the types of the signature where they are not annotated.
+func (c *checker) hooks(props []*ast.PropertyDef) {
+	for _, prop := range props {
+		key, ok := prop.Key.(*ast.Identifier)
+		if !ok {
+			continue
+		}
+		fn, ok := prop.Value.(*ast.FunctionDef)
+		if !ok {
+			continue
+		}
+		sig, ok := c.manifest.Hook(key.Value)
+		if !ok {
+			continue
+		}
+
+		if len(fn.Parameters) != len(sig.Params) {
+			c.errorf(fn, "hook %s takes %d parameters in the API manifest, got %d", key.Value, len(sig.Params), len(fn.Parameters))
+			continue
+		}
+		if len(fn.Types) == 0 && len(fn.Parameters) > 0 {
+			fn.Types = make([]*ast.Identifier, len(fn.Parameters))
+		}
+		for i, param := range sig.Params {
+			switch typ := fn.ParamType(i); {
+			case param.Type == "" || param.Type == "any":
+			case typ == "":
+				fn.Types[i] = &ast.Identifier{BaseNode: fn.Parameters[i].BaseNode, Value: param.Type}
+			case typ != param.Type:
+				c.errorf(fn.Types[i], "parameter %s of hook %s has type %s in the API manifest, not %s", fn.Parameters[i].Value, key.Value, param.Type, typ)
+			}
+		}
+		switch typ := fn.ReturnType(); {
+		case sig.Returns == "" || sig.Returns == "any":
+		case typ == "":
+			fn.Returns = &ast.Identifier{BaseNode: fn.BaseNode, Value: sig.Returns}
+		case typ != sig.Returns:
+			c.errorf(fn.Returns, "hook %s returns %s in the API manifest, not %s", key.Value, sig.Returns, typ)
+		}
+	}
+}
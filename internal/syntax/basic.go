@@ -3,43 +3,132 @@ package syntax
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hsoul/skconf/internal/ast"
 	"github.com/hsoul/skconf/internal/lexer"
+	"github.com/hsoul/skconf/internal/units"
 )
 
 func (p *Parser) parseInteger() ast.Expression {
-	lit := &ast.Integer{BaseNode: ast.BaseNode{Token: p.curToken}}
+	return p.parseIntegerLiteral(p.curToken, "")
+}
+
+// parseIntegerLiteral converts an integer token, prefixed by sign. Hex
+// literals wrap around as in Lua, so 0xFFFFFFFFFFFFFFFF is -1; a decimal
+// literal outside the int64 range is an error.
+func (p *Parser) parseIntegerLiteral(tok lexer.Token, sign string) ast.Expression {
+	digits := strings.ReplaceAll(tok.Literal, "_", "")
+
+	var value int64
+	if hex, ok := cutHexPrefix(digits); ok {
+		u, err := strconv.ParseUint(hex, 16, 64)
+		if err != nil {
+			p.AddErrorMsg(&tok, fmt.Sprintf("integer literal %s does not fit in 64 bits", tok.Literal))
+			return nil
+		}
+		value = int64(u)
+		if sign == "-" {
+			value = -value
+		}
+	} else {
+		v, err := strconv.ParseInt(sign+digits, 10, 64)
+		if err != nil {
+			p.AddErrorMsg(&tok, fmt.Sprintf("integer literal %s%s overflows int64", sign, tok.Literal))
+			return nil
+		}
+		value = v
+	}
+
+	tok.Literal = sign + tok.Literal
+	return &ast.Integer{BaseNode: ast.BaseNode{Token: tok}, Value: value}
+}
 
-	value, err := strconv.ParseInt(p.curToken.Literal, 10, 64)
+func (p *Parser) parseFloat() ast.Expression {
+	return p.parseFloatLiteral(p.curToken, "")
+}
+
+// parseFloatLiteral converts a float token, prefixed by sign.
+func (p *Parser) parseFloatLiteral(tok lexer.Token, sign string) ast.Expression {
+	value, err := strconv.ParseFloat(sign+strings.ReplaceAll(tok.Literal, "_", ""), 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as int", p.curToken.Literal)
-		p.AddErrorMsg(&p.curToken, msg)
+		p.AddErrorMsg(&tok, fmt.Sprintf("float literal %s%s overflows float64", sign, tok.Literal))
 		return nil
 	}
 
-	lit.Value = value
-	return lit
+	tok.Literal = sign + tok.Literal
+	return &ast.Float{BaseNode: ast.BaseNode{Token: tok}, Value: value}
 }
 
-func (p *Parser) parseFloat() ast.Expression {
-	lit := &ast.Float{BaseNode: ast.BaseNode{Token: p.curToken}}
+func (p *Parser) parseUnit() ast.Expression {
+	return p.parseUnitLiteral(p.curToken, "")
+}
 
-	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
+// parseUnitLiteral converts a quantity token such as 1.5s or 20%,
+// prefixed by sign. The unit is the trailing run of letters, or %.
+func (p *Parser) parseUnitLiteral(tok lexer.Token, sign string) ast.Expression {
+	number := strings.TrimRightFunc(tok.Literal, func(r rune) bool {
+		return r == '%' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z'
+	})
+	unit := tok.Literal[len(number):]
+	if _, ok := units.Lookup(unit); !ok {
+		p.AddErrorMsg(&tok, fmt.Sprintf("unknown unit %q in %s (known units: %s)", unit, tok.Literal, strings.Join(units.Names(), ", ")))
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(sign+strings.ReplaceAll(number, "_", ""), 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as float", p.curToken.Literal)
-		p.AddErrorMsg(&p.curToken, msg)
+		p.AddErrorMsg(&tok, fmt.Sprintf("quantity %s%s overflows float64", sign, tok.Literal))
 		return nil
 	}
 
-	lit.Value = value
-	return lit
+	tok.Literal = sign + tok.Literal
+	return &ast.UnitLiteral{BaseNode: ast.BaseNode{Token: tok}, Value: value, Unit: unit}
+}
+
+func cutHexPrefix(s string) (string, bool) {
+	if hex, ok := strings.CutPrefix(s, "0x"); ok {
+		return hex, true
+	}
+	return strings.CutPrefix(s, "0X")
 }
 
 func (p *Parser) parseString() ast.Expression {
 	return &ast.String{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
 }
 
+// parseInterpolatedString parses a string with ${...} parts. The lexer
+// splits it into a STRINGHEAD, a STRINGMID between two parts and a
+// STRINGTAIL, each carrying the text around the parts.
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	exp := &ast.InterpolatedString{BaseNode: ast.BaseNode{Token: p.curToken}}
+	for {
+		if p.curToken.Literal != "" {
+			exp.Parts = append(exp.Parts, &ast.String{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal})
+		}
+		if p.curTokenIs(lexer.STRINGTAIL) {
+			return exp
+		}
+
+		p.nextToken() // consume the text before ${
+		if p.curTokenIs(lexer.STRINGMID) || p.curTokenIs(lexer.STRINGTAIL) {
+			p.AddErrorMsg(&p.curToken, "empty ${} in string")
+			return nil
+		}
+		part := p.parseExpression(LOWEST)
+		if part == nil {
+			return nil
+		}
+		exp.Parts = append(exp.Parts, part)
+
+		if p.peekTokenIs(lexer.STRINGMID) {
+			p.nextToken()
+		} else if !p.expectPeek(lexer.STRINGTAIL) {
+			return nil
+		}
+	}
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	return &ast.Boolean{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curTokenIs(lexer.TRUE)}
 }
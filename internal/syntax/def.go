@@ -1,6 +1,8 @@
 package syntax
 
 import (
+	"fmt"
+
 	"github.com/hsoul/skconf/internal/ast"
 	"github.com/hsoul/skconf/internal/lexer"
 )
@@ -11,6 +13,7 @@ func (p *Parser) parseSkillDefinition() *ast.SkillDef {
 			Token: p.curToken,
 		},
 		Properties: []*ast.PropertyDef{},
+		Doc:        p.curDoc,
 	}
 
 	if !p.expectPeek(lexer.IDENTIFIER) {
@@ -24,6 +27,11 @@ func (p *Parser) parseSkillDefinition() *ast.SkillDef {
 		Value: p.curToken.Literal,
 	}
 
+	var ok bool
+	if skill.Parent, ok = p.parseExtends(); !ok {
+		return nil
+	}
+
 	if !p.expectPeek(lexer.LBRACE) {
 		return nil
 	}
@@ -36,9 +44,23 @@ func (p *Parser) parseSkillDefinition() *ast.SkillDef {
 			continue
 		}
 
+		if p.curTokenIs(lexer.ILLEGAL) {
+			p.AddErrorMsg(&p.curToken, p.curToken.Literal)
+			return nil
+		}
+
+		if p.curTokenIs(lexer.USE) {
+			use := p.parseUse()
+			if use == nil {
+				return nil
+			}
+			skill.Uses = append(skill.Uses, use)
+		}
+
 		if p.curTokenIs(lexer.IDENTIFIER) {
 			name := p.curToken.Literal
 			identifier := p.curToken
+			doc := p.curDoc
 			if p.peekTokenIs(lexer.ASSIGN) {
 				p.nextToken() // skip the "="
 				p.nextToken()
@@ -54,6 +76,7 @@ func (p *Parser) parseSkillDefinition() *ast.SkillDef {
 								Value: name,
 							},
 							Value: expr,
+							Doc:   doc,
 						})
 					}
 				} else {
@@ -72,8 +95,10 @@ func (p *Parser) parseSkillDefinition() *ast.SkillDef {
 						skill.Properties = append(skill.Properties, &ast.PropertyDef{
 							Key:   key,
 							Value: method,
+							Doc:   doc,
 						})
 						fn.Name = key
+						docFunction(fn, doc)
 					}
 				}
 			}
@@ -99,6 +124,7 @@ func (p *Parser) parseStateDefinition() *ast.StateDef {
 			Token: p.curToken,
 		},
 		Properties: []*ast.PropertyDef{},
+		Doc:        p.curDoc,
 	}
 
 	if !p.expectPeek(lexer.IDENTIFIER) {
@@ -112,6 +138,11 @@ func (p *Parser) parseStateDefinition() *ast.StateDef {
 		Value: p.curToken.Literal,
 	}
 
+	var ok bool
+	if state.Parent, ok = p.parseExtends(); !ok {
+		return nil
+	}
+
 	if !p.expectPeek(lexer.LBRACE) {
 		return nil
 	}
@@ -119,9 +150,23 @@ func (p *Parser) parseStateDefinition() *ast.StateDef {
 	p.nextToken()
 
 	for !p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) {
+		if p.curTokenIs(lexer.ILLEGAL) {
+			p.AddErrorMsg(&p.curToken, p.curToken.Literal)
+			return nil
+		}
+
+		if p.curTokenIs(lexer.USE) {
+			use := p.parseUse()
+			if use == nil {
+				return nil
+			}
+			state.Uses = append(state.Uses, use)
+		}
+
 		if p.curToken.Type == lexer.IDENTIFIER {
 			name := p.curToken.Literal
 			identifier := p.curToken
+			doc := p.curDoc
 
 			if p.peekTokenIs(lexer.ASSIGN) {
 				p.nextToken() // 跳过=
@@ -137,6 +182,7 @@ func (p *Parser) parseStateDefinition() *ast.StateDef {
 								Value: name,
 							},
 							Value: table,
+							Doc:   doc,
 						})
 					}
 				} else if !p.curTokenIs(lexer.FUNC) {
@@ -150,6 +196,7 @@ func (p *Parser) parseStateDefinition() *ast.StateDef {
 								Value: name,
 							},
 							Value: expr,
+							Doc:   doc,
 						})
 					}
 				} else {
@@ -168,8 +215,10 @@ func (p *Parser) parseStateDefinition() *ast.StateDef {
 						state.Properties = append(state.Properties, &ast.PropertyDef{
 							Key:   key,
 							Value: method,
+							Doc:   doc,
 						})
 						fn.Name = key
+						docFunction(fn, doc)
 					}
 				}
 			}
@@ -183,3 +232,178 @@ func (p *Parser) parseStateDefinition() *ast.StateDef {
 
 	return state
 }
+
+// parseExtends parses the optional "extends Parent" after the name of a
+// skill or state. ok is false after a syntax error.
+func (p *Parser) parseExtends() (parent ast.Expression, ok bool) {
+	if !p.peekTokenIs(lexer.EXTENDS) {
+		return nil, true
+	}
+	p.nextToken()
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil, false
+	}
+	parent = p.parseExpression(LOWEST)
+	if parent == nil {
+		return nil, false
+	}
+	if _, isName := ast.QualifiedName(parent); !isName {
+		p.AddErrorMsg(&p.curToken, fmt.Sprintf("extends expects a name, got %s", parent))
+		return nil, false
+	}
+	return parent, true
+}
+
+// parseUse parses "use name(args)" in a skill or state; the parentheses
+// can be left out for a template without parameters.
+func (p *Parser) parseUse() *ast.UseDecl {
+	use := &ast.UseDecl{BaseNode: ast.BaseNode{Token: p.curToken}}
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	exp := p.parseExpression(LOWEST)
+	if exp == nil {
+		return nil
+	}
+	use.Template = exp
+	if call, ok := exp.(*ast.FunctionCall); ok {
+		use.Template = call.Function
+		use.Arguments = call.Arguments
+	}
+	if _, isName := ast.QualifiedName(use.Template); !isName {
+		p.AddErrorMsg(&use.Token, fmt.Sprintf("use expects a template such as dot(30), got %s", exp))
+		return nil
+	}
+	return use
+}
+
+// parseTemplateDefinition parses template name(params) { properties }.
+// Like parseEnumDefinition, it is only called at the top level.
+func (p *Parser) parseTemplateDefinition() *ast.TemplateDef {
+	template := &ast.TemplateDef{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Doc:      p.curDoc,
+	}
+
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	template.Name = &ast.Identifier{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Value:    p.curToken.Literal,
+	}
+
+	if p.peekTokenIs(lexer.LPAREN) {
+		p.nextToken()
+		var types []*ast.Identifier
+		template.Parameters, types = p.parseFunctionParameters()
+		if template.Parameters == nil {
+			return nil
+		}
+		for _, typ := range types {
+			if typ != nil {
+				p.AddErrorMsg(&typ.Token, "template parameters cannot have types")
+				return nil
+			}
+		}
+	}
+
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	p.nextToken()
+
+	for !p.curTokenIs(lexer.RBRACE) {
+		if !p.curTokenIs(lexer.IDENTIFIER) || !p.peekTokenIs(lexer.ASSIGN) {
+			p.AddErrorMsg(&p.curToken, fmt.Sprintf("unexpected token %q, expected a property", p.curToken.Literal))
+			return nil
+		}
+
+		key := &ast.Identifier{
+			BaseNode: ast.BaseNode{Token: p.curToken},
+			Value:    p.curToken.Literal,
+		}
+		prop := &ast.PropertyDef{Key: key, Doc: p.curDoc}
+		p.nextToken() // skip the name
+		p.nextToken() // skip the "="
+		if p.curTokenIs(lexer.FUNC) {
+			fn, ok := p.parseFunction().(*ast.FunctionDef)
+			if !ok {
+				return nil
+			}
+			fn.Name = key
+			docFunction(fn, prop.Doc)
+			prop.Value = fn
+		} else if prop.Value = p.parseExpression(LOWEST); prop.Value == nil {
+			return nil
+		}
+		template.Properties = append(template.Properties, prop)
+
+		if p.peekTokenIs(lexer.RBRACE) {
+			p.nextToken()
+			break
+		}
+		if !p.expectPeek(lexer.COMMA) {
+			return nil
+		}
+		p.nextToken()
+	}
+	return template
+}
+
+// parseEnumDefinition parses enum Name { Member [= expr], ... }. Like
+// parseConstStatement, it is only called at the top level.
+func (p *Parser) parseEnumDefinition() *ast.EnumDef {
+	enum := &ast.EnumDef{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Doc:      p.curDoc,
+	}
+
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	enum.Name = &ast.Identifier{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Value:    p.curToken.Literal,
+	}
+
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	p.nextToken()
+
+	for !p.curTokenIs(lexer.RBRACE) {
+		if !p.curTokenIs(lexer.IDENTIFIER) {
+			p.AddErrorMsg(&p.curToken, fmt.Sprintf("unexpected token %q, expected an enum member", p.curToken.Literal))
+			return nil
+		}
+
+		member := &ast.EnumMember{
+			BaseNode: ast.BaseNode{Token: p.curToken},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{Token: p.curToken},
+				Value:    p.curToken.Literal,
+			},
+			Doc: p.curDoc,
+		}
+		if p.peekTokenIs(lexer.ASSIGN) {
+			p.nextToken() // skip the name
+			p.nextToken() // skip the "="
+			member.Value = p.parseExpression(LOWEST)
+			if member.Value == nil {
+				return nil
+			}
+		}
+		enum.Members = append(enum.Members, member)
+
+		if p.peekTokenIs(lexer.RBRACE) {
+			p.nextToken()
+			break
+		}
+		if !p.expectPeek(lexer.COMMA) {
+			return nil
+		}
+		p.nextToken()
+	}
+	return enum
+}
@@ -10,14 +10,19 @@ import (
 const (
 	_           int = iota
 	LOWEST          // 最低优先级
-	ASSIGN          // =
+	TERNARY         // cond ? a : b
 	OR              // or
 	AND             // and
 	EQUALS          // == or !=
 	LESSGREATER     // > or < or >= or <=
+	BITOR           // |
+	BITXOR          // ~
+	BITAND          // &
+	SHIFT           // << or >>
 	SUM             // +
-	PRODUCT         // *
+	PRODUCT         // * or / or // or %
 	PREFIX          // -X or !X
+	POWER           // ^ or **, binds tighter than a prefix on its left: -x^2 is -(x^2)
 	CALL            // myFunction(X)
 	DOT             // module.function
 )
@@ -33,11 +38,33 @@ var precedences = map[lexer.TokenType]int{
 	lexer.MINUS:    SUM,
 	lexer.MULTIPLY: PRODUCT,
 	lexer.DIVIDE:   PRODUCT,
+	lexer.INTDIV:   PRODUCT,
+	lexer.MODULO:   PRODUCT,
+	lexer.POWER:    POWER,
+	lexer.BITAND:   BITAND,
+	lexer.BITOR:    BITOR,
+	lexer.BITXOR:   BITXOR,
+	lexer.SHL:      SHIFT,
+	lexer.SHR:      SHIFT,
 	lexer.LPAREN:   CALL,
+	lexer.LBRACKET: CALL,
 	lexer.DOT:      DOT,
+	lexer.OPTDOT:   DOT,
 	lexer.AND:      AND,
 	lexer.OR:       OR,
-	lexer.ASSIGN:   ASSIGN,
+	lexer.QUESTION: TERNARY,
+}
+
+// assignOperators are the tokens that turn an expression statement into an
+// assignment.
+var assignOperators = map[lexer.TokenType]bool{
+	lexer.ASSIGN:         true,
+	lexer.PLUSASSIGN:     true,
+	lexer.MINUSASSIGN:    true,
+	lexer.MULTIPLYASSIGN: true,
+	lexer.DIVIDEASSIGN:   true,
+	lexer.INCREMENT:      true,
+	lexer.DECREMENT:      true,
 }
 
 func (p *Parser) registerPrefix(tokenType lexer.TokenType, fn prefixParseFn) {
@@ -52,7 +79,9 @@ func (p *Parser) registerPrecedenceFuncs() {
 	p.registerPrefix(lexer.IDENTIFIER, p.parseIdentifier) // Register prefix parse functions
 	p.registerPrefix(lexer.INTEGER, p.parseInteger)
 	p.registerPrefix(lexer.FLOAT, p.parseFloat)
+	p.registerPrefix(lexer.QUANTITY, p.parseUnit)
 	p.registerPrefix(lexer.STRING, p.parseString)
+	p.registerPrefix(lexer.STRINGHEAD, p.parseInterpolatedString)
 	p.registerPrefix(lexer.TRUE, p.parseBoolean)
 	p.registerPrefix(lexer.FALSE, p.parseBoolean)
 	p.registerPrefix(lexer.LPAREN, p.parseGroupedExpression)
@@ -60,11 +89,20 @@ func (p *Parser) registerPrecedenceFuncs() {
 	p.registerPrefix(lexer.FUNC, p.parseFunction)
 	p.registerPrefix(lexer.NOT, p.parsePrefixExpression)
 	p.registerPrefix(lexer.MINUS, p.parsePrefixExpression)
+	p.registerPrefix(lexer.BITXOR, p.parsePrefixExpression)
 
 	p.registerInfix(lexer.PLUS, p.parseInfixExpression) // Register infix parse functions
 	p.registerInfix(lexer.MINUS, p.parseInfixExpression)
 	p.registerInfix(lexer.MULTIPLY, p.parseInfixExpression)
 	p.registerInfix(lexer.DIVIDE, p.parseInfixExpression)
+	p.registerInfix(lexer.INTDIV, p.parseInfixExpression)
+	p.registerInfix(lexer.MODULO, p.parseInfixExpression)
+	p.registerInfix(lexer.POWER, p.parseInfixExpression)
+	p.registerInfix(lexer.BITAND, p.parseInfixExpression)
+	p.registerInfix(lexer.BITOR, p.parseInfixExpression)
+	p.registerInfix(lexer.BITXOR, p.parseInfixExpression)
+	p.registerInfix(lexer.SHL, p.parseInfixExpression)
+	p.registerInfix(lexer.SHR, p.parseInfixExpression)
 	p.registerInfix(lexer.EQ, p.parseInfixExpression)
 	p.registerInfix(lexer.NEQ, p.parseInfixExpression)
 	p.registerInfix(lexer.LT, p.parseInfixExpression)
@@ -72,10 +110,12 @@ func (p *Parser) registerPrecedenceFuncs() {
 	p.registerInfix(lexer.LTE, p.parseInfixExpression)
 	p.registerInfix(lexer.GTE, p.parseInfixExpression)
 	p.registerInfix(lexer.DOT, p.parseDotExpression)
+	p.registerInfix(lexer.OPTDOT, p.parseDotExpression)
 	p.registerInfix(lexer.LPAREN, p.parseFunctionCall)
+	p.registerInfix(lexer.LBRACKET, p.parseIndexExpression)
 	p.registerInfix(lexer.AND, p.parseInfixExpression)
 	p.registerInfix(lexer.OR, p.parseInfixExpression)
-	p.registerInfix(lexer.ASSIGN, p.parseInfixExpression)
+	p.registerInfix(lexer.QUESTION, p.parseConditionalExpression)
 }
 
 func (p *Parser) parsePrefixExpression() ast.Expression {
@@ -87,6 +127,16 @@ func (p *Parser) parsePrefixExpression() ast.Expression {
 	}
 
 	p.nextToken()
+	if expression.Operator == "-" && p.peekPrecedence() <= PREFIX { // -2 ^ 2 is -(2 ^ 2)
+		switch {
+		case p.curTokenIs(lexer.INTEGER):
+			return p.parseIntegerLiteral(p.curToken, "-")
+		case p.curTokenIs(lexer.FLOAT):
+			return p.parseFloatLiteral(p.curToken, "-")
+		case p.curTokenIs(lexer.QUANTITY):
+			return p.parseUnitLiteral(p.curToken, "-")
+		}
+	}
 	expression.Right = p.parseExpression(PREFIX)
 
 	return expression
@@ -102,12 +152,39 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.curPrecedence()
+	if p.curTokenIs(lexer.POWER) {
+		expression.Operator = "^" // ** is spelled ^ from here on
+		precedence--              // right associative: 2^3^2 is 2^(3^2)
+	}
 	p.nextToken()
 	expression.Right = p.parseExpression(precedence)
 
 	return expression
 }
 
+// parseConditionalExpression parses cond ? a : b. It is right associative,
+// so a ? b : c ? d : e is a ? b : (c ? d : e).
+func (p *Parser) parseConditionalExpression(cond ast.Expression) ast.Expression {
+	exp := &ast.ConditionalExpression{
+		BaseNode:  ast.BaseNode{Token: p.curToken},
+		Condition: cond,
+	}
+
+	p.nextToken() // consume '?'
+	exp.Consequence = p.parseExpression(LOWEST)
+	if exp.Consequence == nil || !p.expectPeek(lexer.COLON) {
+		return nil
+	}
+
+	p.nextToken() // consume ':'
+	exp.Alternative = p.parseExpression(TERNARY - 1)
+	if exp.Alternative == nil {
+		return nil
+	}
+
+	return exp
+}
+
 func (p *Parser) peekPrecedence() int {
 	if p, ok := precedences[p.peekToken.Type]; ok {
 		return p
@@ -122,12 +199,50 @@ func (p *Parser) curPrecedence() int {
 	return LOWEST
 }
 
-func (p *Parser) parseExpressionStatement() *ast.ExprStmt {
-	stmt := &ast.ExprStmt{
-		BaseNode: ast.BaseNode{
-			Token: p.curToken,
-		},
-		Expression: p.parseExpression(LOWEST),
+// parseSimpleStatement parses an expression statement or an assignment:
+// x = v, x += v (also -=, *=, /=), x++ or x--. Only names, members and
+// elements can be assigned.
+func (p *Parser) parseSimpleStatement() ast.Statement {
+	tok := p.curToken
+	exp := p.parseExpression(LOWEST)
+	if exp == nil {
+		return nil
+	}
+
+	if !assignOperators[p.peekToken.Type] {
+		return &ast.ExprStmt{
+			BaseNode:   ast.BaseNode{Token: tok},
+			Expression: exp,
+		}
+	}
+
+	p.nextToken()
+	stmt := &ast.AssignStatement{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Target:   exp,
+		Operator: p.curToken.Literal,
+	}
+
+	switch target := exp.(type) {
+	case *ast.Identifier, *ast.IndexExpression:
+	case *ast.DotExpression:
+		if target.Optional {
+			p.AddErrorMsg(&p.curToken, fmt.Sprintf("cannot assign to optional member %s", target.Right))
+			return nil
+		}
+	default:
+		p.AddErrorMsg(&p.curToken, fmt.Sprintf("left side of %s must be a name, member or element", stmt.Operator))
+		return nil
+	}
+
+	if p.curTokenIs(lexer.INCREMENT) || p.curTokenIs(lexer.DECREMENT) {
+		return stmt
+	}
+
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+	if stmt.Value == nil {
+		return nil
 	}
 
 	return stmt
@@ -137,6 +252,11 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 	prefix := p.prefixParseFns[p.curToken.Type]
 	if prefix == nil {
 		msg := fmt.Sprintf("no prefix parse function for %s found", p.curToken.Type)
+		if p.curTokenIs(lexer.INCREMENT) || p.curTokenIs(lexer.DECREMENT) {
+			msg = fmt.Sprintf("%s is a statement and cannot be used as a value", p.curToken.Literal)
+		} else if p.curTokenIs(lexer.ILLEGAL) {
+			msg = p.curToken.Literal
+		}
 		p.AddErrorMsg(&p.curToken, msg)
 		return nil
 	}
@@ -200,7 +320,10 @@ func (p *Parser) parseIdentifier() ast.Expression {
 	return &ast.Identifier{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
 }
 
+// parseDotExpression parses member access with . or ?. Chains such as
+// ctx.caster.stats.atk nest to the left.
 func (p *Parser) parseDotExpression(left ast.Expression) ast.Expression {
+	optional := p.curTokenIs(lexer.OPTDOT)
 	if !p.expectPeek(lexer.IDENTIFIER) {
 		return nil
 	}
@@ -209,6 +332,26 @@ func (p *Parser) parseDotExpression(left ast.Expression) ast.Expression {
 		BaseNode: ast.BaseNode{Token: p.curToken},
 		Left:     left,
 		Right:    &ast.Identifier{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal},
+		Optional: optional,
+	}
+
+	return exp
+}
+
+func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	exp := &ast.IndexExpression{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Left:     left,
+	}
+
+	p.nextToken() // consume '['
+	exp.Index = p.parseExpression(LOWEST)
+	if exp.Index == nil {
+		return nil
+	}
+
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil
 	}
 
 	return exp
@@ -5,31 +5,53 @@ import (
 	"github.com/hsoul/skconf/internal/lexer"
 )
 
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := []*ast.Identifier{}
+// parseFunctionParameters parses the parameters of a function up to the
+// closing parenthesis, each optionally annotated with a type as in
+// target: Unit. types is nil if none is annotated.
+func (p *Parser) parseFunctionParameters() (params, types []*ast.Identifier) {
+	params = []*ast.Identifier{}
 
 	if p.peekTokenIs(lexer.RPAREN) {
 		p.nextToken()
-		return identifiers
+		return params, nil
 	}
 
-	p.nextToken()
+	annotated := false
+	for {
+		p.nextToken()
+		params = append(params, &ast.Identifier{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal})
 
-	ident := &ast.Identifier{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
-	identifiers = append(identifiers, ident)
+		var typ *ast.Identifier
+		if p.peekTokenIs(lexer.COLON) {
+			p.nextToken()
+			if typ = p.parseTypeName(); typ == nil {
+				return nil, nil
+			}
+			annotated = true
+		}
+		types = append(types, typ)
 
-	for p.peekTokenIs(lexer.COMMA) {
-		p.nextToken()
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
 		p.nextToken()
-		ident := &ast.Identifier{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
-		identifiers = append(identifiers, ident)
 	}
 
 	if !p.expectPeek(lexer.RPAREN) {
-		return nil
+		return nil, nil
+	}
+	if !annotated {
+		types = nil
 	}
+	return params, types
+}
 
-	return identifiers
+// parseTypeName parses the type name after a ":" or "->".
+func (p *Parser) parseTypeName() *ast.Identifier {
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	return &ast.Identifier{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
 }
 
 func (p *Parser) parseFunction() ast.Expression {
@@ -37,17 +59,25 @@ func (p *Parser) parseFunction() ast.Expression {
 		BaseNode: ast.BaseNode{
 			Token: p.curToken,
 		},
+		Doc: p.curDoc,
 	}
 
 	if !p.expectPeek(lexer.LPAREN) {
 		return nil
 	}
 
-	funcLit.Parameters = p.parseFunctionParameters()
+	funcLit.Parameters, funcLit.Types = p.parseFunctionParameters()
 	if funcLit.Parameters == nil {
 		return nil
 	}
 
+	if p.peekTokenIs(lexer.ARROW) {
+		p.nextToken()
+		if funcLit.Returns = p.parseTypeName(); funcLit.Returns == nil {
+			return nil
+		}
+	}
+
 	if !p.expectPeek(lexer.LBRACE) {
 		return nil
 	}
@@ -60,9 +90,33 @@ func (p *Parser) parseFunction() ast.Expression {
 	return funcLit
 }
 
+// parseFunctionDeclaration parses func name(params) { body }. Like
+// parseConstStatement, it is only called at the top level.
+func (p *Parser) parseFunctionDeclaration() *ast.FunctionDef {
+	tok, doc := p.curToken, p.curDoc
+	p.nextToken()
+	name := &ast.Identifier{BaseNode: ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+
+	fn, ok := p.parseFunction().(*ast.FunctionDef)
+	if !ok {
+		return nil
+	}
+	fn.Token, fn.Doc, fn.Name = tok, doc, name
+	return fn
+}
+
+// docFunction gives a function the doc comment of the property or variable
+// it is assigned to, unless the function has its own.
+func docFunction(value ast.Expression, doc string) {
+	if fn, ok := value.(*ast.FunctionDef); ok && fn.Doc == "" {
+		fn.Doc = doc
+	}
+}
+
 func (p *Parser) parseFunctionCall(function ast.Expression) ast.Expression {
+	tok := p.curToken // the '(', before parsing the arguments moves past it
 	exp := &ast.FunctionCall{
-		BaseNode:  ast.BaseNode{Token: p.curToken},
+		BaseNode:  ast.BaseNode{Token: tok},
 		Function:  function,
 		Arguments: p.parseExpressionList(lexer.RPAREN),
 	}
@@ -3,8 +3,10 @@ package syntax
 import (
 	"fmt"
 	"runtime"
+	"strings"
 
 	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
 	"github.com/hsoul/skconf/internal/lexer"
 )
 
@@ -17,7 +19,10 @@ type Parser struct {
 	l              *lexer.Lexer
 	curToken       lexer.Token
 	peekToken      lexer.Token
+	curDoc         string // 紧邻 curToken 之前的文档注释 / doc comments right before curToken
+	peekDoc        string
 	errors         []string
+	diagnostics    []diag.Diagnostic
 	prefixParseFns map[lexer.TokenType]prefixParseFn
 	infixParseFns  map[lexer.TokenType]infixParseFn
 	fileName       string
@@ -41,16 +46,23 @@ func New(l *lexer.Lexer, fileName string) *Parser {
 }
 
 func (p *Parser) nextToken() {
-	p.curToken = p.peekToken
-	p.peekToken = p.l.NextToken()
-
-	for p.curTokenIs(lexer.COMMENT) {
-		p.curToken = p.peekToken
-		p.peekToken = p.l.NextToken()
-	}
+	p.curToken, p.curDoc = p.peekToken, p.peekDoc
+	p.peekToken, p.peekDoc = p.readToken()
+}
 
-	for p.peekTokenIs(lexer.COMMENT) {
-		p.peekToken = p.l.NextToken()
+// readToken returns the next token that is not a comment, along with the
+// text of the "---" doc comments before it, one line per comment.
+func (p *Parser) readToken() (lexer.Token, string) {
+	var doc []string
+	for {
+		tok := p.l.NextToken()
+		switch tok.Type {
+		case lexer.COMMENT:
+		case lexer.DOCCOMMENT:
+			doc = append(doc, tok.Literal)
+		default:
+			return tok, strings.Join(doc, "\n")
+		}
 	}
 }
 
@@ -68,6 +80,9 @@ func (p *Parser) expectPeek(t lexer.TokenType) bool { // Check if the next token
 		return true
 	}
 	msg := fmt.Sprintf("unexpected token %q, expected %q", p.peekToken.Literal, t.TokenLiteral())
+	if p.peekTokenIs(lexer.ILLEGAL) {
+		msg = p.peekToken.Literal
+	}
 	p.AddErrorMsg(&p.peekToken, msg)
 	return false
 }
@@ -76,7 +91,19 @@ func (p *Parser) Errors() []string {
 	return p.errors
 }
 
+// Diagnostics returns the parse errors in structured form, without the
+// parser source location that Errors() carries for debugging.
+func (p *Parser) Diagnostics() []diag.Diagnostic {
+	return p.diagnostics
+}
+
 func (p *Parser) AddErrorMsg(tok *lexer.Token, msg string) {
+	d := diag.Diagnostic{Severity: diag.Error, File: p.fileName, Message: msg}
+	if tok != nil {
+		d.Pos = tok.Pos
+	}
+	p.diagnostics = append(p.diagnostics, d)
+
 	_, file, line, ok := runtime.Caller(1)
 	if !ok {
 		file = "???"
@@ -88,5 +115,7 @@ func (p *Parser) AddErrorMsg(tok *lexer.Token, msg string) {
 		msg = fmt.Sprintf("%s:%d: %s", file, line, msg)
 	}
 	p.errors = append(p.errors, msg)
-	fmt.Println(msg)
+	if w := p.l.Trace(); w != nil {
+		fmt.Fprintln(w, msg)
+	}
 }
@@ -0,0 +1,33 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/hsoul/skconf/internal/lexer"
+)
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		line, col int
+	}{
+		{"number", "var x = 99999999999999999999", 1, 9},
+		{"number after cjk", "var 伤害 = 99999999999999999999", 1, 10},
+		{"unit", "var 伤害 = 3parsecs", 1, 10},
+		{"missing name", "var = 1", 1, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input), "t.dsl")
+			p.ParseProgram()
+			diags := p.Diagnostics()
+			if len(diags) == 0 {
+				t.Fatal("no error reported")
+			}
+			if pos := diags[0].Pos; pos.Line != tt.line || pos.Column != tt.col {
+				t.Errorf("%s reported at %d:%d, want %d:%d", diags[0].Message, pos.Line, pos.Column, tt.line, tt.col)
+			}
+		})
+	}
+}
@@ -19,14 +19,30 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseComment()
 	case lexer.VAR:
 		return p.parseVarStatement()
+	case lexer.CONST, lexer.ENUM, lexer.TEMPLATE:
+		p.AddErrorMsg(&p.curToken, p.curToken.Literal+" is only allowed at the top level of a file")
+		return nil
+	case lexer.FUNC:
+		if p.peekTokenIs(lexer.IDENTIFIER) {
+			p.AddErrorMsg(&p.curToken, "named functions are only allowed at the top level of a file")
+			p.parseFunctionDeclaration() // skip it
+			return nil
+		}
+		return p.parseSimpleStatement()
 	case lexer.FOR:
 		return p.parseForStatement()
+	case lexer.MATCH:
+		return p.parseMatchStatement()
+	case lexer.WHILE:
+		return p.parseWhileStatement()
+	case lexer.REPEAT:
+		return p.parseRepeatStatement()
 	case lexer.BREAK:
 		return &ast.BreakStatement{BaseNode: ast.BaseNode{Token: p.curToken}}
 	case lexer.CONTINUE:
 		return &ast.ContinueStatement{BaseNode: ast.BaseNode{Token: p.curToken}}
 	default:
-		return p.parseExpressionStatement()
+		return p.parseSimpleStatement()
 	}
 }
 
@@ -41,6 +57,22 @@ func (p *Parser) ParseProgram() *ast.Program {
 			if stmt := p.parseImportStatement(); stmt != nil {
 				program.Imports = append(program.Imports, *stmt)
 			}
+		} else if p.curToken.Type == lexer.CONST {
+			if stmt := p.parseConstStatement(); stmt != nil {
+				program.Statements = append(program.Statements, stmt)
+			}
+		} else if p.curToken.Type == lexer.ENUM {
+			if stmt := p.parseEnumDefinition(); stmt != nil {
+				program.Statements = append(program.Statements, stmt)
+			}
+		} else if p.curToken.Type == lexer.FUNC && p.peekTokenIs(lexer.IDENTIFIER) {
+			if stmt := p.parseFunctionDeclaration(); stmt != nil {
+				program.Statements = append(program.Statements, stmt)
+			}
+		} else if p.curToken.Type == lexer.TEMPLATE {
+			if stmt := p.parseTemplateDefinition(); stmt != nil {
+				program.Statements = append(program.Statements, stmt)
+			}
 		} else if stmt := p.parseStatement(); stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
@@ -58,6 +90,7 @@ func (p *Parser) parseVarStatement() *ast.VarStatement {
 	stmt := &ast.VarStatement{
 		BaseNode: ast.BaseNode{Token: p.curToken},
 	}
+	doc := p.curDoc
 
 	if !p.expectPeek(lexer.IDENTIFIER) {
 		return nil
@@ -78,6 +111,7 @@ func (p *Parser) parseVarStatement() *ast.VarStatement {
 	if stmt.Value == nil {
 		return nil
 	}
+	docFunction(stmt.Value, doc)
 
 	if p.peekTokenIs(lexer.SEMICOLON) {
 		p.nextToken()
@@ -86,7 +120,39 @@ func (p *Parser) parseVarStatement() *ast.VarStatement {
 	return stmt
 }
 
-func (p *Parser) parseForStatement() *ast.ForStatement {
+// parseConstStatement parses const NAME = expr. ParseProgram only calls it
+// at the top level.
+func (p *Parser) parseConstStatement() *ast.ConstStatement {
+	stmt := &ast.ConstStatement{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Doc:      p.curDoc,
+	}
+
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+		Value:    p.curToken.Literal,
+	}
+
+	if !p.expectPeek(lexer.ASSIGN) {
+		return nil
+	}
+	p.nextToken() // skip =
+
+	stmt.Value = p.parseExpression(LOWEST)
+	if stmt.Value == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(lexer.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseForStatement() ast.Statement {
 	stmt := &ast.ForStatement{
 		BaseNode: ast.BaseNode{Token: p.curToken},
 	}
@@ -134,46 +200,44 @@ func (p *Parser) parseForStatement() *ast.ForStatement {
 		}
 	}
 
-	if !p.curTokenIs(lexer.VAR) && !p.curTokenIs(lexer.SEMICOLON) { // Check if this is a condition-only for loop
-		stmt.Condition = p.parseExpression(LOWEST) // for condition { }
-
-		if !p.expectPeek(lexer.LBRACE) {
+	if p.curTokenIs(lexer.VAR) { // Classic for loop: for init; condition; post { }
+		stmt.Init = p.parseStatement()
+	} else if !p.curTokenIs(lexer.SEMICOLON) {
+		init := p.parseSimpleStatement()
+		if init == nil {
 			return nil
 		}
 
-		stmt.Body = p.parseBlockStatement()
-		return stmt
-	}
+		if exprStmt, ok := init.(*ast.ExprStmt); ok && p.peekTokenIs(lexer.LBRACE) { // Condition-only for loop: for condition { }
+			p.nextToken()
+			return &ast.WhileStatement{
+				BaseNode:  stmt.BaseNode,
+				Condition: exprStmt.Expression,
+				Body:      p.parseBlockStatement(),
+			}
+		}
 
-	if !p.curTokenIs(lexer.SEMICOLON) { // Classic for loop: for init; condition; post { }
-		stmt.Init = p.parseStatement()
+		stmt.Init = init
 	}
 
-	if !p.curTokenIs(lexer.SEMICOLON) {
-		if !p.expectPeek(lexer.SEMICOLON) {
-			return nil
-		}
-	} else {
-		p.nextToken() // skip semicolon
+	if !p.curTokenIs(lexer.SEMICOLON) && !p.expectPeek(lexer.SEMICOLON) {
+		return nil
 	}
 
-	if !p.curTokenIs(lexer.SEMICOLON) {
+	if !p.peekTokenIs(lexer.SEMICOLON) {
+		p.nextToken()
 		stmt.Condition = p.parseExpression(LOWEST)
 	}
 
-	if !p.curTokenIs(lexer.SEMICOLON) {
-		if !p.expectPeek(lexer.SEMICOLON) {
-			return nil
-		}
-	} else {
-		p.nextToken() // skip semicolon
+	if !p.expectPeek(lexer.SEMICOLON) {
+		return nil
 	}
 
-	if !p.curTokenIs(lexer.LBRACE) {
+	if !p.peekTokenIs(lexer.LBRACE) {
 		p.nextToken()
-		stmt.Post = &ast.ExprStmt{
-			BaseNode:   ast.BaseNode{Token: p.curToken},
-			Expression: p.parseExpression(LOWEST),
+		stmt.Post = p.parseSimpleStatement()
+		if stmt.Post == nil {
+			return nil
 		}
 	}
 
@@ -185,6 +249,45 @@ func (p *Parser) parseForStatement() *ast.ForStatement {
 	return stmt
 }
 
+// parseWhileStatement parses while condition { }.
+func (p *Parser) parseWhileStatement() ast.Statement {
+	stmt := &ast.WhileStatement{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+	}
+	p.nextToken() // consume 'while'
+
+	stmt.Condition = p.parseExpression(LOWEST)
+	if stmt.Condition == nil || !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	stmt.Body = p.parseBlockStatement()
+	return stmt
+}
+
+// parseRepeatStatement parses repeat { } until condition.
+func (p *Parser) parseRepeatStatement() ast.Statement {
+	stmt := &ast.RepeatStatement{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+	}
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	stmt.Body = p.parseBlockStatement()
+	if !p.expectPeek(lexer.UNTIL) {
+		return nil
+	}
+	p.nextToken() // consume 'until'
+
+	stmt.Condition = p.parseExpression(LOWEST)
+	if stmt.Condition == nil {
+		return nil
+	}
+	if p.peekTokenIs(lexer.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
 func (p *Parser) parseImportStatement() *ast.ImportStatement {
 	stmt := &ast.ImportStatement{
 		BaseNode: ast.BaseNode{
@@ -255,6 +358,79 @@ func (p *Parser) parseIfStatement() ast.Statement {
 	return stmt
 }
 
+// parseMatchStatement parses match expr { Fire => {...}, Ice | Water =>
+// {...}, _ => {...} }. Commas between the arms are optional; the default
+// arm _ must come last.
+func (p *Parser) parseMatchStatement() ast.Statement {
+	stmt := &ast.MatchStatement{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+	}
+	p.nextToken() // consume 'match'
+
+	stmt.Subject = p.parseExpression(LOWEST)
+	if stmt.Subject == nil || !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	p.nextToken() // consume '{'
+
+	for !p.curTokenIs(lexer.RBRACE) {
+		if p.curTokenIs(lexer.EOF) {
+			p.AddErrorMsg(&p.curToken, "unexpected end of file in match")
+			return nil
+		}
+		if def := stmt.Default(); def != nil && def == stmt.Arms[len(stmt.Arms)-1] {
+			p.AddErrorMsg(&p.curToken, "the default arm _ must be the last arm of a match")
+		}
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		stmt.Arms = append(stmt.Arms, arm)
+		p.nextToken() // consume the '}' of the arm
+		if p.curTokenIs(lexer.COMMA) {
+			p.nextToken()
+		}
+	}
+	return stmt
+}
+
+// parseMatchArm parses Pattern { "|" Pattern } "=>" Block, or the default
+// arm _ => Block. A pattern binds tighter than "|", so 1 | 2 is two
+// patterns rather than a bitwise or.
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{
+		BaseNode: ast.BaseNode{Token: p.curToken},
+	}
+
+	if !p.curTokenIs(lexer.UNDERSCORE) {
+		for {
+			if p.curTokenIs(lexer.UNDERSCORE) {
+				p.AddErrorMsg(&p.curToken, "the default arm _ cannot have other patterns")
+				return nil
+			}
+			pattern := p.parseExpression(BITOR)
+			if pattern == nil {
+				return nil
+			}
+			arm.Patterns = append(arm.Patterns, pattern)
+			if !p.peekTokenIs(lexer.BITOR) {
+				break
+			}
+			p.nextToken() // consume the pattern
+			p.nextToken() // consume '|'
+		}
+	} else if p.peekTokenIs(lexer.BITOR) {
+		p.AddErrorMsg(&p.curToken, "the default arm _ cannot have other patterns")
+		return nil
+	}
+
+	if !p.expectPeek(lexer.FATARROW) || !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	arm.Body = p.parseBlockStatement()
+	return arm
+}
+
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	stmt := &ast.ReturnStatement{
 		BaseNode: ast.BaseNode{
@@ -28,7 +28,8 @@ func (p *Parser) parseTable() ast.Expression {
 			continue
 		}
 
-		if p.curTokenIs(lexer.LBRACKET) || (p.curTokenIs(lexer.IDENTIFIER) && !p.peekTokenIs(lexer.DOT)) {
+		doc := p.curDoc
+		if p.curTokenIs(lexer.LBRACKET) || (p.curTokenIs(lexer.IDENTIFIER) && p.peekTokenIs(lexer.ASSIGN)) {
 			var key ast.Expression
 			if p.curTokenIs(lexer.LBRACKET) { // Handle array-style indexing with [key]
 				p.nextToken() // consume '['
@@ -65,7 +66,9 @@ func (p *Parser) parseTable() ast.Expression {
 				BaseNode: ast.BaseNode{Token: p.curToken},
 				Key:      key,
 				Value:    value,
+				Doc:      doc,
 			})
+			docFunction(value, doc)
 		} else { // array
 			value := p.parseExpression(LOWEST)
 			if value == nil {
@@ -76,6 +79,7 @@ func (p *Parser) parseTable() ast.Expression {
 				BaseNode: ast.BaseNode{Token: p.curToken},
 				Key:      nil,
 				Value:    value,
+				Doc:      doc,
 			})
 		}
 
@@ -0,0 +1,193 @@
+// Package units describes the units quantities such as 1.5s or 20% are
+// written in and the base units the runtime stores them in.
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// Dimension is what a quantity measures. Quantities of different
+// dimensions cannot be added or compared.
+type Dimension string
+
+const (
+	Duration Dimension = "duration"
+	Distance Dimension = "distance"
+	Percent  Dimension = "percent"
+)
+
+// Dimensions lists every dimension.
+var Dimensions = []Dimension{Duration, Distance, Percent}
+
+// IsDimension reports whether name, such as a manifest type, is a
+// dimension.
+func IsDimension(name string) bool {
+	for _, dim := range Dimensions {
+		if string(dim) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Unit is a unit a quantity can be written in. Scale is its size in the
+// canonical unit of its dimension: seconds, meters or a ratio.
+type Unit struct {
+	Name      string
+	Dimension Dimension
+	Scale     *big.Rat
+}
+
+var unitTable = map[string]Unit{
+	"ms": {"ms", Duration, big.NewRat(1, 1000)},
+	"s":  {"s", Duration, big.NewRat(1, 1)},
+	"cm": {"cm", Distance, big.NewRat(1, 100)},
+	"m":  {"m", Distance, big.NewRat(1, 1)},
+	"%":  {"%", Percent, big.NewRat(1, 100)},
+}
+
+func Lookup(name string) (Unit, bool) {
+	u, ok := unitTable[name]
+	return u, ok
+}
+
+// Names returns the unit names in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(unitTable))
+	for name := range unitTable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Base is the unit the runtime stores a dimension in. Integral bases such
+// as frames and milliseconds hold integers; with Fixed set, values are
+// fixed-point integers scaled by Fixed.
+type Base struct {
+	Name     string
+	Scale    *big.Rat // size in the canonical unit of the dimension
+	Integral bool
+	Fixed    int64
+	FPS      int // frames per second of the "frame" base
+}
+
+type baseUnit struct {
+	scale    *big.Rat
+	integral bool
+}
+
+// baseTable lists the bases of every dimension. The first is the default.
+var baseTable = map[Dimension][]string{
+	Duration: {"s", "ms", "frame"},
+	Distance: {"m", "cm", "mm"},
+	Percent:  {"ratio", "percent", "permille"},
+}
+
+var baseUnits = map[string]baseUnit{
+	"s":        {big.NewRat(1, 1), false},
+	"ms":       {big.NewRat(1, 1000), true},
+	"m":        {big.NewRat(1, 1), false},
+	"cm":       {big.NewRat(1, 100), true},
+	"mm":       {big.NewRat(1, 1000), true},
+	"ratio":    {big.NewRat(1, 1), false},
+	"percent":  {big.NewRat(1, 100), true},
+	"permille": {big.NewRat(1, 1000), true},
+}
+
+// DefaultBase is the base of dim when the manifest configures none:
+// seconds, meters and ratios, all as floats.
+func DefaultBase(dim Dimension) Base {
+	b, _ := NewBase(dim, "", 0, 0)
+	return b
+}
+
+// NewBase returns the base called name for dim; an empty name selects the
+// default. fps is required by, and only allowed for, the "frame" base.
+func NewBase(dim Dimension, name string, fps int, fixed int64) (Base, error) {
+	names := baseTable[dim]
+	if name == "" {
+		name = names[0]
+	}
+	known := false
+	for _, n := range names {
+		known = known || n == name
+	}
+	if !known {
+		return Base{}, fmt.Errorf("unknown %s base %q (known: %v)", dim, name, names)
+	}
+	if fixed < 0 {
+		return Base{}, fmt.Errorf("%s base: fixed must be positive, got %d", dim, fixed)
+	}
+
+	b := Base{Name: name, Fixed: fixed}
+	if name == "frame" {
+		if fps <= 0 {
+			return Base{}, fmt.Errorf("%s base frame needs a positive fps", dim)
+		}
+		b.Scale, b.Integral, b.FPS = big.NewRat(1, int64(fps)), true, fps
+		return b, nil
+	}
+	if fps != 0 {
+		return Base{}, fmt.Errorf("%s base %s does not take an fps", dim, name)
+	}
+	b.Scale, b.Integral = baseUnits[name].scale, baseUnits[name].integral
+	return b, nil
+}
+
+// IsInt reports whether values in b are integers.
+func (b Base) IsInt() bool {
+	return b.Integral || b.Fixed > 0
+}
+
+func (b Base) String() string {
+	s := b.Name
+	if b.FPS > 0 {
+		s = fmt.Sprintf("frames at %d fps", b.FPS)
+	}
+	if b.Fixed > 0 {
+		s = fmt.Sprintf("%s in fixed point x%d", s, b.Fixed)
+	}
+	return s
+}
+
+// Convert converts value, written in unit u, to b. The result is an int64
+// if b.IsInt and a float64 otherwise. Fixed-point values are rounded to the
+// nearest integer; in an integral base a value that is not a whole number
+// is an error, since rounding 10ms to a frame would change its meaning.
+func (b Base) Convert(value *big.Rat, u Unit) (any, error) {
+	r := new(big.Rat).Mul(value, u.Scale)
+	r.Quo(r, b.Scale)
+
+	if !b.IsInt() {
+		f, _ := r.Float64()
+		return f, nil
+	}
+	if b.Fixed > 0 {
+		r.Mul(r, new(big.Rat).SetInt64(b.Fixed))
+		r.SetInt(round(r))
+	} else if !r.IsInt() {
+		f, _ := r.Float64()
+		return nil, fmt.Errorf("is %s %s, not a whole number", strconv.FormatFloat(f, 'g', -1, 64), b)
+	}
+	if !r.Num().IsInt64() {
+		return nil, fmt.Errorf("overflows int64 in %s", b)
+	}
+	return r.Num().Int64(), nil
+}
+
+// round rounds r to the nearest integer, halves away from zero.
+func round(r *big.Rat) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	q, m := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if m.Lsh(m, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q
+}
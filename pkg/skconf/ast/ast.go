@@ -0,0 +1,210 @@
+// Package ast is the typed syntax tree of a compiled source, as found in
+// skconf.File.Program.
+//
+// The tree is a stable view of the one the compiler works on, and is
+// covered by the compatibility promise of package skconf. It holds the
+// declarations of a source, which are its data: imports, consts, enums,
+// skills, states and named functions, with the values of their properties
+// as expressions. The tree is taken after checking, so consts and constant
+// expressions are folded to literals, quantities such as 1.5s are plain
+// numbers, and skills and states list their inherited and template
+// properties. Code, that is function bodies and top-level statements, is
+// not represented: a Function carries its signature only.
+//
+// New expression types may be added in minor versions; type switches over
+// Expression should have a default case.
+package ast
+
+// Position is the place of a node in its source. Line and Column count from
+// 1; Column counts characters, not bytes.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Program is one source file.
+type Program struct {
+	Imports   []*Import
+	Consts    []*Const
+	Enums     []*Enum
+	Skills    []*Definition
+	States    []*Definition
+	Functions []*Function // named functions, including the imported ones the source calls
+}
+
+// Import is an import declaration. Path is the dotted path as written,
+// such as lib.common or UE.CCC.
+type Import struct {
+	Pos  Position
+	Path string
+	DSL  bool // names a DSL file, whose declarations were copied in, rather than a host module
+}
+
+// Const is a const declaration; Value is a literal.
+type Const struct {
+	Pos   Position
+	Name  string
+	Value Expression
+	Doc   string
+}
+
+// Enum is an enum declaration.
+type Enum struct {
+	Pos     Position
+	Name    string
+	Members []*EnumMember
+	Doc     string
+}
+
+// EnumMember is a member of an enum with its value, explicit or not.
+type EnumMember struct {
+	Pos   Position
+	Name  string
+	Value int64
+	Doc   string
+}
+
+// Definition is a skill or a state.
+type Definition struct {
+	Pos        Position
+	Name       string
+	Parent     string // name after extends, such as fireball or lib.common.fireball; empty if none
+	Properties []*Property
+	Doc        string
+}
+
+// Property is a property of a skill, a state or a table. Key is nil for
+// the positional entries of a table.
+type Property struct {
+	Pos       Position
+	Key       Expression
+	Value     Expression
+	Doc       string
+	Inherited bool // comes from the parent or a template rather than the definition itself
+}
+
+// Expression is a value. It is one of the pointer types below.
+type Expression interface {
+	Position() Position
+	expression()
+}
+
+type (
+	Identifier struct {
+		Pos  Position
+		Name string
+	}
+
+	Integer struct {
+		Pos   Position
+		Value int64
+	}
+
+	Float struct {
+		Pos   Position
+		Value float64
+	}
+
+	String struct {
+		Pos   Position
+		Value string
+	}
+
+	// InterpolatedString is a string with ${...} parts; Parts alternates
+	// String literals and the expressions between them.
+	InterpolatedString struct {
+		Pos   Position
+		Parts []Expression
+	}
+
+	Boolean struct {
+		Pos   Position
+		Value bool
+	}
+
+	Table struct {
+		Pos        Position
+		Properties []*Property
+	}
+
+	// Function is a function, named or not. Types are the annotated types
+	// of the parameters, such as "float", with "" for those without one.
+	Function struct {
+		Pos        Position
+		Name       string
+		Parameters []string
+		Types      []string
+		Returns    string
+		Doc        string
+	}
+
+	PrefixExpression struct {
+		Pos      Position
+		Operator string
+		Right    Expression
+	}
+
+	InfixExpression struct {
+		Pos      Position
+		Left     Expression
+		Operator string
+		Right    Expression
+	}
+
+	// DotExpression is Left.Name, or Left?.Name if Optional.
+	DotExpression struct {
+		Pos      Position
+		Left     Expression
+		Name     string
+		Optional bool
+	}
+
+	IndexExpression struct {
+		Pos   Position
+		Left  Expression
+		Index Expression
+	}
+
+	ConditionalExpression struct {
+		Pos         Position
+		Condition   Expression
+		Consequence Expression
+		Alternative Expression
+	}
+
+	FunctionCall struct {
+		Pos       Position
+		Function  Expression
+		Arguments []Expression
+	}
+)
+
+func (e *Identifier) Position() Position            { return e.Pos }
+func (e *Integer) Position() Position               { return e.Pos }
+func (e *Float) Position() Position                 { return e.Pos }
+func (e *String) Position() Position                { return e.Pos }
+func (e *InterpolatedString) Position() Position    { return e.Pos }
+func (e *Boolean) Position() Position               { return e.Pos }
+func (e *Table) Position() Position                 { return e.Pos }
+func (e *Function) Position() Position              { return e.Pos }
+func (e *PrefixExpression) Position() Position      { return e.Pos }
+func (e *InfixExpression) Position() Position       { return e.Pos }
+func (e *DotExpression) Position() Position         { return e.Pos }
+func (e *IndexExpression) Position() Position       { return e.Pos }
+func (e *ConditionalExpression) Position() Position { return e.Pos }
+func (e *FunctionCall) Position() Position          { return e.Pos }
+
+func (e *Identifier) expression()            {}
+func (e *Integer) expression()               {}
+func (e *Float) expression()                 {}
+func (e *String) expression()                {}
+func (e *InterpolatedString) expression()    {}
+func (e *Boolean) expression()               {}
+func (e *Table) expression()                 {}
+func (e *Function) expression()              {}
+func (e *PrefixExpression) expression()      {}
+func (e *InfixExpression) expression()       {}
+func (e *DotExpression) expression()         {}
+func (e *IndexExpression) expression()       {}
+func (e *ConditionalExpression) expression() {}
+func (e *FunctionCall) expression()          {}
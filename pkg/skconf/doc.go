@@ -0,0 +1,31 @@
+// Package skconf is the public embedding API of the skill DSL compiler.
+//
+// A typical embedding loads sources from any fs.FS and compiles them for one
+// of the registered targets:
+//
+//	sources, err := skconf.LoadFS(os.DirFS("skills"))
+//	if err != nil {
+//		return err
+//	}
+//	result, diags := skconf.Compile(ctx, sources, skconf.Options{Target: "lua"})
+//	for _, d := range diags {
+//		log.Println(d)
+//	}
+//	if result == nil {
+//		return errors.New("compile failed")
+//	}
+//
+// The checked programs are available on the result as File.Program, in the
+// types of package github.com/hsoul/skconf/pkg/skconf/ast.
+//
+// # Compatibility
+//
+// This package and its ast sub-package follow semantic versioning: within a
+// major version, exported identifiers are not removed or renamed and
+// function signatures do not change. New fields, options, diagnostics,
+// targets and expression types may be added in minor versions, so callers
+// should use keyed struct literals and give their type switches a default
+// case. The text of diagnostics, of generated code and of File.Tree may
+// change in any release. Everything under internal/, including the syntax
+// tree the compiler itself works on, carries no compatibility guarantee.
+package skconf
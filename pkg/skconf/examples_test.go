@@ -0,0 +1,87 @@
+package skconf
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the outputs in examples/output that changed")
+
+// timestamp matches the generation time in the header of Lua outputs.
+var timestamp = regexp.MustCompile(`(?m)^-- \d{4}-\d\d-\d\d \d\d:\d\d:\d\d$`)
+
+// TestExamples compiles every example on its own, as the CLI does, for
+// every target and compares the outputs with examples/output: those of the
+// default target at its top, those of the others in a directory named
+// after the target. Generation times are ignored.
+func TestExamples(t *testing.T) {
+	examples := os.DirFS("../../examples/dsl")
+	sources, err := LoadFS(examples, "*"+Ext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, target := range Targets() {
+		dir := "../../examples/output"
+		if target != DefaultTarget {
+			dir = filepath.Join(dir, target)
+		}
+		for _, src := range sources {
+			t.Run(target+"/"+src.Name, func(t *testing.T) {
+				result, diags := Compile(context.Background(), []Source{src}, Options{Target: target, ImportFS: examples})
+				if result == nil {
+					t.Fatal(diags)
+				}
+				for _, out := range result.Files[0].Outputs {
+					name := filepath.Join(dir, filepath.FromSlash(out.Name))
+					want, err := os.ReadFile(name)
+					if err != nil && !(*update && os.IsNotExist(err)) {
+						t.Fatal(err)
+					}
+					got, wantText := mask(out.Content), mask(want)
+					if got == wantText {
+						continue
+					}
+					if *update {
+						if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+							t.Fatal(err)
+						}
+						if err := os.WriteFile(name, out.Content, 0644); err != nil {
+							t.Fatal(err)
+						}
+						continue
+					}
+					line, gotLine, wantLine := firstDifference(got, wantText)
+					t.Errorf("%s differs at line %d:\ngot:  %s\nwant: %s\n(rerun with -update to accept the new output)", name, line, gotLine, wantLine)
+				}
+			})
+		}
+	}
+}
+
+func mask(content []byte) string {
+	return timestamp.ReplaceAllString(string(content), "-- <time>")
+}
+
+// firstDifference returns the number and text of the first line that
+// differs between got and want; a missing line reads as "<end of file>".
+func firstDifference(got, want string) (int, string, string) {
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := 0; ; i++ {
+		g, w := "<end of file>", "<end of file>"
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			return i + 1, g, w
+		}
+	}
+}
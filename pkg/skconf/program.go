@@ -0,0 +1,147 @@
+package skconf
+
+import (
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/lexer"
+	public "github.com/hsoul/skconf/pkg/skconf/ast"
+)
+
+// exportProgram builds the public tree of a checked program.
+func exportProgram(program *ast.Program) *public.Program {
+	out := &public.Program{}
+	for _, imp := range program.Imports {
+		path, _ := ast.QualifiedName(imp.Value)
+		out.Imports = append(out.Imports, &public.Import{Pos: position(imp.Pos()), Path: path, DSL: imp.Linked})
+	}
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.ConstStatement:
+			out.Consts = append(out.Consts, &public.Const{
+				Pos:   position(s.Pos()),
+				Name:  s.Name.Value,
+				Value: exportExpression(s.Value),
+				Doc:   s.Doc,
+			})
+		case *ast.EnumDef:
+			enum := &public.Enum{Pos: position(s.Pos()), Name: s.Name.Value, Doc: s.Doc}
+			for _, member := range s.Members {
+				m := &public.EnumMember{Pos: position(member.Pos()), Name: member.Name.Value, Doc: member.Doc}
+				if v, ok := member.Value.(*ast.Integer); ok {
+					m.Value = v.Value
+				}
+				enum.Members = append(enum.Members, m)
+			}
+			out.Enums = append(out.Enums, enum)
+		case *ast.SkillDef:
+			out.Skills = append(out.Skills, exportDefinition(s.Pos(), s.Name, s.Parent, s.Properties, s.MergedProperties(), s.Doc))
+		case *ast.StateDef:
+			out.States = append(out.States, exportDefinition(s.Pos(), s.Name, s.Parent, s.Properties, s.MergedProperties(), s.Doc))
+		case *ast.FunctionDef:
+			if s.Name != nil {
+				out.Functions = append(out.Functions, exportFunction(s))
+			}
+		}
+	}
+	return out
+}
+
+func exportDefinition(pos lexer.Position, name *ast.Identifier, parent ast.Expression, own, merged []*ast.PropertyDef, doc string) *public.Definition {
+	def := &public.Definition{Pos: position(pos), Name: name.Value, Doc: doc}
+	def.Parent, _ = ast.QualifiedName(parent)
+
+	declared := map[*ast.PropertyDef]bool{}
+	for _, prop := range own {
+		declared[prop] = true
+	}
+	for _, prop := range merged {
+		p := exportProperty(prop)
+		p.Inherited = !declared[prop]
+		def.Properties = append(def.Properties, p)
+	}
+	return def
+}
+
+func exportProperty(prop *ast.PropertyDef) *public.Property {
+	// The parser leaves the token of a property unset; it starts at its key,
+	// or at its value for a positional table entry.
+	pos := prop.Value.Pos()
+	if prop.Key != nil {
+		pos = prop.Key.Pos()
+	}
+	return &public.Property{
+		Pos:   position(pos),
+		Key:   exportExpression(prop.Key),
+		Value: exportExpression(prop.Value),
+		Doc:   prop.Doc,
+	}
+}
+
+func exportFunction(fn *ast.FunctionDef) *public.Function {
+	out := &public.Function{Pos: position(fn.Pos()), Returns: fn.ReturnType(), Doc: fn.Doc}
+	out.Name, _ = ast.QualifiedName(fn.Name)
+	for i, param := range fn.Parameters {
+		out.Parameters = append(out.Parameters, param.Value)
+		out.Types = append(out.Types, fn.ParamType(i))
+	}
+	return out
+}
+
+func exportExpressions(exps []ast.Expression) []public.Expression {
+	out := make([]public.Expression, len(exps))
+	for i, exp := range exps {
+		out[i] = exportExpression(exp)
+	}
+	return out
+}
+
+func exportExpression(exp ast.Expression) public.Expression {
+	if exp == nil {
+		return nil
+	}
+	pos := position(exp.Pos())
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		return &public.Identifier{Pos: pos, Name: e.Value}
+	case *ast.Integer:
+		return &public.Integer{Pos: pos, Value: e.Value}
+	case *ast.Float:
+		return &public.Float{Pos: pos, Value: e.Value}
+	case *ast.String:
+		return &public.String{Pos: pos, Value: e.Value}
+	case *ast.InterpolatedString:
+		return &public.InterpolatedString{Pos: pos, Parts: exportExpressions(e.Parts)}
+	case *ast.Boolean:
+		return &public.Boolean{Pos: pos, Value: e.Value}
+	case *ast.TableDef:
+		table := &public.Table{Pos: pos}
+		for _, prop := range e.Properties {
+			table.Properties = append(table.Properties, exportProperty(prop))
+		}
+		return table
+	case *ast.FunctionDef:
+		return exportFunction(e)
+	case *ast.PrefixExpression:
+		return &public.PrefixExpression{Pos: pos, Operator: e.Operator, Right: exportExpression(e.Right)}
+	case *ast.InfixExpression:
+		return &public.InfixExpression{Pos: pos, Left: exportExpression(e.Left), Operator: e.Operator, Right: exportExpression(e.Right)}
+	case *ast.DotExpression:
+		name, _ := ast.QualifiedName(e.Right)
+		return &public.DotExpression{Pos: pos, Left: exportExpression(e.Left), Name: name, Optional: e.Optional}
+	case *ast.IndexExpression:
+		return &public.IndexExpression{Pos: pos, Left: exportExpression(e.Left), Index: exportExpression(e.Index)}
+	case *ast.ConditionalExpression:
+		return &public.ConditionalExpression{
+			Pos:         pos,
+			Condition:   exportExpression(e.Condition),
+			Consequence: exportExpression(e.Consequence),
+			Alternative: exportExpression(e.Alternative),
+		}
+	case *ast.FunctionCall:
+		return &public.FunctionCall{Pos: pos, Function: exportExpression(e.Function), Arguments: exportExpressions(e.Arguments)}
+	}
+	return nil
+}
+
+func position(pos lexer.Position) public.Position {
+	return public.Position{Line: pos.Line, Column: pos.Column}
+}
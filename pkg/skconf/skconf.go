@@ -0,0 +1,260 @@
+package skconf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/hsoul/skconf/internal/ast"
+	"github.com/hsoul/skconf/internal/diag"
+	"github.com/hsoul/skconf/internal/generator"
+	_ "github.com/hsoul/skconf/internal/generator/languages/csharp"
+	_ "github.com/hsoul/skconf/internal/generator/languages/golang"
+	_ "github.com/hsoul/skconf/internal/generator/languages/json"
+	"github.com/hsoul/skconf/internal/generator/languages/lua"
+	_ "github.com/hsoul/skconf/internal/generator/languages/ts"
+	"github.com/hsoul/skconf/internal/lexer"
+	"github.com/hsoul/skconf/internal/manifest"
+	"github.com/hsoul/skconf/internal/sema"
+	"github.com/hsoul/skconf/internal/syntax"
+	public "github.com/hsoul/skconf/pkg/skconf/ast"
+)
+
+type (
+	Diagnostic = diag.Diagnostic
+	Note       = diag.Note
+	Severity   = diag.Severity
+	Manifest   = manifest.Manifest
+)
+
+const (
+	SeverityError   = diag.Error
+	SeverityWarning = diag.Warning
+	SeverityInfo    = diag.Info
+)
+
+// DefaultTarget is used when Options.Target is empty.
+const DefaultTarget = lua.Language
+
+type Options struct {
+	// Target selects the code generator, see Targets. Empty means
+	// DefaultTarget.
+	Target string
+
+	// TargetOptions are passed to the selected generator; unknown keys are
+	// reported as errors. See the generator packages for the keys each
+	// target understands.
+	TargetOptions map[string]string
+
+	// Manifest describes the host API (modules such as UE and UF and the
+	// signatures of their functions), its enums, and the base units
+	// quantities such as 1.5s compile to. Typed targets derive their host interfaces from it;
+	// without one they fall back to the calls the sources make.
+	Manifest *Manifest
+
+	// ImportFS, if non-nil, is where imports that name none of the sources
+	// are looked up: import lib.common reads lib/common.dsl. Such files
+	// provide their declarations to the importing sources but generate no
+	// output. Imports found in neither place, such as host modules, are left
+	// to the target.
+	ImportFS fs.FS
+
+	// LoopBudget, if positive, bounds the passes of loops in the generated
+	// code: a while, a repeat or a for not known to end after a fixed
+	// number of passes raises an error naming its source line once it
+	// starts pass LoopBudget+1. Range loops and counting loops such as
+	// for var i = 0; i < 10; i += 1 are not instrumented.
+	LoopBudget int
+
+	// Trace, if non-nil, receives the lexer token stream and parser errors
+	// as they are produced. It is meant for debugging the compiler itself.
+	Trace io.Writer
+}
+
+// Output kinds.
+const (
+	KindCode        = string(generator.Code)
+	KindSourceMap   = string(generator.SourceMap)
+	KindExportIndex = string(generator.ExportIndex)
+	KindTypeStubs   = string(generator.TypeStubs)
+)
+
+// Output is one generated file. Name is a slash separated path relative to
+// the output directory and Kind is one of the Kind constants.
+type Output struct {
+	Name    string
+	Kind    string
+	Content []byte
+}
+
+// File is the compilation result of one Source.
+type File struct {
+	Source  string
+	Program *public.Program
+	Outputs []Output
+
+	program *ast.Program
+}
+
+// Tree renders the compiler's own syntax tree of the file as indented
+// text, as the CLI writes it to ast_tree.dot. The text is meant for people
+// and may change in any release; programs should read Program instead.
+func (f *File) Tree() string {
+	return ast.PrintTree(f.program)
+}
+
+type Result struct {
+	Files []*File
+}
+
+// ParseManifest decodes a JSON API manifest, see Options.Manifest.
+func ParseManifest(data []byte) (*Manifest, error) {
+	return manifest.Parse(data)
+}
+
+// Targets returns the names of the registered code generators.
+func Targets() []string {
+	return generator.Languages()
+}
+
+// Compile parses and checks every source and generates code for the
+// selected target. Sources can use the consts, enums, templates and
+// functions of the sources they import and extend their skills and states.
+// The generators, and File.Program, see consts, enum members and constant
+// expressions folded to literals, skills and states with their inherited
+// and template properties merged, the imported functions a source calls
+// copied into it, and quantities such as 1.5s as plain numbers in the base
+// units of the manifest.
+// The result is nil if any error diagnostic was reported or ctx was
+// cancelled; warnings are returned alongside a successful result.
+func Compile(ctx context.Context, sources []Source, opts Options) (*Result, []Diagnostic) {
+	target := opts.Target
+	if target == "" {
+		target = DefaultTarget
+	}
+	if !generator.Has(target) {
+		return nil, []Diagnostic{{
+			Severity: diag.Error,
+			Message:  fmt.Sprintf("unsupported target %q (available: %s)", target, strings.Join(Targets(), ", ")),
+		}}
+	}
+
+	var diags []Diagnostic
+	result := &Result{}
+
+	for _, src := range sources {
+		if err := ctx.Err(); err != nil {
+			return nil, append(diags, Diagnostic{Severity: diag.Error, Message: err.Error()})
+		}
+
+		program, parseDiags := parse(src, opts)
+		diags = append(diags, parseDiags...)
+		if program == nil {
+			continue
+		}
+		result.Files = append(result.Files, &File{Source: src.Name, program: program})
+	}
+
+	files := make([]sema.File, len(result.Files))
+	for i, file := range result.Files {
+		files[i] = sema.File{Name: file.Source, Program: file.program}
+	}
+	files, importDiags := loadImports(files, opts)
+	diags = append(diags, importDiags...)
+
+	if diag.HasErrors(diags) {
+		return nil, diags
+	}
+
+	diags = append(diags, sema.Check(files, opts.Manifest)...)
+	if diag.HasErrors(diags) {
+		return nil, diags
+	}
+
+	for _, file := range result.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, append(diags, Diagnostic{Severity: diag.Error, Message: err.Error()})
+		}
+
+		file.Program = exportProgram(file.program)
+
+		gen, err := generator.New(target, opts.TargetOptions)
+		if err != nil {
+			return nil, append(diags, Diagnostic{Severity: diag.Error, Message: err.Error()})
+		}
+
+		artifacts, genDiags := gen.Generate(&generator.Unit{
+			Name:    strings.TrimSuffix(file.Source, path.Ext(file.Source)),
+			File:    file.Source,
+			Program: file.program,
+
+			Manifest:   opts.Manifest,
+			LoopBudget: opts.LoopBudget,
+		})
+		diags = append(diags, genDiags...)
+
+		for _, artifact := range artifacts {
+			file.Outputs = append(file.Outputs, Output{
+				Name:    artifact.Name,
+				Kind:    string(artifact.Kind),
+				Content: artifact.Content,
+			})
+		}
+	}
+
+	if diag.HasErrors(diags) {
+		return nil, diags
+	}
+
+	return result, diags
+}
+
+func parse(src Source, opts Options) (*ast.Program, []Diagnostic) {
+	l := lexer.New(string(src.Content))
+	l.SetTrace(opts.Trace)
+	p := syntax.New(l, src.Name)
+	program := p.ParseProgram()
+	return program, p.Diagnostics()
+}
+
+// loadImports adds to files the files they import, directly or not, from
+// opts.ImportFS.
+func loadImports(files []sema.File, opts Options) ([]sema.File, []Diagnostic) {
+	if opts.ImportFS == nil {
+		return files, nil
+	}
+
+	seen := map[string]bool{}
+	for _, f := range files {
+		seen[f.Name] = true
+	}
+
+	var diags []Diagnostic
+	for i := 0; i < len(files); i++ {
+		for _, imp := range files[i].Program.Imports {
+			path, ok := ast.QualifiedName(imp.Value)
+			if !ok {
+				continue
+			}
+			name := strings.ReplaceAll(path, ".", "/") + Ext
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			content, err := fs.ReadFile(opts.ImportFS, name)
+			if err != nil {
+				continue // not a DSL file, such as a host module
+			}
+			program, parseDiags := parse(Source{Name: name, Content: content}, opts)
+			diags = append(diags, parseDiags...)
+			if program != nil {
+				files = append(files, sema.File{Name: name, Program: program})
+			}
+		}
+	}
+	return files, diags
+}
@@ -0,0 +1,194 @@
+package skconf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hsoul/skconf/pkg/skconf/ast"
+)
+
+const testManifest = `{"modules": {"UE": {"functions": {
+	"Damage": {"params": [{"name": "target", "type": "Unit"}, {"name": "amount", "type": "float"}]}
+}}}}`
+
+func TestHostCallChecks(t *testing.T) {
+	m, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, call, want string
+	}{
+		{"missing", "UE.Missing(t)", "UE.Missing is not in the API manifest"},
+		{"arity", "UE.Damage(t)", "UE.Damage takes 2 arguments, got 1"},
+	}
+	for _, target := range Targets() {
+		for _, tt := range tests {
+			t.Run(target+"/"+tt.name, func(t *testing.T) {
+				src := "skill s {\n  tid = 1,\n  f = func(t) {\n    " + tt.call + "\n  },\n}\n"
+				result, diags := Compile(context.Background(), []Source{{Name: "s.dsl", Content: []byte(src)}}, Options{Target: target, Manifest: m})
+				if result != nil {
+					t.Fatal("compiled without errors")
+				}
+				if len(diags) != 1 || diags[0].Message != tt.want {
+					t.Fatalf("got %v, want one error %q", diags, tt.want)
+				}
+				if pos := diags[0].Pos; pos.Line != 4 || pos.Column != 8 { // at the function name
+					t.Errorf("reported at %d:%d, want 4:8", pos.Line, pos.Column)
+				}
+			})
+		}
+	}
+}
+
+// A call spanning lines is reported at its "(", not past its arguments.
+func TestCallPosition(t *testing.T) {
+	src := "skill s {\n  tid = 1,\n  v = UE.Rand(1,\n    2),\n}\n"
+	_, diags := Compile(context.Background(), []Source{{Name: "s.dsl", Content: []byte(src)}}, Options{Target: "json"})
+	if len(diags) != 1 {
+		t.Fatalf("got %v, want one error", diags)
+	}
+	if pos := diags[0].Pos; pos.Line != 3 || pos.Column != 14 {
+		t.Errorf("%s reported at %d:%d, want 3:14", diags[0].Message, pos.Line, pos.Column)
+	}
+	if want := "json: cannot evaluate UE.Rand(1, 2) statically"; diags[0].Message != want {
+		t.Errorf("got %q, want %q", diags[0].Message, want)
+	}
+}
+
+// Named functions are declarations with the types of their annotations,
+// documented by their doc comment, not values assigned in Init.
+func TestNamedFunctionSignature(t *testing.T) {
+	src := "--- Scales dmg.\nfunc scaled(dmg: float, ratio: float) -> float {\n    return dmg * ratio\n}\n"
+	tests := []struct {
+		target, doc, decl string
+	}{
+		{"go", "// Scales dmg.", "func scaled(host Host, dmg float64, ratio float64) float64 {"},
+		{"csharp", "/// <summary>Scales dmg.</summary>", "public static double scaled(IHost host, double dmg, double ratio)"},
+		{"ts", "/** Scales dmg. */", "export function scaled(host: Host, dmg: number, ratio: number): number {"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			result, diags := Compile(context.Background(), []Source{{Name: "s.dsl", Content: []byte(src)}}, Options{Target: tt.target})
+			if result == nil {
+				t.Fatal(diags)
+			}
+			code := string(result.Files[0].Outputs[0].Content)
+			lines := strings.Split(code, "\n")
+			for i, line := range lines {
+				if strings.TrimSpace(line) == tt.decl {
+					if i == 0 || strings.TrimSpace(lines[i-1]) != tt.doc {
+						t.Errorf("%q is not documented by %q", tt.decl, tt.doc)
+					}
+					return
+				}
+			}
+			t.Errorf("no %q in\n%s", tt.decl, code)
+		})
+	}
+}
+
+// Go fields set from members of the unit's enums, directly or through a
+// const, have the enum type; those of imported enums stay integers.
+func TestGoEnumFields(t *testing.T) {
+	sources := []Source{
+		{Name: "lib/common.dsl", Content: []byte("enum Mode { Melee, Ranged }\n")},
+		{Name: "s.dsl", Content: []byte("import lib.common\nenum Element { Fire, Ice }\nconst DEFAULT = Element.Fire\n" +
+			"skill s { tid = 1, element = Element.Ice, fallback = DEFAULT, mode = lib.common.Mode.Ranged, }\n")},
+	}
+	result, diags := Compile(context.Background(), sources, Options{Target: "go"})
+	if result == nil {
+		t.Fatal(diags)
+	}
+	code := string(result.Files[1].Outputs[0].Content)
+	for _, want := range []string{
+		"Element  Element", "Fallback Element", "Mode     int64",
+		"Element:  ElementIce,", "Fallback: ElementFire,", "Mode:     1,",
+	} {
+		if !strings.Contains(code, "\t"+want+"\n") {
+			t.Errorf("no %q in\n%s", want, code)
+		}
+	}
+}
+
+// With a loop budget, the while loop fails after its passes run out and
+// the counting loop, known to end, is left alone.
+func TestLoopBudget(t *testing.T) {
+	src := "func f() {\n  for var i = 0; i < 10; i += 1 {\n  }\n  while UE.Busy() {\n  }\n}\n"
+	for _, target := range Targets() {
+		if target == "json" { // no code
+			continue
+		}
+		t.Run(target, func(t *testing.T) {
+			result, diags := Compile(context.Background(), []Source{{Name: "s.dsl", Content: []byte(src)}}, Options{Target: target, LoopBudget: 100})
+			if result == nil {
+				t.Fatal(diags)
+			}
+			code := string(result.Files[0].Outputs[0].Content)
+			if strings.Count(code, "loop ran more than") != 1 || !strings.Contains(code, "s.dsl:4: loop ran more than 100 times") {
+				t.Errorf("got\n%s", code)
+			}
+		})
+	}
+}
+
+// Lua requires host modules, one per line; imported DSL files are copied
+// in and required by nothing.
+func TestLuaImports(t *testing.T) {
+	sources := []Source{
+		{Name: "lib/common.dsl", Content: []byte("const BASE = 1\n")},
+		{Name: "s.dsl", Content: []byte("import lib.common\nimport UE.CCC\nimport UF.Log\nvar x = lib.common.BASE\n")},
+	}
+	result, diags := Compile(context.Background(), sources, Options{})
+	if result == nil {
+		t.Fatal(diags)
+	}
+	code := string(result.Files[1].Outputs[0].Content)
+	if !strings.Contains(code, "\nrequire(\"UE.CCC\")\nrequire(\"UF.Log\")\n") || strings.Contains(code, "lib.common") {
+		t.Errorf("got\n%s", code)
+	}
+}
+
+// File.Program is the checked program: folded consts, numbered enum
+// members and merged properties.
+func TestProgram(t *testing.T) {
+	src := "const base = 2 * 50\nenum Element { Fire, Ice = 5, Holy }\n" +
+		"skill a { tid = 1, damage = base, }\nskill b extends a { tid = 2, el = Element.Holy, }\n"
+	result, diags := Compile(context.Background(), []Source{{Name: "s.dsl", Content: []byte(src)}}, Options{Target: "json"})
+	if result == nil {
+		t.Fatal(diags)
+	}
+	program := result.Files[0].Program
+
+	if v, ok := program.Consts[0].Value.(*ast.Integer); !ok || v.Value != 100 {
+		t.Errorf("const base = %#v, want 100", program.Consts[0].Value)
+	}
+	var members []string
+	for _, m := range program.Enums[0].Members {
+		members = append(members, fmt.Sprintf("%s=%d", m.Name, m.Value))
+	}
+	if got := strings.Join(members, " "); got != "Fire=0 Ice=5 Holy=6" {
+		t.Errorf("members %s", got)
+	}
+
+	b := program.Skills[1]
+	if b.Name != "b" || b.Parent != "a" {
+		t.Errorf("skill %s extends %q", b.Name, b.Parent)
+	}
+	var props []string
+	for _, p := range b.Properties {
+		key := p.Key.(*ast.Identifier).Name
+		if p.Inherited {
+			key += "(inherited)"
+		}
+		props = append(props, key)
+	}
+	if got := strings.Join(props, " "); got != "tid damage(inherited) el" {
+		t.Errorf("properties %s", got)
+	}
+	if p := b.Properties[2]; p.Pos.Line != 4 {
+		t.Errorf("el at line %d, want 4", p.Pos.Line)
+	}
+}
@@ -0,0 +1,65 @@
+package skconf
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// Ext is the file extension of DSL sources.
+const Ext = ".dsl"
+
+// Source is one DSL file. Name is used in diagnostics and to derive the
+// names of generated outputs.
+type Source struct {
+	Name    string
+	Content []byte
+}
+
+// LoadFS reads DSL sources from fsys. Without patterns every *.dsl file in
+// fsys is loaded; otherwise the files matching any of the fs.Glob patterns
+// are. Sources are returned sorted by name.
+func LoadFS(fsys fs.FS, patterns ...string) ([]Source, error) {
+	names := map[string]bool{}
+
+	if len(patterns) == 0 {
+		err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && path.Ext(name) == Ext {
+				names[name] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range matches {
+			names[name] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	sources := make([]Source, 0, len(sorted))
+	for _, name := range sorted {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, Source{Name: name, Content: content})
+	}
+	return sources, nil
+}